@@ -0,0 +1,350 @@
+package netmgr
+
+import (
+	"github.com/godbus/dbus/v5"
+
+	"github.com/nlepage/go-netmgr/internal/dbusext"
+	"github.com/nlepage/go-netmgr/internal/enums"
+)
+
+// AccessPointIface is the Wi-Fi Access Point interface.
+const AccessPointIface = "org.freedesktop.NetworkManager.AccessPoint"
+
+type (
+	// AccessPoint represents a Wi-Fi access point.
+	//
+	// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.AccessPoint.html for more information.
+	AccessPoint interface {
+		dbus.BusObject
+
+		// Properties
+
+		// Flags describes the capabilities of the access point.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.AccessPoint.html#gdbus-property-org-freedesktop-NetworkManager-AccessPoint.Flags for more information.
+		Flags() (AccessPointFlags, error)
+
+		// WpaFlags describes the access point's capabilities according to WPA (Wifi Protected Access).
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.AccessPoint.html#gdbus-property-org-freedesktop-NetworkManager-AccessPoint.WpaFlags for more information.
+		WpaFlags() (AccessPointSecurityFlags, error)
+
+		// RsnFlags describes the access point's capabilities according to the RSN (Robust Secure Network) protocol.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.AccessPoint.html#gdbus-property-org-freedesktop-NetworkManager-AccessPoint.RsnFlags for more information.
+		RsnFlags() (AccessPointSecurityFlags, error)
+
+		// Ssid is the Service Set Identifier identifying the access point.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.AccessPoint.html#gdbus-property-org-freedesktop-NetworkManager-AccessPoint.Ssid for more information.
+		Ssid() ([]byte, error)
+
+		// Frequency is the radio channel frequency in use by the access point, in MHz.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.AccessPoint.html#gdbus-property-org-freedesktop-NetworkManager-AccessPoint.Frequency for more information.
+		Frequency() (uint32, error)
+
+		// HwAddress is the hardware address (BSSID) of the access point.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.AccessPoint.html#gdbus-property-org-freedesktop-NetworkManager-AccessPoint.HwAddress for more information.
+		HwAddress() (string, error)
+
+		// Mode describes the operating mode of the access point.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.AccessPoint.html#gdbus-property-org-freedesktop-NetworkManager-AccessPoint.Mode for more information.
+		Mode() (Wifi80211Mode, error)
+
+		// MaxBitrate is the maximum bitrate this access point is capable of, in kilobits/second (Kb/s).
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.AccessPoint.html#gdbus-property-org-freedesktop-NetworkManager-AccessPoint.MaxBitrate for more information.
+		MaxBitrate() (uint32, error)
+
+		// Strength is the current signal quality of the access point, in percent.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.AccessPoint.html#gdbus-property-org-freedesktop-NetworkManager-AccessPoint.Strength for more information.
+		Strength() (uint8, error)
+	}
+
+	accessPoint struct {
+		dbusext.BusObject
+	}
+)
+
+var _ AccessPoint = (*accessPoint)(nil)
+
+// NewAccessPoint returns the AccessPoint from conn corresponding to path.
+func NewAccessPoint(conn *dbus.Conn, path dbus.ObjectPath) AccessPoint {
+	return &accessPoint{dbusext.NewBusObject(conn, BusName, path)}
+}
+
+// NewAccessPoints returns the slice of AccessPoint from conn corresponding to paths.
+func NewAccessPoints(conn *dbus.Conn, paths []dbus.ObjectPath) []AccessPoint {
+	accessPoints := make([]AccessPoint, len(paths))
+	for i, path := range paths {
+		accessPoints[i] = NewAccessPoint(conn, path)
+	}
+	return accessPoints
+}
+
+func (ap *accessPoint) Flags() (AccessPointFlags, error) {
+	flags, err := ap.GetUProperty(AccessPointIface + ".Flags")
+	return AccessPointFlags(flags), err
+}
+
+func (ap *accessPoint) WpaFlags() (AccessPointSecurityFlags, error) {
+	flags, err := ap.GetUProperty(AccessPointIface + ".WpaFlags")
+	return AccessPointSecurityFlags(flags), err
+}
+
+func (ap *accessPoint) RsnFlags() (AccessPointSecurityFlags, error) {
+	flags, err := ap.GetUProperty(AccessPointIface + ".RsnFlags")
+	return AccessPointSecurityFlags(flags), err
+}
+
+func (ap *accessPoint) Ssid() ([]byte, error) {
+	return ap.GetAYProperty(AccessPointIface + ".Ssid")
+}
+
+func (ap *accessPoint) Frequency() (uint32, error) {
+	return ap.GetUProperty(AccessPointIface + ".Frequency")
+}
+
+func (ap *accessPoint) HwAddress() (string, error) {
+	return ap.GetSProperty(AccessPointIface + ".HwAddress")
+}
+
+func (ap *accessPoint) Mode() (Wifi80211Mode, error) {
+	mode, err := ap.GetUProperty(AccessPointIface + ".Mode")
+	return Wifi80211Mode(mode), err
+}
+
+func (ap *accessPoint) MaxBitrate() (uint32, error) {
+	return ap.GetUProperty(AccessPointIface + ".MaxBitrate")
+}
+
+func (ap *accessPoint) Strength() (uint8, error) {
+	return ap.GetYProperty(AccessPointIface + ".Strength")
+}
+
+// AccessPointFlags are the 802.11 access point flags.
+//
+// See https://developer.gnome.org/NetworkManager/stable/nm-dbus-types.html#NM80211ApFlags for more information.
+type AccessPointFlags uint
+
+const (
+	// AccessPointFlagNone means access point has no special capabilities.
+	AccessPointFlagNone AccessPointFlags = 0
+
+	// AccessPointFlagPrivacy means access point requires authentication and encryption (usually means WEP).
+	AccessPointFlagPrivacy AccessPointFlags = 1 << (iota - 1)
+
+	// AccessPointFlagWps means access point supports some WPS method.
+	AccessPointFlagWps
+
+	// AccessPointFlagWpsPbc means access point supports push-button WPS.
+	AccessPointFlagWpsPbc
+
+	// AccessPointFlagWpsPin means access point supports PIN-based WPS.
+	AccessPointFlagWpsPin
+)
+
+var accessPointFlagsNames = []enums.Name{
+	{Value: uint(AccessPointFlagPrivacy), Name: "NM_802_11_AP_FLAGS_PRIVACY"},
+	{Value: uint(AccessPointFlagWps), Name: "NM_802_11_AP_FLAGS_WPS"},
+	{Value: uint(AccessPointFlagWpsPbc), Name: "NM_802_11_AP_FLAGS_WPS_PBC"},
+	{Value: uint(AccessPointFlagWpsPin), Name: "NM_802_11_AP_FLAGS_WPS_PIN"},
+}
+
+func (f AccessPointFlags) String() string {
+	return enums.FlagsString(uint(f), "NM_802_11_AP_FLAGS_NONE", accessPointFlagsNames)
+}
+
+// ParseAccessPointFlags returns the AccessPointFlags corresponding to s, as returned by AccessPointFlags.String.
+func ParseAccessPointFlags(s string) (AccessPointFlags, error) {
+	v, err := enums.ParseFlags("AccessPointFlags", s, "NM_802_11_AP_FLAGS_NONE", accessPointFlagsNames)
+	return AccessPointFlags(v), err
+}
+
+// Bits decomposes f into its single flags.
+func (f AccessPointFlags) Bits() []AccessPointFlags {
+	bits := enums.Bits(uint(f))
+	flags := make([]AccessPointFlags, len(bits))
+	for i, bit := range bits {
+		flags[i] = AccessPointFlags(bit)
+	}
+	return flags
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (f AccessPointFlags) MarshalText() ([]byte, error) {
+	return []byte(f.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (f *AccessPointFlags) UnmarshalText(text []byte) error {
+	v, err := ParseAccessPointFlags(string(text))
+	if err != nil {
+		return err
+	}
+	*f = v
+	return nil
+}
+
+// AccessPointSecurityFlags are the 802.11 access point security and authentication flags.
+//
+// See https://developer.gnome.org/NetworkManager/stable/nm-dbus-types.html#NM80211ApSecurityFlags for more information.
+type AccessPointSecurityFlags uint
+
+const (
+	// AccessPointSecurityNone means the access point has no special security requirements.
+	AccessPointSecurityNone AccessPointSecurityFlags = 0
+
+	// AccessPointSecurityPairWep40 means 40/64-bit WEP is supported for pairwise/unicast encryption.
+	AccessPointSecurityPairWep40 AccessPointSecurityFlags = 1 << (iota - 1)
+
+	// AccessPointSecurityPairWep104 means 104/128-bit WEP is supported for pairwise/unicast encryption.
+	AccessPointSecurityPairWep104
+
+	// AccessPointSecurityPairTkip means the TKIP cipher is supported for pairwise/unicast encryption.
+	AccessPointSecurityPairTkip
+
+	// AccessPointSecurityPairCcmp means the AES/CCMP cipher is supported for pairwise/unicast encryption.
+	AccessPointSecurityPairCcmp
+
+	// AccessPointSecurityGroupWep40 means 40/64-bit WEP is supported for group/broadcast encryption.
+	AccessPointSecurityGroupWep40
+
+	// AccessPointSecurityGroupWep104 means 104/128-bit WEP is supported for group/broadcast encryption.
+	AccessPointSecurityGroupWep104
+
+	// AccessPointSecurityGroupTkip means the TKIP cipher is supported for group/broadcast encryption.
+	AccessPointSecurityGroupTkip
+
+	// AccessPointSecurityGroupCcmp means the AES/CCMP cipher is supported for group/broadcast encryption.
+	AccessPointSecurityGroupCcmp
+
+	// AccessPointSecurityKeyMgmtPsk means WPA/RSN Pre-Shared Key encryption is supported.
+	AccessPointSecurityKeyMgmtPsk
+
+	// AccessPointSecurityKeyMgmt8021X means 802.1x authentication and key management is supported.
+	AccessPointSecurityKeyMgmt8021X
+
+	// AccessPointSecurityKeyMgmtSae means WPA/RSN Simultaneous Authentication of Equals is supported.
+	AccessPointSecurityKeyMgmtSae
+
+	// AccessPointSecurityKeyMgmtOwe means WPA/RSN Opportunistic Wireless Encryption is supported.
+	AccessPointSecurityKeyMgmtOwe
+
+	// AccessPointSecurityKeyMgmtOweTm means WPA/RSN Opportunistic Wireless Encryption transition mode is supported.
+	AccessPointSecurityKeyMgmtOweTm
+
+	// AccessPointSecurityKeyMgmtEapSuiteB192 means WPA3 Enterprise Suite-B 192 bit mode is supported.
+	AccessPointSecurityKeyMgmtEapSuiteB192
+)
+
+var accessPointSecurityFlagsNames = []enums.Name{
+	{Value: uint(AccessPointSecurityPairWep40), Name: "NM_802_11_AP_SEC_PAIR_WEP40"},
+	{Value: uint(AccessPointSecurityPairWep104), Name: "NM_802_11_AP_SEC_PAIR_WEP104"},
+	{Value: uint(AccessPointSecurityPairTkip), Name: "NM_802_11_AP_SEC_PAIR_TKIP"},
+	{Value: uint(AccessPointSecurityPairCcmp), Name: "NM_802_11_AP_SEC_PAIR_CCMP"},
+	{Value: uint(AccessPointSecurityGroupWep40), Name: "NM_802_11_AP_SEC_GROUP_WEP40"},
+	{Value: uint(AccessPointSecurityGroupWep104), Name: "NM_802_11_AP_SEC_GROUP_WEP104"},
+	{Value: uint(AccessPointSecurityGroupTkip), Name: "NM_802_11_AP_SEC_GROUP_TKIP"},
+	{Value: uint(AccessPointSecurityGroupCcmp), Name: "NM_802_11_AP_SEC_GROUP_CCMP"},
+	{Value: uint(AccessPointSecurityKeyMgmtPsk), Name: "NM_802_11_AP_SEC_KEY_MGMT_PSK"},
+	{Value: uint(AccessPointSecurityKeyMgmt8021X), Name: "NM_802_11_AP_SEC_KEY_MGMT_802_1X"},
+	{Value: uint(AccessPointSecurityKeyMgmtSae), Name: "NM_802_11_AP_SEC_KEY_MGMT_SAE"},
+	{Value: uint(AccessPointSecurityKeyMgmtOwe), Name: "NM_802_11_AP_SEC_KEY_MGMT_OWE"},
+	{Value: uint(AccessPointSecurityKeyMgmtOweTm), Name: "NM_802_11_AP_SEC_KEY_MGMT_OWE_TM"},
+	{Value: uint(AccessPointSecurityKeyMgmtEapSuiteB192), Name: "NM_802_11_AP_SEC_KEY_MGMT_EAP_SUITE_B_192"},
+}
+
+func (f AccessPointSecurityFlags) String() string {
+	return enums.FlagsString(uint(f), "NM_802_11_AP_SEC_NONE", accessPointSecurityFlagsNames)
+}
+
+// ParseAccessPointSecurityFlags returns the AccessPointSecurityFlags corresponding to s, as returned by AccessPointSecurityFlags.String.
+func ParseAccessPointSecurityFlags(s string) (AccessPointSecurityFlags, error) {
+	v, err := enums.ParseFlags("AccessPointSecurityFlags", s, "NM_802_11_AP_SEC_NONE", accessPointSecurityFlagsNames)
+	return AccessPointSecurityFlags(v), err
+}
+
+// Bits decomposes f into its single flags.
+func (f AccessPointSecurityFlags) Bits() []AccessPointSecurityFlags {
+	bits := enums.Bits(uint(f))
+	flags := make([]AccessPointSecurityFlags, len(bits))
+	for i, bit := range bits {
+		flags[i] = AccessPointSecurityFlags(bit)
+	}
+	return flags
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (f AccessPointSecurityFlags) MarshalText() ([]byte, error) {
+	return []byte(f.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (f *AccessPointSecurityFlags) UnmarshalText(text []byte) error {
+	v, err := ParseAccessPointSecurityFlags(string(text))
+	if err != nil {
+		return err
+	}
+	*f = v
+	return nil
+}
+
+// Wifi80211Mode indicates the 802.11 mode an access point or device is currently in.
+//
+// See https://developer.gnome.org/NetworkManager/stable/nm-dbus-types.html#NM80211Mode for more information.
+type Wifi80211Mode uint
+
+const (
+	// Wifi80211ModeUnknown means the device or access point mode is unknown.
+	Wifi80211ModeUnknown Wifi80211Mode = iota
+
+	// Wifi80211ModeAdhoc means for both devices and access point objects, indicates the object is part of an Ad-Hoc 802.11 network without a central coordinating access point.
+	Wifi80211ModeAdhoc
+
+	// Wifi80211ModeInfra means the device or access point is in infrastructure mode.
+	Wifi80211ModeInfra
+
+	// Wifi80211ModeAp means the device is an access point/hotspot.
+	Wifi80211ModeAp
+
+	// Wifi80211ModeMesh means the device is a 802.11s mesh point.
+	Wifi80211ModeMesh
+)
+
+var wifi80211ModeNames = []enums.Name{
+	{Value: uint(Wifi80211ModeUnknown), Name: "NM_802_11_MODE_UNKNOWN"},
+	{Value: uint(Wifi80211ModeAdhoc), Name: "NM_802_11_MODE_ADHOC"},
+	{Value: uint(Wifi80211ModeInfra), Name: "NM_802_11_MODE_INFRA"},
+	{Value: uint(Wifi80211ModeAp), Name: "NM_802_11_MODE_AP"},
+	{Value: uint(Wifi80211ModeMesh), Name: "NM_802_11_MODE_MESH"},
+}
+
+func (m Wifi80211Mode) String() string {
+	return enums.String(uint(m), wifi80211ModeNames)
+}
+
+// ParseWifi80211Mode returns the Wifi80211Mode named s, as returned by Wifi80211Mode.String.
+func ParseWifi80211Mode(s string) (Wifi80211Mode, error) {
+	v, err := enums.Parse("Wifi80211Mode", s, wifi80211ModeNames)
+	return Wifi80211Mode(v), err
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (m Wifi80211Mode) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (m *Wifi80211Mode) UnmarshalText(text []byte) error {
+	v, err := ParseWifi80211Mode(string(text))
+	if err != nil {
+		return err
+	}
+	*m = v
+	return nil
+}
@@ -0,0 +1,33 @@
+package netmgr
+
+// AdslDeviceIface is the Adsl Device interface.
+const AdslDeviceIface = "org.freedesktop.NetworkManager.Device.Adsl"
+
+type (
+	// AdslDevice represents an ADSL device.
+	//
+	// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Adsl.html for more information.
+	AdslDevice interface {
+		Device
+		isAdslDevice()
+
+		// Properties
+
+		// Carrier tells if the device has a carrier.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Adsl.html#gdbus-property-org-freedesktop-NetworkManager-Device-Adsl.Carrier for more information.
+		Carrier() (bool, error)
+	}
+
+	adslDevice struct {
+		device
+	}
+)
+
+var _ AdslDevice = (*adslDevice)(nil)
+
+func (*adslDevice) isAdslDevice() {}
+
+func (d *adslDevice) Carrier() (bool, error) {
+	return d.GetBProperty(AdslDeviceIface + ".Carrier")
+}
@@ -2,11 +2,13 @@
 package agtmgr
 
 import (
-	"strconv"
+	"context"
 
 	"github.com/godbus/dbus/v5"
 
 	"github.com/nlepage/go-netmgr/internal/dbusext"
+	"github.com/nlepage/go-netmgr/internal/enums"
+	netmgrutil "github.com/nlepage/go-netmgr/util"
 )
 
 // BusName of NetworkManager.
@@ -30,6 +32,14 @@ type (
 		Register(identifier string) error
 		RegisterWithCapabilities(identifier string, capabilities Capabilities) error
 		Unregister() error
+
+		// Helpers
+
+		// Serve exports agent, and registers it to NetworkManager with identifier and capabilities.
+		//
+		// The agent is registered again each time NetworkManager restarts.
+		// When ctx is done, the agent is unregistered and unexported, and Serve returns.
+		Serve(ctx context.Context, identifier string, capabilities Capabilities, agent SecretAgent) error
 	}
 
 	agentManager struct {
@@ -47,13 +57,13 @@ func New(conn *dbus.Conn) AgentManager {
 // System returns the Agent Manager from the system bus.
 //
 // It is equivalent to:
-//  conn, err := dbus.SystemBus()
+//  conn, err := netmgrutil.SystemBus()
 //  if err != nil {
 //      // Manage error
 //  }
-//  nm := agtmgr.New(conn)
+//  am := agtmgr.New(conn)
 func System() (AgentManager, error) {
-	conn, err := dbus.SystemBus()
+	conn, err := netmgrutil.SystemBus()
 	if err != nil {
 		return nil, err
 	}
@@ -119,12 +129,32 @@ const (
 	CapabilityVpnHints
 )
 
+var capabilitiesNames = []enums.Name{
+	{Value: uint(CapabilityNone), Name: "NM_SECRET_AGENT_CAPABILITY_NONE"},
+	{Value: uint(CapabilityVpnHints), Name: "NM_SECRET_AGENT_CAPABILITY_VPN_HINTS"},
+}
+
 func (c Capabilities) String() string {
-	switch c {
-	case CapabilityNone:
-		return "NM_SECRET_AGENT_CAPABILITY_NONE"
-	case CapabilityVpnHints:
-		return "NM_SECRET_AGENT_CAPABILITY_VPN_HINTS"
+	return enums.String(uint(c), capabilitiesNames)
+}
+
+// ParseCapabilities returns the Capabilities named s, as returned by Capabilities.String.
+func ParseCapabilities(s string) (Capabilities, error) {
+	v, err := enums.Parse("Capabilities", s, capabilitiesNames)
+	return Capabilities(v), err
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (c Capabilities) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (c *Capabilities) UnmarshalText(text []byte) error {
+	v, err := ParseCapabilities(string(text))
+	if err != nil {
+		return err
 	}
-	return strconv.Itoa(int(c))
+	*c = v
+	return nil
 }
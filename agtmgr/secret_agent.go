@@ -0,0 +1,322 @@
+package agtmgr
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+
+	"github.com/godbus/dbus/v5"
+
+	netmgr "github.com/nlepage/go-netmgr"
+	"github.com/nlepage/go-netmgr/internal/dbusext"
+	"github.com/nlepage/go-netmgr/internal/enums"
+)
+
+// SecretAgentIface is the SecretAgent interface.
+const SecretAgentIface = "org.freedesktop.NetworkManager.SecretAgent"
+
+// SecretAgentPath is the path at which NetworkManager calls secret agents.
+const SecretAgentPath = "/org/freedesktop/NetworkManager/SecretAgent"
+
+// Errors which may be returned by a SecretAgent, any other error is sent to NetworkManager as ErrFailed.
+var (
+	// ErrNotAuthorized means the caller is not authorized to call the agent.
+	ErrNotAuthorized = dbus.NewError(SecretAgentIface+".NotAuthorized", []interface{}{"not authorized"})
+
+	// ErrInvalidConnection means the connection is invalid.
+	ErrInvalidConnection = dbus.NewError(SecretAgentIface+".InvalidConnection", []interface{}{"invalid connection"})
+
+	// ErrUserCanceled means the user canceled the request.
+	ErrUserCanceled = dbus.NewError(SecretAgentIface+".UserCanceled", []interface{}{"user canceled"})
+
+	// ErrAgentCanceled means the agent canceled the request.
+	ErrAgentCanceled = dbus.NewError(SecretAgentIface+".AgentCanceled", []interface{}{"agent canceled"})
+
+	// ErrNoSecrets means the agent has no secrets for the connection.
+	ErrNoSecrets = dbus.NewError(SecretAgentIface+".NoSecrets", []interface{}{"no secrets"})
+
+	// ErrFailed means the request failed.
+	ErrFailed = dbus.NewError(SecretAgentIface+".Failed", []interface{}{"failed"})
+)
+
+type (
+	// SecretAgent is implemented by agents providing and saving network secrets.
+	//
+	// AgentManager.Serve exports a SecretAgent on D-Bus and registers it to NetworkManager.
+	//
+	// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.SecretAgent.html for more information.
+	SecretAgent interface {
+		// GetSecrets retrieves and returns stored secrets, if any, or asks the user for them, for settingName of connection.
+		//
+		// hints are the names of the secrets needed, or hints for VPN plugins.
+		// ctx is canceled when NetworkManager calls CancelGetSecrets for the request, or when Serve stops,
+		// returning its error then sends ErrAgentCanceled to NetworkManager.
+		// ErrUserCanceled should be returned only if the user refused to give the secrets.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.SecretAgent.html#gdbus-method-org-freedesktop-NetworkManager-SecretAgent.GetSecrets for more information.
+		GetSecrets(ctx context.Context, connection netmgr.ConnectionSettings, connectionPath dbus.ObjectPath, settingName string, hints []string, flags GetSecretsFlags) (netmgr.ConnectionSettings, error)
+
+		// CancelGetSecrets cancels a pending GetSecrets request.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.SecretAgent.html#gdbus-method-org-freedesktop-NetworkManager-SecretAgent.CancelGetSecrets for more information.
+		CancelGetSecrets(connectionPath dbus.ObjectPath, settingName string) error
+
+		// SaveSecrets saves the secrets of connection.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.SecretAgent.html#gdbus-method-org-freedesktop-NetworkManager-SecretAgent.SaveSecrets for more information.
+		SaveSecrets(connection netmgr.ConnectionSettings, connectionPath dbus.ObjectPath) error
+
+		// DeleteSecrets deletes the secrets of connection.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.SecretAgent.html#gdbus-method-org-freedesktop-NetworkManager-SecretAgent.DeleteSecrets for more information.
+		DeleteSecrets(connection netmgr.ConnectionSettings, connectionPath dbus.ObjectPath) error
+	}
+
+	// secretAgentObject is the object exported on D-Bus for a SecretAgent.
+	//
+	// Only the current owner of BusName is authorized to call it, as other peers could read or change the secrets.
+	secretAgentObject struct {
+		ctx     context.Context
+		agent   SecretAgent
+		pending map[secretsRequest]context.CancelFunc
+		owner   string
+		lck     sync.Mutex
+	}
+
+	secretsRequest struct {
+		connectionPath dbus.ObjectPath
+		settingName    string
+	}
+)
+
+func (am *agentManager) Serve(ctx context.Context, identifier string, capabilities Capabilities, agent SecretAgent) error {
+	obj := &secretAgentObject{
+		ctx:     ctx,
+		agent:   agent,
+		pending: make(map[secretsRequest]context.CancelFunc),
+	}
+	if err := am.Conn.Export(obj, SecretAgentPath, SecretAgentIface); err != nil {
+		return err
+	}
+	defer am.Conn.Export(nil, SecretAgentPath, SecretAgentIface)
+
+	bus := dbusext.NewBusObject(am.Conn, "org.freedesktop.DBus", "/org/freedesktop/DBus")
+	owners := make(chan []interface{})
+	if err := bus.BodySignalArg0("org.freedesktop.DBus", "NameOwnerChanged", BusName, owners, nil); err != nil {
+		return err
+	}
+	defer bus.RemoveSignal("org.freedesktop.DBus", "NameOwnerChanged", owners)
+
+	// the owner is read after subscribing to its changes, so that no change is missed
+	var owner string
+	if err := bus.CallAndStore("org.freedesktop.DBus.GetNameOwner", dbusext.Args{BusName}, dbusext.Args{&owner}); err != nil {
+		return err
+	}
+	obj.setOwner(owner)
+
+	if err := am.RegisterWithCapabilities(identifier, capabilities); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case body := <-owners:
+			if len(body) != 3 || body[0] != BusName {
+				continue
+			}
+			owner, _ := body[2].(string)
+			obj.setOwner(owner)
+			if owner == "" {
+				continue
+			}
+			// NetworkManager has (re)started and forgot about the agent
+			if err := am.RegisterWithCapabilities(identifier, capabilities); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return am.Unregister()
+		}
+	}
+}
+
+// Serve exports agent on the system bus, and registers it to NetworkManager with identifier and capabilities.
+//
+// The agent is registered again each time NetworkManager restarts.
+// Only NetworkManager is authorized to call the agent, the other callers get ErrNotAuthorized.
+// When ctx is done, the agent is unregistered and unexported, and Serve returns.
+func Serve(ctx context.Context, identifier string, capabilities Capabilities, agent SecretAgent) error {
+	am, err := System()
+	if err != nil {
+		return err
+	}
+	return am.Serve(ctx, identifier, capabilities, agent)
+}
+
+// setOwner sets the unique name of the owner of BusName, empty if NetworkManager is not running.
+func (o *secretAgentObject) setOwner(owner string) {
+	o.lck.Lock()
+	o.owner = owner
+	o.lck.Unlock()
+}
+
+// authorize returns ErrNotAuthorized unless sender is the current owner of BusName, as checked by libnm.
+func (o *secretAgentObject) authorize(sender dbus.Sender) *dbus.Error {
+	o.lck.Lock()
+	defer o.lck.Unlock()
+	if o.owner == "" || string(sender) != o.owner {
+		return ErrNotAuthorized
+	}
+	return nil
+}
+
+func (o *secretAgentObject) GetSecrets(sender dbus.Sender, connection map[string]map[string]dbus.Variant, connectionPath dbus.ObjectPath, settingName string, hints []string, flags uint32) (map[string]map[string]interface{}, *dbus.Error) {
+	if err := o.authorize(sender); err != nil {
+		return nil, err
+	}
+
+	req := secretsRequest{connectionPath, settingName}
+	ctx, cancel := context.WithCancel(o.ctx)
+	defer cancel()
+
+	o.lck.Lock()
+	o.pending[req] = cancel
+	o.lck.Unlock()
+	defer func() {
+		o.lck.Lock()
+		delete(o.pending, req)
+		o.lck.Unlock()
+	}()
+
+	secrets, err := o.agent.GetSecrets(ctx, decodeConnection(connection), connectionPath, settingName, hints, GetSecretsFlags(flags))
+	if err != nil {
+		if ctx.Err() != nil && errors.Is(err, ctx.Err()) {
+			// ctx is canceled by CancelGetSecrets or by Serve stopping, not by the user
+			return nil, ErrAgentCanceled
+		}
+		return nil, toDBusError(err)
+	}
+	if secrets == nil {
+		secrets = netmgr.ConnectionSettings{}
+	}
+	return secrets, nil
+}
+
+func (o *secretAgentObject) CancelGetSecrets(sender dbus.Sender, connectionPath dbus.ObjectPath, settingName string) *dbus.Error {
+	if err := o.authorize(sender); err != nil {
+		return err
+	}
+
+	o.lck.Lock()
+	if cancel, ok := o.pending[secretsRequest{connectionPath, settingName}]; ok {
+		cancel()
+	}
+	o.lck.Unlock()
+
+	return toDBusError(o.agent.CancelGetSecrets(connectionPath, settingName))
+}
+
+func (o *secretAgentObject) SaveSecrets(sender dbus.Sender, connection map[string]map[string]dbus.Variant, connectionPath dbus.ObjectPath) *dbus.Error {
+	if err := o.authorize(sender); err != nil {
+		return err
+	}
+	return toDBusError(o.agent.SaveSecrets(decodeConnection(connection), connectionPath))
+}
+
+func (o *secretAgentObject) DeleteSecrets(sender dbus.Sender, connection map[string]map[string]dbus.Variant, connectionPath dbus.ObjectPath) *dbus.Error {
+	if err := o.authorize(sender); err != nil {
+		return err
+	}
+	return toDBusError(o.agent.DeleteSecrets(decodeConnection(connection), connectionPath))
+}
+
+func decodeConnection(connection map[string]map[string]dbus.Variant) netmgr.ConnectionSettings {
+	settings := make(netmgr.ConnectionSettings, len(connection))
+	for name, setting := range connection {
+		settings[name] = dbusext.ASV2ASI(setting)
+	}
+	return settings
+}
+
+func toDBusError(err error) *dbus.Error {
+	if err == nil {
+		return nil
+	}
+	var dbusErr *dbus.Error
+	if errors.As(err, &dbusErr) && strings.HasPrefix(dbusErr.Name, SecretAgentIface+".") {
+		return dbusErr
+	}
+	return dbus.NewError(ErrFailed.Name, []interface{}{err.Error()})
+}
+
+// GetSecretsFlags modify the behavior of a GetSecrets request.
+//
+// See https://developer.gnome.org/NetworkManager/stable/nm-dbus-types.html#NMSecretAgentGetSecretsFlags for more information.
+type GetSecretsFlags uint
+
+const (
+	// GetSecretsFlagNone means no user interaction is allowed, only stored secrets may be returned.
+	GetSecretsFlagNone GetSecretsFlags = 0
+
+	// GetSecretsFlagAllowInteraction means the agent may prompt the user for secrets if needed.
+	GetSecretsFlagAllowInteraction GetSecretsFlags = 0x1
+
+	// GetSecretsFlagRequestNew means new secrets are requested, stored ones are likely wrong.
+	GetSecretsFlagRequestNew GetSecretsFlags = 0x2
+
+	// GetSecretsFlagUserRequested means the request was initiated by user action, the user should be prompted.
+	GetSecretsFlagUserRequested GetSecretsFlags = 0x4
+
+	// GetSecretsFlagWpsPbcActive means WPS push button configuration is active, the agent may say so to the user.
+	GetSecretsFlagWpsPbcActive GetSecretsFlags = 0x8
+
+	// GetSecretsFlagOnlySystem is internal to NetworkManager and never sent to agents.
+	GetSecretsFlagOnlySystem GetSecretsFlags = 0x80000000
+
+	// GetSecretsFlagNoErrors is internal to NetworkManager and never sent to agents.
+	GetSecretsFlagNoErrors GetSecretsFlags = 0x40000000
+)
+
+var getSecretsFlagsNames = []enums.Name{
+	{Value: uint(GetSecretsFlagAllowInteraction), Name: "NM_SECRET_AGENT_GET_SECRETS_FLAG_ALLOW_INTERACTION"},
+	{Value: uint(GetSecretsFlagRequestNew), Name: "NM_SECRET_AGENT_GET_SECRETS_FLAG_REQUEST_NEW"},
+	{Value: uint(GetSecretsFlagUserRequested), Name: "NM_SECRET_AGENT_GET_SECRETS_FLAG_USER_REQUESTED"},
+	{Value: uint(GetSecretsFlagWpsPbcActive), Name: "NM_SECRET_AGENT_GET_SECRETS_FLAG_WPS_PBC_ACTIVE"},
+	{Value: uint(GetSecretsFlagOnlySystem), Name: "NM_SECRET_AGENT_GET_SECRETS_FLAG_ONLY_SYSTEM"},
+	{Value: uint(GetSecretsFlagNoErrors), Name: "NM_SECRET_AGENT_GET_SECRETS_FLAG_NO_ERRORS"},
+}
+
+func (f GetSecretsFlags) String() string {
+	return enums.FlagsString(uint(f), "NM_SECRET_AGENT_GET_SECRETS_FLAG_NONE", getSecretsFlagsNames)
+}
+
+// ParseGetSecretsFlags returns the GetSecretsFlags corresponding to s, as returned by GetSecretsFlags.String.
+func ParseGetSecretsFlags(s string) (GetSecretsFlags, error) {
+	v, err := enums.ParseFlags("GetSecretsFlags", s, "NM_SECRET_AGENT_GET_SECRETS_FLAG_NONE", getSecretsFlagsNames)
+	return GetSecretsFlags(v), err
+}
+
+// Bits decomposes f into its single flags.
+func (f GetSecretsFlags) Bits() []GetSecretsFlags {
+	bits := enums.Bits(uint(f))
+	flags := make([]GetSecretsFlags, len(bits))
+	for i, bit := range bits {
+		flags[i] = GetSecretsFlags(bit)
+	}
+	return flags
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (f GetSecretsFlags) MarshalText() ([]byte, error) {
+	return []byte(f.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (f *GetSecretsFlags) UnmarshalText(text []byte) error {
+	v, err := ParseGetSecretsFlags(string(text))
+	if err != nil {
+		return err
+	}
+	*f = v
+	return nil
+}
@@ -0,0 +1,166 @@
+package agtmgr
+
+import (
+	"bufio"
+	"context"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+
+	netmgr "github.com/nlepage/go-netmgr"
+	"github.com/nlepage/go-netmgr/internal/dbusext"
+	netmgrutil "github.com/nlepage/go-netmgr/util"
+)
+
+// blockingAgent is a SecretAgent whose GetSecrets blocks until its ctx is canceled.
+type blockingAgent struct {
+	started chan struct{}
+}
+
+func (a *blockingAgent) GetSecrets(ctx context.Context, connection netmgr.ConnectionSettings, connectionPath dbus.ObjectPath, settingName string, hints []string, flags GetSecretsFlags) (netmgr.ConnectionSettings, error) {
+	close(a.started)
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func (a *blockingAgent) CancelGetSecrets(connectionPath dbus.ObjectPath, settingName string) error {
+	return nil
+}
+
+func (a *blockingAgent) SaveSecrets(connection netmgr.ConnectionSettings, connectionPath dbus.ObjectPath) error {
+	return nil
+}
+
+func (a *blockingAgent) DeleteSecrets(connection netmgr.ConnectionSettings, connectionPath dbus.ObjectPath) error {
+	return nil
+}
+
+func TestCancelGetSecrets(t *testing.T) {
+	address := privateBusAddress(t)
+	agentConn, callerConn := dial(t, address), dial(t, address)
+
+	agent := &blockingAgent{started: make(chan struct{})}
+	obj := &secretAgentObject{
+		ctx:     context.Background(),
+		agent:   agent,
+		pending: make(map[secretsRequest]context.CancelFunc),
+		// the caller plays NetworkManager
+		owner: callerConn.Names()[0],
+	}
+	if err := agentConn.Export(obj, SecretAgentPath, SecretAgentIface); err != nil {
+		t.Fatal(err)
+	}
+
+	const connectionPath = dbus.ObjectPath("/org/freedesktop/NetworkManager/Settings/1")
+	agentObj := callerConn.Object(agentConn.Names()[0], SecretAgentPath)
+	call := agentObj.Go(SecretAgentIface+".GetSecrets", 0, nil, map[string]map[string]dbus.Variant{}, connectionPath, "802-11-wireless-security", []string{}, uint32(GetSecretsFlagAllowInteraction))
+
+	<-agent.started
+	if err := agentObj.Call(SecretAgentIface+".CancelGetSecrets", 0, connectionPath, "802-11-wireless-security").Err; err != nil {
+		t.Fatal(err)
+	}
+
+	err := (<-call.Done).Err
+	if dbusErr, ok := err.(dbus.Error); !ok || dbusErr.Name != ErrAgentCanceled.Name {
+		t.Errorf("expected %s, got %v", ErrAgentCanceled.Name, err)
+	}
+}
+
+func TestGetSecretsNotAuthorized(t *testing.T) {
+	address := privateBusAddress(t)
+	agentConn, managerConn, otherConn := dial(t, address), dial(t, address), dial(t, address)
+
+	agent := &blockingAgent{started: make(chan struct{})}
+	obj := &secretAgentObject{
+		ctx:     context.Background(),
+		agent:   agent,
+		pending: make(map[secretsRequest]context.CancelFunc),
+		owner:   managerConn.Names()[0],
+	}
+	if err := agentConn.Export(obj, SecretAgentPath, SecretAgentIface); err != nil {
+		t.Fatal(err)
+	}
+
+	const connectionPath = dbus.ObjectPath("/org/freedesktop/NetworkManager/Settings/1")
+	err := otherConn.Object(agentConn.Names()[0], SecretAgentPath).Call(SecretAgentIface+".GetSecrets", 0, map[string]map[string]dbus.Variant{}, connectionPath, "802-11-wireless-security", []string{}, uint32(GetSecretsFlagNone)).Err
+	if dbusErr, ok := err.(dbus.Error); !ok || dbusErr.Name != ErrNotAuthorized.Name {
+		t.Errorf("expected %s, got %v", ErrNotAuthorized.Name, err)
+	}
+	select {
+	case <-agent.started:
+		t.Error("the agent was called by a peer which is not NetworkManager")
+	default:
+	}
+}
+
+// privateBusAddress starts a private dbus-daemon and returns its address, the test is skipped if dbus-daemon is unavailable.
+func privateBusAddress(t *testing.T) string {
+	daemon, err := exec.LookPath("dbus-daemon")
+	if err != nil {
+		t.Skip("dbus-daemon is unavailable")
+	}
+
+	cmd := exec.Command(daemon, "--session", "--nofork", "--print-address=1")
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cmd.Start(); err != nil {
+		t.Skipf("dbus-daemon could not be started: %v", err)
+	}
+	t.Cleanup(func() {
+		cmd.Process.Kill()
+		cmd.Wait()
+	})
+
+	address, err := bufio.NewReader(stdout).ReadString('\n')
+	if err != nil {
+		t.Skipf("dbus-daemon did not print its address: %v", err)
+	}
+	return strings.TrimSpace(address)
+}
+
+func dial(t *testing.T, address string) *dbus.Conn {
+	conn, err := dbus.Dial(address, netmgrutil.WithSignalDispatcher())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	if err := conn.Auth(nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := conn.Hello(); err != nil {
+		t.Fatal(err)
+	}
+	return conn
+}
+
+func TestNameOwnerChangedArg0(t *testing.T) {
+	address := privateBusAddress(t)
+	conn, otherConn := dial(t, address), dial(t, address)
+
+	bus := dbusext.NewBusObject(conn, "org.freedesktop.DBus", "/org/freedesktop/DBus")
+	owners := make(chan []interface{}, 2)
+	if err := bus.BodySignalArg0("org.freedesktop.DBus", "NameOwnerChanged", BusName, owners, nil); err != nil {
+		t.Fatal(err)
+	}
+	defer bus.RemoveSignal("org.freedesktop.DBus", "NameOwnerChanged", owners)
+
+	for _, name := range []string{"org.example.Other", BusName} {
+		if _, err := otherConn.RequestName(name, dbus.NameFlagDoNotQueue); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	select {
+	case body := <-owners:
+		if body[0] != BusName || body[2] != otherConn.Names()[0] {
+			t.Errorf("unexpected NameOwnerChanged %v", body)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("NameOwnerChanged was not received")
+	}
+}
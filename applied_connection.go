@@ -0,0 +1,168 @@
+package netmgr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/godbus/dbus/v5"
+
+	"github.com/nlepage/go-netmgr/internal/dbusext"
+	"github.com/nlepage/go-netmgr/internal/enums"
+)
+
+// EditAppliedConnectionAttempts is the maximum number of attempts of Device.EditAppliedConnection.
+const EditAppliedConnectionAttempts = 5
+
+// ErrVersionIDMismatch is returned by Device.Reapply when the applied connection changed since GetAppliedConnection.
+var ErrVersionIDMismatch = dbus.NewError(DeviceIface+".VersionIdMismatch", []interface{}{"version id mismatch"})
+
+// ConflictError is returned when an object kept changing between reading and updating it.
+type ConflictError struct {
+	// Path is the path of the object.
+	Path dbus.ObjectPath
+
+	// Attempts is the number of update attempts.
+	Attempts int
+
+	// Err is the error of the last attempt.
+	Err error
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("%s kept changing after %d update attempts: %v", e.Path, e.Attempts, e.Err)
+}
+
+func (e *ConflictError) Unwrap() error {
+	return e.Err
+}
+
+func (d *device) Reapply(connection ConnectionSettings, versionID uint64, flags DeviceReapplyFlags) error {
+	if connection == nil {
+		connection = ConnectionSettings{}
+	}
+	return d.CallAndStore(DeviceIface+".Reapply", dbusext.Args{connection, versionID, uint32(flags)}, nil)
+}
+
+func (d *device) GetAppliedConnection() (ConnectionSettings, uint64, error) {
+	var connection map[string]map[string]dbus.Variant
+	var versionID uint64
+	// no flags are defined for GetAppliedConnection
+	if err := d.CallAndStore(DeviceIface+".GetAppliedConnection", dbusext.Args{uint32(0)}, dbusext.Args{&connection, &versionID}); err != nil {
+		return nil, 0, err
+	}
+	return decodeConnectionSettings(connection), versionID, nil
+}
+
+func (d *device) EditAppliedConnection(ctx context.Context, flags DeviceReapplyFlags, edit func(ConnectionSettings) error) error {
+	var err error
+	for attempt := 0; attempt < EditAppliedConnectionAttempts; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		var connection ConnectionSettings
+		var versionID uint64
+		if connection, versionID, err = d.GetAppliedConnection(); err != nil {
+			return err
+		}
+		if err := edit(connection); err != nil {
+			return err
+		}
+
+		if err = d.Reapply(connection, versionID, flags); !isDBusError(err, ErrVersionIDMismatch) {
+			return err
+		}
+	}
+	return &ConflictError{d.Path(), EditAppliedConnectionAttempts, err}
+}
+
+// deprecatedIPProperties are the deprecated properties of the ipv4 and ipv6 settings, with the properties replacing them.
+var deprecatedIPProperties = map[string]string{
+	"addresses": "address-data",
+	"routes":    "route-data",
+}
+
+// decodeConnectionSettings returns the ConnectionSettings corresponding to the D-Bus representation connection.
+//
+// The deprecated properties of the ipv4 and ipv6 settings are dropped when the properties replacing them are present,
+// as NetworkManager ignores the replacing properties when the deprecated ones are sent back,
+// and the struct arrays of ipv6 cannot be encoded back to their signature once decoded.
+func decodeConnectionSettings(connection map[string]map[string]dbus.Variant) ConnectionSettings {
+	settings := make(ConnectionSettings, len(connection))
+	for name, setting := range connection {
+		values := dbusext.ASV2ASI(setting)
+		if name == "ipv4" || name == "ipv6" {
+			for deprecated, replacing := range deprecatedIPProperties {
+				if _, ok := values[replacing]; ok {
+					delete(values, deprecated)
+				}
+			}
+		}
+		settings[name] = values
+	}
+	return settings
+}
+
+// isDBusError tells if err is a D-Bus error with the same name as target.
+func isDBusError(err error, target *dbus.Error) bool {
+	// errors of method calls are returned by value
+	var dbusErr dbus.Error
+	if errors.As(err, &dbusErr) {
+		return dbusErr.Name == target.Name
+	}
+	var dbusErrPtr *dbus.Error
+	return errors.As(err, &dbusErrPtr) && dbusErrPtr.Name == target.Name
+}
+
+// DeviceReapplyFlags are the flags of Device.Reapply.
+//
+// See https://developer.gnome.org/NetworkManager/stable/nm-dbus-types.html#NMDeviceReapplyFlags for more information.
+type DeviceReapplyFlags uint
+
+const (
+	// DeviceReapplyFlagNone means no flag.
+	DeviceReapplyFlagNone DeviceReapplyFlags = 0
+
+	// DeviceReapplyFlagPreserveExternalIP keeps the IP addresses and routes configured externally on the device, since NetworkManager 1.42.
+	DeviceReapplyFlagPreserveExternalIP DeviceReapplyFlags = 1
+)
+
+var deviceReapplyFlagsNames = []enums.Name{
+	{Value: uint(DeviceReapplyFlagPreserveExternalIP), Name: "NM_DEVICE_REAPPLY_FLAGS_PRESERVE_EXTERNAL_IP"},
+}
+
+func (f DeviceReapplyFlags) String() string {
+	return enums.FlagsString(uint(f), "NM_DEVICE_REAPPLY_FLAGS_NONE", deviceReapplyFlagsNames)
+}
+
+// ParseDeviceReapplyFlags returns the DeviceReapplyFlags corresponding to s, as returned by DeviceReapplyFlags.String.
+func ParseDeviceReapplyFlags(s string) (DeviceReapplyFlags, error) {
+	v, err := enums.ParseFlags("DeviceReapplyFlags", s, "NM_DEVICE_REAPPLY_FLAGS_NONE", deviceReapplyFlagsNames)
+	return DeviceReapplyFlags(v), err
+}
+
+// Bits decomposes f into its single flags.
+func (f DeviceReapplyFlags) Bits() []DeviceReapplyFlags {
+	bits := enums.Bits(uint(f))
+	flags := make([]DeviceReapplyFlags, len(bits))
+	for i, bit := range bits {
+		flags[i] = DeviceReapplyFlags(bit)
+	}
+	return flags
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (f DeviceReapplyFlags) MarshalText() ([]byte, error) {
+	return []byte(f.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (f *DeviceReapplyFlags) UnmarshalText(text []byte) error {
+	v, err := ParseDeviceReapplyFlags(string(text))
+	if err != nil {
+		return err
+	}
+	*f = v
+	return nil
+}
@@ -0,0 +1,107 @@
+package netmgr
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/godbus/dbus/v5/prop"
+)
+
+// ipv6Address is an element of the deprecated addresses property of the ipv6 setting, of signature (ayuay).
+type ipv6Address struct {
+	Address []byte
+	Prefix  uint32
+	Gateway []byte
+}
+
+// ipv6Addresses is a value of the deprecated addresses property of the ipv6 setting.
+var ipv6Addresses = dbus.MakeVariant([]ipv6Address{{Address: make([]byte, 16), Prefix: 64, Gateway: make([]byte, 16)}})
+
+// fakeAppliedDevice implements the GetAppliedConnection and Reapply methods of a device,
+// the applied connection is changed concurrently by the first conflicts reapplies.
+type fakeAppliedDevice struct {
+	mtu       uint32
+	ipv6      map[string]dbus.Variant
+	versionID uint64
+	conflicts int
+	reapplies int
+}
+
+func (f *fakeAppliedDevice) GetAppliedConnection(flags uint32) (map[string]map[string]dbus.Variant, uint64, *dbus.Error) {
+	return map[string]map[string]dbus.Variant{
+		"connection":     {"id": dbus.MakeVariant("eth0")},
+		"802-3-ethernet": {"mtu": dbus.MakeVariant(f.mtu)},
+		"ipv6": {
+			"addresses":    ipv6Addresses,
+			"address-data": dbus.MakeVariant([]map[string]dbus.Variant{{"address": dbus.MakeVariant("::"), "prefix": dbus.MakeVariant(uint32(64))}}),
+		},
+	}, f.versionID, nil
+}
+
+func (f *fakeAppliedDevice) Reapply(connection map[string]map[string]dbus.Variant, versionID uint64, flags uint32) *dbus.Error {
+	f.reapplies++
+	if f.reapplies <= f.conflicts {
+		// simulates a concurrent change of the applied connection
+		f.versionID++
+	}
+	if versionID != f.versionID {
+		return ErrVersionIDMismatch
+	}
+	f.mtu = connection["802-3-ethernet"]["mtu"].Value().(uint32)
+	f.ipv6 = connection["ipv6"]
+	return nil
+}
+
+func TestEditAppliedConnection(t *testing.T) {
+	conn := privateBus(t)
+
+	const devicePath = dbus.ObjectPath(NetworkManagerPath + "/Devices/1")
+
+	export(t, conn, BusName, devicePath, map[string]map[string]*prop.Prop{
+		DeviceIface: {"DeviceType": {Value: uint32(DeviceTypeEthernet)}},
+	})
+	fake := &fakeAppliedDevice{mtu: 1500, versionID: 1, conflicts: 1}
+	if err := conn.Export(fake, devicePath, DeviceIface); err != nil {
+		t.Fatal(err)
+	}
+
+	d := NewDevice(conn, devicePath)
+
+	edits := 0
+	if err := d.EditAppliedConnection(context.Background(), DeviceReapplyFlagNone, func(connection ConnectionSettings) error {
+		edits++
+		connection["802-3-ethernet"]["mtu"] = uint32(9000)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if edits != 2 || fake.reapplies != 2 {
+		t.Errorf("expected 2 edits and reapplies, got %d and %d", edits, fake.reapplies)
+	}
+	if fake.mtu != 9000 {
+		t.Errorf("expected mtu 9000, got %d", fake.mtu)
+	}
+	// the deprecated addresses would be sent back as aav instead of a(ayuay)
+	if addresses, ok := fake.ipv6["addresses"]; ok {
+		t.Errorf("expected ipv6 addresses to be dropped, got %s", addresses.Signature())
+	}
+	if _, ok := fake.ipv6["address-data"]; !ok {
+		t.Error("expected ipv6 address-data to be reapplied")
+	}
+
+	fake.conflicts = fake.reapplies + EditAppliedConnectionAttempts
+	err := d.EditAppliedConnection(context.Background(), DeviceReapplyFlagNone, func(connection ConnectionSettings) error {
+		connection["802-3-ethernet"]["mtu"] = uint32(1500)
+		return nil
+	})
+	var conflictErr *ConflictError
+	if !errors.As(err, &conflictErr) || conflictErr.Path != devicePath || conflictErr.Attempts != EditAppliedConnectionAttempts || !isDBusError(err, ErrVersionIDMismatch) {
+		t.Errorf("expected a *ConflictError, got %v", err)
+	}
+	if fake.mtu != 9000 {
+		t.Errorf("expected mtu unchanged after conflict, got %d", fake.mtu)
+	}
+}
@@ -0,0 +1,110 @@
+package netmgr
+
+import "github.com/nlepage/go-netmgr/internal/enums"
+
+// BluetoothDeviceIface is the Bluetooth Device interface.
+const BluetoothDeviceIface = "org.freedesktop.NetworkManager.Device.Bluetooth"
+
+type (
+	// BluetoothDevice represents a Bluetooth device.
+	//
+	// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Bluetooth.html for more information.
+	BluetoothDevice interface {
+		Device
+		isBluetoothDevice()
+
+		// Properties
+
+		// HwAddress is the hardware address of the device.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Bluetooth.html#gdbus-property-org-freedesktop-NetworkManager-Device-Bluetooth.HwAddress for more information.
+		HwAddress() (string, error)
+
+		// Name is the name of the Bluetooth device.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Bluetooth.html#gdbus-property-org-freedesktop-NetworkManager-Device-Bluetooth.Name for more information.
+		Name() (string, error)
+
+		// BtCapabilities are the Bluetooth capabilities of the device.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Bluetooth.html#gdbus-property-org-freedesktop-NetworkManager-Device-Bluetooth.BtCapabilities for more information.
+		BtCapabilities() (BluetoothCapabilities, error)
+	}
+
+	bluetoothDevice struct {
+		device
+	}
+)
+
+var _ BluetoothDevice = (*bluetoothDevice)(nil)
+
+func (*bluetoothDevice) isBluetoothDevice() {}
+
+func (d *bluetoothDevice) HwAddress() (string, error) {
+	return d.GetSProperty(BluetoothDeviceIface + ".HwAddress")
+}
+
+func (d *bluetoothDevice) Name() (string, error) {
+	return d.GetSProperty(BluetoothDeviceIface + ".Name")
+}
+
+func (d *bluetoothDevice) BtCapabilities() (BluetoothCapabilities, error) {
+	capabilities, err := d.GetUProperty(BluetoothDeviceIface + ".BtCapabilities")
+	return BluetoothCapabilities(capabilities), err
+}
+
+// BluetoothCapabilities are the network capabilities of a Bluetooth device.
+//
+// See https://developer.gnome.org/NetworkManager/stable/nm-dbus-types.html#NMBluetoothCapabilities for more information.
+type BluetoothCapabilities uint
+
+const (
+	// BluetoothCapabilityNone means the device has no usable capabilities.
+	BluetoothCapabilityNone BluetoothCapabilities = 0
+
+	// BluetoothCapabilityDUN means the device provides Dial-Up Networking capability.
+	BluetoothCapabilityDUN BluetoothCapabilities = 1 << (iota - 1)
+
+	// BluetoothCapabilityNAP means the device provides Network Access Point capability.
+	BluetoothCapabilityNAP
+)
+
+var bluetoothCapabilitiesNames = []enums.Name{
+	{Value: uint(BluetoothCapabilityDUN), Name: "NM_BT_CAPABILITY_DUN"},
+	{Value: uint(BluetoothCapabilityNAP), Name: "NM_BT_CAPABILITY_NAP"},
+}
+
+func (c BluetoothCapabilities) String() string {
+	return enums.FlagsString(uint(c), "NM_BT_CAPABILITY_NONE", bluetoothCapabilitiesNames)
+}
+
+// ParseBluetoothCapabilities returns the BluetoothCapabilities corresponding to s, as returned by BluetoothCapabilities.String.
+func ParseBluetoothCapabilities(s string) (BluetoothCapabilities, error) {
+	v, err := enums.ParseFlags("BluetoothCapabilities", s, "NM_BT_CAPABILITY_NONE", bluetoothCapabilitiesNames)
+	return BluetoothCapabilities(v), err
+}
+
+// Bits decomposes c into its single flags.
+func (c BluetoothCapabilities) Bits() []BluetoothCapabilities {
+	bits := enums.Bits(uint(c))
+	capabilities := make([]BluetoothCapabilities, len(bits))
+	for i, bit := range bits {
+		capabilities[i] = BluetoothCapabilities(bit)
+	}
+	return capabilities
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (c BluetoothCapabilities) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (c *BluetoothCapabilities) UnmarshalText(text []byte) error {
+	v, err := ParseBluetoothCapabilities(string(text))
+	if err != nil {
+		return err
+	}
+	*c = v
+	return nil
+}
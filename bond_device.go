@@ -0,0 +1,85 @@
+package netmgr
+
+import (
+	"context"
+	"reflect"
+)
+
+// BondDeviceIface is the Bond Device interface.
+const BondDeviceIface = "org.freedesktop.NetworkManager.Device.Bond"
+
+type (
+	// BondDevice represents a bond controller device.
+	//
+	// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Bond.html for more information.
+	BondDevice interface {
+		Device
+		isBondDevice()
+
+		// Properties
+
+		// HwAddress is the hardware address of the device.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Bond.html#gdbus-property-org-freedesktop-NetworkManager-Device-Bond.HwAddress for more information.
+		HwAddress() (string, error)
+
+		// Carrier tells if the device has a carrier.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Bond.html#gdbus-property-org-freedesktop-NetworkManager-Device-Bond.Carrier for more information.
+		Carrier() (bool, error)
+
+		// Slaves are the devices enslaved to the bond device.
+		//
+		// Deprecated: Use Ports, Slaves is deprecated since NetworkManager 1.34.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Bond.html#gdbus-property-org-freedesktop-NetworkManager-Device-Bond.Slaves for more information.
+		Slaves() ([]Device, error)
+
+		// Ports are the devices enslaved to the bond device, read from Slaves for NetworkManager older than 1.34.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.html#gdbus-property-org-freedesktop-NetworkManager-Device.Ports for more information.
+		Ports() ([]Device, error)
+
+		// Properties changes
+
+		// CarrierChanged sends the new Carrier value to ch each time it changes.
+		CarrierChanged(ch chan<- bool) error
+
+		// Helpers
+
+		// WatchCarrier returns a channel receiving the current Carrier value, then each change, until ctx is done.
+		WatchCarrier(ctx context.Context) (<-chan bool, error)
+	}
+
+	bondDevice struct {
+		device
+	}
+)
+
+var _ BondDevice = (*bondDevice)(nil)
+
+func (*bondDevice) isBondDevice() {}
+
+func (d *bondDevice) HwAddress() (string, error) {
+	return d.GetSProperty(BondDeviceIface + ".HwAddress")
+}
+
+func (d *bondDevice) Carrier() (bool, error) {
+	return d.GetBProperty(BondDeviceIface + ".Carrier")
+}
+
+func (d *bondDevice) Slaves() ([]Device, error) {
+	return d.slaves(BondDeviceIface)
+}
+
+func (d *bondDevice) Ports() ([]Device, error) {
+	return d.ports(BondDeviceIface)
+}
+
+func (d *bondDevice) CarrierChanged(ch chan<- bool) error {
+	return d.PropertyChanged(BondDeviceIface, "Carrier", reflect.TypeOf(false), ch, nil)
+}
+
+func (d *bondDevice) WatchCarrier(ctx context.Context) (<-chan bool, error) {
+	return watchCarrier(ctx, &d.device, d.Carrier, d.CarrierChanged)
+}
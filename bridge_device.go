@@ -0,0 +1,85 @@
+package netmgr
+
+import (
+	"context"
+	"reflect"
+)
+
+// BridgeDeviceIface is the Bridge Device interface.
+const BridgeDeviceIface = "org.freedesktop.NetworkManager.Device.Bridge"
+
+type (
+	// BridgeDevice represents a bridge controller device.
+	//
+	// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Bridge.html for more information.
+	BridgeDevice interface {
+		Device
+		isBridgeDevice()
+
+		// Properties
+
+		// HwAddress is the hardware address of the device.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Bridge.html#gdbus-property-org-freedesktop-NetworkManager-Device-Bridge.HwAddress for more information.
+		HwAddress() (string, error)
+
+		// Carrier tells if the device has a carrier.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Bridge.html#gdbus-property-org-freedesktop-NetworkManager-Device-Bridge.Carrier for more information.
+		Carrier() (bool, error)
+
+		// Slaves are the devices enslaved to the bridge device.
+		//
+		// Deprecated: Use Ports, Slaves is deprecated since NetworkManager 1.34.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Bridge.html#gdbus-property-org-freedesktop-NetworkManager-Device-Bridge.Slaves for more information.
+		Slaves() ([]Device, error)
+
+		// Ports are the devices enslaved to the bridge device, read from Slaves for NetworkManager older than 1.34.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.html#gdbus-property-org-freedesktop-NetworkManager-Device.Ports for more information.
+		Ports() ([]Device, error)
+
+		// Properties changes
+
+		// CarrierChanged sends the new Carrier value to ch each time it changes.
+		CarrierChanged(ch chan<- bool) error
+
+		// Helpers
+
+		// WatchCarrier returns a channel receiving the current Carrier value, then each change, until ctx is done.
+		WatchCarrier(ctx context.Context) (<-chan bool, error)
+	}
+
+	bridgeDevice struct {
+		device
+	}
+)
+
+var _ BridgeDevice = (*bridgeDevice)(nil)
+
+func (*bridgeDevice) isBridgeDevice() {}
+
+func (d *bridgeDevice) HwAddress() (string, error) {
+	return d.GetSProperty(BridgeDeviceIface + ".HwAddress")
+}
+
+func (d *bridgeDevice) Carrier() (bool, error) {
+	return d.GetBProperty(BridgeDeviceIface + ".Carrier")
+}
+
+func (d *bridgeDevice) Slaves() ([]Device, error) {
+	return d.slaves(BridgeDeviceIface)
+}
+
+func (d *bridgeDevice) Ports() ([]Device, error) {
+	return d.ports(BridgeDeviceIface)
+}
+
+func (d *bridgeDevice) CarrierChanged(ch chan<- bool) error {
+	return d.PropertyChanged(BridgeDeviceIface, "Carrier", reflect.TypeOf(false), ch, nil)
+}
+
+func (d *bridgeDevice) WatchCarrier(ctx context.Context) (<-chan bool, error) {
+	return watchCarrier(ctx, &d.device, d.Carrier, d.CarrierChanged)
+}
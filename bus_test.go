@@ -0,0 +1,64 @@
+package netmgr
+
+import (
+	"bufio"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/godbus/dbus/v5/prop"
+
+	netmgrutil "github.com/nlepage/go-netmgr/util"
+)
+
+// privateBus starts a private dbus-daemon and returns a connection to it, the test is skipped if dbus-daemon is unavailable.
+func privateBus(t *testing.T) *dbus.Conn {
+	daemon, err := exec.LookPath("dbus-daemon")
+	if err != nil {
+		t.Skip("dbus-daemon is unavailable")
+	}
+
+	cmd := exec.Command(daemon, "--session", "--nofork", "--print-address=1")
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cmd.Start(); err != nil {
+		t.Skipf("dbus-daemon could not be started: %v", err)
+	}
+	t.Cleanup(func() {
+		cmd.Process.Kill()
+		cmd.Wait()
+	})
+
+	address, err := bufio.NewReader(stdout).ReadString('\n')
+	if err != nil {
+		t.Skipf("dbus-daemon did not print its address: %v", err)
+	}
+
+	conn, err := dbus.Dial(strings.TrimSpace(address), netmgrutil.WithSignalDispatcher())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	if err := conn.Auth(nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := conn.Hello(); err != nil {
+		t.Fatal(err)
+	}
+	return conn
+}
+
+// export exports props on path of conn, under the well-known name, which may already be owned by conn.
+func export(t *testing.T, conn *dbus.Conn, name string, path dbus.ObjectPath, props map[string]map[string]*prop.Prop) *prop.Properties {
+	if reply, err := conn.RequestName(name, dbus.NameFlagDoNotQueue); err != nil || (reply != dbus.RequestNameReplyPrimaryOwner && reply != dbus.RequestNameReplyAlreadyOwner) {
+		t.Fatalf("could not own %s: %v", name, err)
+	}
+	p, err := prop.Export(conn, path, props)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
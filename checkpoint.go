@@ -1,6 +1,12 @@
 package netmgr
 
 import (
+	"errors"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/godbus/dbus/v5"
 
 	"github.com/nlepage/go-netmgr/internal/dbusext"
@@ -9,6 +15,9 @@ import (
 // CheckpointIface is the Checkpoint interface.
 const CheckpointIface = "org.freedesktop.NetworkManager.Checkpoint"
 
+// ErrNoRollbackTimeout is returned by Checkpoint.TimeUntilRollback when the checkpoint has no rollback timeout.
+var ErrNoRollbackTimeout = errors.New("checkpoint has no rollback timeout")
+
 type (
 	// Checkpoint is a snapshot of NetworkManager state for a given device list.
 	//
@@ -18,13 +27,51 @@ type (
 
 		// Properties
 
-		// FIXME documentation
+		// Devices is the list of devices for which the checkpoint was created.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Checkpoint.html#gdbus-property-org-freedesktop-NetworkManager-Checkpoint.Devices for more information.
 		Devices() ([]Device, error)
+
+		// Created is the time the checkpoint was created.
+		//
+		// NetworkManager gives it in CLOCK_BOOTTIME, it is converted to wall clock time using /proc/uptime,
+		// so it is only supported on Linux.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Checkpoint.html#gdbus-property-org-freedesktop-NetworkManager-Checkpoint.Created for more information.
+		Created() (time.Time, error)
+
+		// RollbackTimeout is the timeout for the automatic rollback, 0 if the rollback is disabled.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Checkpoint.html#gdbus-property-org-freedesktop-NetworkManager-Checkpoint.RollbackTimeout for more information.
+		RollbackTimeout() (time.Duration, error)
+
+		// Helpers
+
+		// TimeUntilRollback is the time left before the automatic rollback, ErrNoRollbackTimeout is returned if the rollback is disabled.
+		//
+		// As Created, it depends on /proc/uptime and is only supported on Linux.
+		TimeUntilRollback() (time.Duration, error)
 	}
 
 	checkpoint struct {
 		dbusext.BusObject
 	}
+
+	// DeviceRollbackResult is the result of a checkpoint rollback for a device.
+	DeviceRollbackResult struct {
+		// Device is the device which was rolled back.
+		Device Device
+
+		// Interface is the interface name of Device, read on a best-effort basis,
+		// it is empty if it could not be read, for example because the device no longer exists.
+		Interface string
+
+		// Result is the result of the rollback.
+		Result RollbackResult
+	}
+
+	// RollbackResults are the results of a checkpoint rollback, keyed by device object path.
+	RollbackResults map[dbus.ObjectPath]DeviceRollbackResult
 )
 
 var _ Checkpoint = (*checkpoint)(nil)
@@ -50,3 +97,75 @@ func (c *checkpoint) Devices() ([]Device, error) {
 	}
 	return NewDevices(c.Conn, paths), nil
 }
+
+func (c *checkpoint) Created() (time.Time, error) {
+	created, err := c.created()
+	if err != nil {
+		return time.Time{}, err
+	}
+	now, err := bootTime()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Now().Add(created - now), nil
+}
+
+func (c *checkpoint) created() (time.Duration, error) {
+	created, err := c.GetXProperty(CheckpointIface + ".Created")
+	return time.Duration(created) * time.Millisecond, err
+}
+
+func (c *checkpoint) RollbackTimeout() (time.Duration, error) {
+	timeout, err := c.GetUProperty(CheckpointIface + ".RollbackTimeout")
+	return time.Duration(timeout) * time.Second, err
+}
+
+func (c *checkpoint) TimeUntilRollback() (time.Duration, error) {
+	timeout, err := c.RollbackTimeout()
+	if err != nil {
+		return 0, err
+	}
+	if timeout == 0 {
+		return 0, ErrNoRollbackTimeout
+	}
+	created, err := c.created()
+	if err != nil {
+		return 0, err
+	}
+	now, err := bootTime()
+	if err != nil {
+		return 0, err
+	}
+	if left := created + timeout - now; left > 0 {
+		return left, nil
+	}
+	return 0, nil
+}
+
+// bootTime returns the time elapsed since boot, including suspend, as CLOCK_BOOTTIME does.
+func bootTime() (time.Duration, error) {
+	b, err := ioutil.ReadFile("/proc/uptime")
+	if err != nil {
+		return 0, err
+	}
+	fields := strings.Fields(string(b))
+	if len(fields) == 0 {
+		return 0, errors.New("unexpected /proc/uptime content")
+	}
+	uptime, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(uptime * float64(time.Second)), nil
+}
+
+func newRollbackResults(conn *dbus.Conn, results map[dbus.ObjectPath]RollbackResult) RollbackResults {
+	rollbackResults := make(RollbackResults, len(results))
+	for path, result := range results {
+		d := NewDevice(conn, path)
+		// the device may no longer exist, its interface name is left empty then
+		iface, _ := d.Interface()
+		rollbackResults[path] = DeviceRollbackResult{d, iface, result}
+	}
+	return rollbackResults
+}
@@ -0,0 +1,131 @@
+package netmgr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DefaultCheckpointRollbackTimeout is the rollback timeout used by CheckpointTransaction when none is given.
+const DefaultCheckpointRollbackTimeout = 30 * time.Second
+
+// ErrConnectivityLost is returned by CheckpointTransaction when the connectivity check failed after the changes.
+var ErrConnectivityLost = errors.New("connectivity lost")
+
+// CheckpointTransactionOptions are the options of CheckpointTransaction.
+type CheckpointTransactionOptions struct {
+	// RollbackTimeout is the rollback timeout of the checkpoint, it is kept extended while the transaction runs.
+	// Defaults to DefaultCheckpointRollbackTimeout, it is rounded up to the second.
+	RollbackTimeout time.Duration
+
+	// Flags are the flags used to create the checkpoint.
+	Flags CheckpointCreateFlags
+
+	// CheckConnectivity enables calling CheckConnectivity after the changes, which are rolled back if the connectivity is not full.
+	CheckConnectivity bool
+
+	// Probe is an optional custom connectivity check called after the changes, which are rolled back if it returns an error.
+	Probe func(ctx context.Context) error
+}
+
+func (nm *networkManager) CheckpointTransaction(ctx context.Context, devices []interface{}, options CheckpointTransactionOptions, fn func(ctx context.Context) error) (RollbackResults, error) {
+	timeout := options.RollbackTimeout
+	if timeout <= 0 {
+		timeout = DefaultCheckpointRollbackTimeout
+	}
+	timeoutSecs := uint((timeout + time.Second - 1) / time.Second)
+
+	checkpoint, err := nm.CheckpointCreate(devices, timeoutSecs, options.Flags)
+	if err != nil {
+		return nil, err
+	}
+
+	// fn runs with a context canceled if the checkpoint could not be kept alive, as it may be rolled back at any time
+	fnCtx, cancelFn := context.WithCancel(ctx)
+	defer cancelFn()
+
+	keepAliveErr := make(chan error, 1)
+	keepAliveCtx, stopKeepAlive := context.WithCancel(ctx)
+	go func() {
+		err := nm.keepCheckpointAlive(keepAliveCtx, checkpoint, timeoutSecs)
+		if err != nil {
+			cancelFn()
+		}
+		keepAliveErr <- err
+	}()
+
+	err = fn(fnCtx)
+	if err == nil {
+		err = fnCtx.Err()
+	}
+	if err == nil {
+		err = nm.checkTransaction(fnCtx, options)
+	}
+
+	stopKeepAlive()
+	if kaErr := <-keepAliveErr; kaErr != nil {
+		// the error of fn is most likely caused by the cancellation of its context
+		err = fmt.Errorf("checkpoint could not be kept alive: %w", kaErr)
+	}
+
+	if err == nil {
+		return nil, nm.CheckpointDestroy(checkpoint)
+	}
+
+	results, rbErr := nm.CheckpointRollback(checkpoint)
+	if rbErr != nil {
+		return nil, fmt.Errorf("%w (rollback failed: %v)", err, rbErr)
+	}
+	return results, err
+}
+
+// CheckpointTransaction creates a checkpoint for devices, and calls fn.
+//
+// If fn succeeds, and the optional connectivity checks pass, the changes are committed by destroying the checkpoint.
+// Otherwise the checkpoint is rolled back, and the rollback results are returned along with the error.
+//
+// While fn and the checks run, the rollback timeout of the checkpoint is kept extended.
+// If extending it failed, the context of fn is canceled, the checkpoint is rolled back if it still exists, and the keep-alive error is returned.
+func CheckpointTransaction(ctx context.Context, devices []interface{}, options CheckpointTransactionOptions, fn func(ctx context.Context) error) (RollbackResults, error) {
+	nm, err := System()
+	if err != nil {
+		return nil, err
+	}
+	return nm.CheckpointTransaction(ctx, devices, options, fn)
+}
+
+func (nm *networkManager) keepCheckpointAlive(ctx context.Context, checkpoint Checkpoint, timeoutSecs uint) error {
+	// adjust 3 times per timeout to survive a slow D-Bus round trip
+	ticker := time.NewTicker(time.Duration(timeoutSecs) * time.Second / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := nm.CheckpointAdjustRollbackTimeout(checkpoint, timeoutSecs); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (nm *networkManager) checkTransaction(ctx context.Context, options CheckpointTransactionOptions) error {
+	if options.CheckConnectivity {
+		connectivity, err := nm.CheckConnectivity()
+		if err != nil {
+			return err
+		}
+		if connectivity != ConnectivityFull {
+			return fmt.Errorf("%w: %s", ErrConnectivityLost, connectivity)
+		}
+	}
+	if options.Probe != nil {
+		if err := options.Probe(ctx); err != nil {
+			return fmt.Errorf("%w: %v", ErrConnectivityLost, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package netmgr
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// removedDevicePath is the path of a device rolled back by fakeCheckpoints, which no longer exists.
+const removedDevicePath = dbus.ObjectPath(NetworkManagerPath + "/Devices/1")
+
+var errCheckpointExpired = dbus.NewError(NetworkManagerInterface+".Failed", []interface{}{"checkpoint expired"})
+
+// fakeCheckpoints implements the checkpoint methods of NetworkManager, the rollback timeout of the checkpoint cannot be adjusted.
+type fakeCheckpoints struct {
+	rolledBack, destroyed bool
+}
+
+func (f *fakeCheckpoints) CheckpointCreate(devices []dbus.ObjectPath, rollbackTimeout, flags uint32) (dbus.ObjectPath, *dbus.Error) {
+	return NetworkManagerPath + "/Checkpoint/1", nil
+}
+
+func (f *fakeCheckpoints) CheckpointAdjustRollbackTimeout(checkpoint dbus.ObjectPath, addTimeout uint32) *dbus.Error {
+	return errCheckpointExpired
+}
+
+func (f *fakeCheckpoints) CheckpointRollback(checkpoint dbus.ObjectPath) (map[dbus.ObjectPath]uint32, *dbus.Error) {
+	f.rolledBack = true
+	return map[dbus.ObjectPath]uint32{removedDevicePath: uint32(RollbackResultErrNoDevice)}, nil
+}
+
+func (f *fakeCheckpoints) CheckpointDestroy(checkpoint dbus.ObjectPath) *dbus.Error {
+	f.destroyed = true
+	return nil
+}
+
+func TestCheckpointTransactionKeepAliveFailed(t *testing.T) {
+	conn := privateBus(t)
+
+	if reply, err := conn.RequestName(BusName, dbus.NameFlagDoNotQueue); err != nil || reply != dbus.RequestNameReplyPrimaryOwner {
+		t.Fatalf("could not own %s: %v", BusName, err)
+	}
+	fake := &fakeCheckpoints{}
+	if err := conn.Export(fake, NetworkManagerPath, NetworkManagerInterface); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	results, err := New(conn).CheckpointTransaction(ctx, nil, CheckpointTransactionOptions{RollbackTimeout: time.Second}, func(ctx context.Context) error {
+		// fn is interrupted by the failed keep-alive
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	if !isDBusError(err, errCheckpointExpired) {
+		t.Errorf("expected the keep-alive error, got %v", err)
+	}
+	if ctx.Err() != nil {
+		t.Error("fn was not canceled when the keep-alive failed")
+	}
+	if !fake.rolledBack || fake.destroyed {
+		t.Errorf("expected the checkpoint to be rolled back, rolled back %t, destroyed %t", fake.rolledBack, fake.destroyed)
+	}
+	if r, ok := results[removedDevicePath]; !ok || r.Result != RollbackResultErrNoDevice || r.Interface != "" {
+		t.Errorf("expected the removed device result keyed by path, got %+v", results)
+	}
+}
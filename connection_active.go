@@ -1,9 +1,13 @@
 package netmgr
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/godbus/dbus/v5"
 
 	"github.com/nlepage/go-netmgr/internal/dbusext"
+	"github.com/nlepage/go-netmgr/internal/enums"
 )
 
 // ConnectionActiveIface is the Active Connection interface.
@@ -18,15 +22,63 @@ type (
 
 		// Properties
 
+		// Connection is the connection profile of this active connection.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Connection.Active.html#gdbus-property-org-freedesktop-NetworkManager-Connection-Active.Connection for more information.
+		Connection() (SettingsConnection, error)
+
 		// Vpn indicates whether this active connection is also a VPN connection.
 		//
 		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Connection.Active.html#gdbus-property-org-freedesktop-NetworkManager-Connection-Active.Vpn for more information.
 		Vpn() (bool, error)
+
+		// Devices is the list of devices which are part of this active connection.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Connection.Active.html#gdbus-property-org-freedesktop-NetworkManager-Connection-Active.Devices for more information.
+		Devices() ([]Device, error)
+
+		// State is the state of this active connection.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Connection.Active.html#gdbus-property-org-freedesktop-NetworkManager-Connection-Active.State for more information.
+		State() (ActiveConnectionState, error)
+
+		// Signals
+
+		// StateChanged is emitted when the state of the active connection has changed.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Connection.Active.html#gdbus-signal-org-freedesktop-NetworkManager-Connection-Active.StateChanged for more information.
+		StateChanged(ch chan<- ActiveConnectionStateChange) error
+
+		// Helpers
+
+		// WaitActivated waits until the active connection is activated.
+		//
+		// If the activation fails, an *ActivationError is returned.
+		// If ctx is done before the activation finishes, ctx.Err() is returned.
+		WaitActivated(ctx context.Context) error
 	}
 
 	connectionActive struct {
 		dbusext.BusObject
 	}
+
+	// ActiveConnectionStateChange is the content of an active connection StateChanged signal.
+	ActiveConnectionStateChange struct {
+		State  ActiveConnectionState
+		Reason ActiveConnectionStateReason
+	}
+
+	// ActivationError is returned when an active connection fails to activate.
+	ActivationError struct {
+		// State is the state reached by the active connection.
+		State ActiveConnectionState
+
+		// Reason is the reason of the active connection state change.
+		Reason ActiveConnectionStateReason
+
+		// DeviceReason is the reason of the failure of the device, DeviceStateReasonNone if unknown.
+		DeviceReason DeviceStateReason
+	}
 )
 
 var _ ConnectionActive = (*connectionActive)(nil)
@@ -59,6 +111,246 @@ func NewConnectionActives(conn *dbus.Conn, paths []dbus.ObjectPath) ([]Connectio
 	return connectionActives, nil
 }
 
+func (ca *connectionActive) Connection() (SettingsConnection, error) {
+	path, err := ca.GetOProperty(ConnectionActiveIface + ".Connection")
+	if err != nil {
+		return nil, err
+	}
+	return NewSettingsConnection(ca.Conn, path), nil
+}
+
 func (ca *connectionActive) Vpn() (bool, error) {
 	return ca.GetBProperty(ConnectionActiveIface + ".Vpn")
 }
+
+func (ca *connectionActive) Devices() ([]Device, error) {
+	paths, err := ca.GetAOProperty(ConnectionActiveIface + ".Devices")
+	if err != nil {
+		return nil, err
+	}
+	return NewDevices(ca.Conn, paths), nil
+}
+
+func (ca *connectionActive) State() (ActiveConnectionState, error) {
+	state, err := ca.GetUProperty(ConnectionActiveIface + ".State")
+	return ActiveConnectionState(state), err
+}
+
+func (ca *connectionActive) StateChanged(ch chan<- ActiveConnectionStateChange) error {
+	return ca.BodySignal(ConnectionActiveIface, "StateChanged", ch, func(body []interface{}) ActiveConnectionStateChange {
+		var change ActiveConnectionStateChange
+		if len(body) == 2 {
+			state, _ := body[0].(uint32)
+			reason, _ := body[1].(uint32)
+			change = ActiveConnectionStateChange{ActiveConnectionState(state), ActiveConnectionStateReason(reason)}
+		}
+		return change
+	})
+}
+
+func (ca *connectionActive) WaitActivated(ctx context.Context) error {
+	changes := make(chan ActiveConnectionStateChange)
+	if err := ca.StateChanged(changes); err != nil {
+		return err
+	}
+	defer ca.RemoveSignal(ConnectionActiveIface, "StateChanged", changes)
+
+	// devices are watched beforehand, the active connection may disappear when it fails
+	devices, err := ca.Devices()
+	if err != nil {
+		return err
+	}
+	deviceChanges := make(chan DeviceStateChange)
+	for _, d := range devices {
+		if err := d.StateChanged(deviceChanges); err != nil {
+			return err
+		}
+		defer dbusext.RemoveSignal(ca.Conn, d.Path(), DeviceIface, "StateChanged", deviceChanges)
+	}
+
+	state, err := ca.State()
+	if err != nil {
+		return err
+	}
+	reason := ActiveConnectionStateReasonUnknown
+	deviceReason := DeviceStateReasonNone
+
+	for {
+		switch state {
+		case ActiveConnectionStateActivated:
+			return nil
+		case ActiveConnectionStateDeactivating, ActiveConnectionStateDeactivated:
+			if deviceReason == DeviceStateReasonNone && len(devices) != 0 {
+				if _, r, err := devices[0].StateReason(); err == nil {
+					deviceReason = r
+				}
+			}
+			return &ActivationError{state, reason, deviceReason}
+		}
+
+		select {
+		case change := <-changes:
+			state, reason = change.State, change.Reason
+		case change := <-deviceChanges:
+			if change.NewState == DeviceStateFailed {
+				deviceReason = change.Reason
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (e *ActivationError) Error() string {
+	return fmt.Sprintf("activation failed: %s (device: %s)", e.Reason, e.DeviceReason)
+}
+
+// ActiveConnectionState values indicate the state of a connection to a specific network while it is starting, connected, or disconnecting from that network.
+//
+// See https://developer.gnome.org/NetworkManager/stable/nm-dbus-types.html#NMActiveConnectionState for more information.
+type ActiveConnectionState uint
+
+const (
+	// ActiveConnectionStateUnknown means the state of the connection is unknown.
+	ActiveConnectionStateUnknown ActiveConnectionState = iota
+
+	// ActiveConnectionStateActivating means a network connection is being prepared.
+	ActiveConnectionStateActivating
+
+	// ActiveConnectionStateActivated means there is a connection to the network.
+	ActiveConnectionStateActivated
+
+	// ActiveConnectionStateDeactivating means the network connection is being torn down and cleaned up.
+	ActiveConnectionStateDeactivating
+
+	// ActiveConnectionStateDeactivated means the network connection is disconnected and will be removed.
+	ActiveConnectionStateDeactivated
+)
+
+var activeConnectionStateNames = []enums.Name{
+	{Value: uint(ActiveConnectionStateUnknown), Name: "NM_ACTIVE_CONNECTION_STATE_UNKNOWN"},
+	{Value: uint(ActiveConnectionStateActivating), Name: "NM_ACTIVE_CONNECTION_STATE_ACTIVATING"},
+	{Value: uint(ActiveConnectionStateActivated), Name: "NM_ACTIVE_CONNECTION_STATE_ACTIVATED"},
+	{Value: uint(ActiveConnectionStateDeactivating), Name: "NM_ACTIVE_CONNECTION_STATE_DEACTIVATING"},
+	{Value: uint(ActiveConnectionStateDeactivated), Name: "NM_ACTIVE_CONNECTION_STATE_DEACTIVATED"},
+}
+
+func (s ActiveConnectionState) String() string {
+	return enums.String(uint(s), activeConnectionStateNames)
+}
+
+// ParseActiveConnectionState returns the ActiveConnectionState named s, as returned by ActiveConnectionState.String.
+func ParseActiveConnectionState(s string) (ActiveConnectionState, error) {
+	v, err := enums.Parse("ActiveConnectionState", s, activeConnectionStateNames)
+	return ActiveConnectionState(v), err
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s ActiveConnectionState) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *ActiveConnectionState) UnmarshalText(text []byte) error {
+	v, err := ParseActiveConnectionState(string(text))
+	if err != nil {
+		return err
+	}
+	*s = v
+	return nil
+}
+
+// ActiveConnectionStateReason values indicate the reason for active connection state change.
+//
+// See https://developer.gnome.org/NetworkManager/stable/nm-dbus-types.html#NMActiveConnectionStateReason for more information.
+type ActiveConnectionStateReason uint
+
+const (
+	// ActiveConnectionStateReasonUnknown means the reason for the active connection state change is unknown.
+	ActiveConnectionStateReasonUnknown ActiveConnectionStateReason = iota
+
+	// ActiveConnectionStateReasonNone means no reason was given for the active connection state change.
+	ActiveConnectionStateReasonNone
+
+	// ActiveConnectionStateReasonUserDisconnected means the active connection changed state because the user disconnected it.
+	ActiveConnectionStateReasonUserDisconnected
+
+	// ActiveConnectionStateReasonDeviceDisconnected means the active connection changed state because the device it was using was disconnected.
+	ActiveConnectionStateReasonDeviceDisconnected
+
+	// ActiveConnectionStateReasonServiceStopped means the service providing the VPN connection was stopped.
+	ActiveConnectionStateReasonServiceStopped
+
+	// ActiveConnectionStateReasonIPConfigInvalid means the IP config of the active connection was invalid.
+	ActiveConnectionStateReasonIPConfigInvalid
+
+	// ActiveConnectionStateReasonConnectTimeout means the connection attempt to the VPN service timed out.
+	ActiveConnectionStateReasonConnectTimeout
+
+	// ActiveConnectionStateReasonServiceStartTimeout means a timeout occurred while starting the service providing the VPN connection.
+	ActiveConnectionStateReasonServiceStartTimeout
+
+	// ActiveConnectionStateReasonServiceStartFailed means starting the service providing the VPN connection failed.
+	ActiveConnectionStateReasonServiceStartFailed
+
+	// ActiveConnectionStateReasonNoSecrets means necessary secrets for the connection were not provided.
+	ActiveConnectionStateReasonNoSecrets
+
+	// ActiveConnectionStateReasonLoginFailed means authentication to the server failed.
+	ActiveConnectionStateReasonLoginFailed
+
+	// ActiveConnectionStateReasonConnectionRemoved means the connection was deleted from settings.
+	ActiveConnectionStateReasonConnectionRemoved
+
+	// ActiveConnectionStateReasonDependencyFailed means master connection of this connection failed to activate.
+	ActiveConnectionStateReasonDependencyFailed
+
+	// ActiveConnectionStateReasonDeviceRealizeFailed means could not create the software device link.
+	ActiveConnectionStateReasonDeviceRealizeFailed
+
+	// ActiveConnectionStateReasonDeviceRemoved means the device this connection depended on disappeared.
+	ActiveConnectionStateReasonDeviceRemoved
+)
+
+var activeConnectionStateReasonNames = []enums.Name{
+	{Value: uint(ActiveConnectionStateReasonUnknown), Name: "NM_ACTIVE_CONNECTION_STATE_REASON_UNKNOWN"},
+	{Value: uint(ActiveConnectionStateReasonNone), Name: "NM_ACTIVE_CONNECTION_STATE_REASON_NONE"},
+	{Value: uint(ActiveConnectionStateReasonUserDisconnected), Name: "NM_ACTIVE_CONNECTION_STATE_REASON_USER_DISCONNECTED"},
+	{Value: uint(ActiveConnectionStateReasonDeviceDisconnected), Name: "NM_ACTIVE_CONNECTION_STATE_REASON_DEVICE_DISCONNECTED"},
+	{Value: uint(ActiveConnectionStateReasonServiceStopped), Name: "NM_ACTIVE_CONNECTION_STATE_REASON_SERVICE_STOPPED"},
+	{Value: uint(ActiveConnectionStateReasonIPConfigInvalid), Name: "NM_ACTIVE_CONNECTION_STATE_REASON_IP_CONFIG_INVALID"},
+	{Value: uint(ActiveConnectionStateReasonConnectTimeout), Name: "NM_ACTIVE_CONNECTION_STATE_REASON_CONNECT_TIMEOUT"},
+	{Value: uint(ActiveConnectionStateReasonServiceStartTimeout), Name: "NM_ACTIVE_CONNECTION_STATE_REASON_SERVICE_START_TIMEOUT"},
+	{Value: uint(ActiveConnectionStateReasonServiceStartFailed), Name: "NM_ACTIVE_CONNECTION_STATE_REASON_SERVICE_START_FAILED"},
+	{Value: uint(ActiveConnectionStateReasonNoSecrets), Name: "NM_ACTIVE_CONNECTION_STATE_REASON_NO_SECRETS"},
+	{Value: uint(ActiveConnectionStateReasonLoginFailed), Name: "NM_ACTIVE_CONNECTION_STATE_REASON_LOGIN_FAILED"},
+	{Value: uint(ActiveConnectionStateReasonConnectionRemoved), Name: "NM_ACTIVE_CONNECTION_STATE_REASON_CONNECTION_REMOVED"},
+	{Value: uint(ActiveConnectionStateReasonDependencyFailed), Name: "NM_ACTIVE_CONNECTION_STATE_REASON_DEPENDENCY_FAILED"},
+	{Value: uint(ActiveConnectionStateReasonDeviceRealizeFailed), Name: "NM_ACTIVE_CONNECTION_STATE_REASON_DEVICE_REALIZE_FAILED"},
+	{Value: uint(ActiveConnectionStateReasonDeviceRemoved), Name: "NM_ACTIVE_CONNECTION_STATE_REASON_DEVICE_REMOVED"},
+}
+
+func (r ActiveConnectionStateReason) String() string {
+	return enums.String(uint(r), activeConnectionStateReasonNames)
+}
+
+// ParseActiveConnectionStateReason returns the ActiveConnectionStateReason named s, as returned by ActiveConnectionStateReason.String.
+func ParseActiveConnectionStateReason(s string) (ActiveConnectionStateReason, error) {
+	v, err := enums.Parse("ActiveConnectionStateReason", s, activeConnectionStateReasonNames)
+	return ActiveConnectionStateReason(v), err
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (r ActiveConnectionStateReason) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (r *ActiveConnectionStateReason) UnmarshalText(text []byte) error {
+	v, err := ParseActiveConnectionStateReason(string(text))
+	if err != nil {
+		return err
+	}
+	*r = v
+	return nil
+}
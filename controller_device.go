@@ -0,0 +1,152 @@
+package netmgr
+
+// ControllerDevice is implemented by the devices having ports, such as BondDevice, BridgeDevice and TeamDevice.
+type ControllerDevice interface {
+	Device
+
+	// HwAddress is the active hardware address of the device.
+	HwAddress() (string, error)
+
+	// Carrier tells if the device has a carrier.
+	Carrier() (bool, error)
+
+	// Ports are the devices enslaved to the controller.
+	Ports() ([]Device, error)
+}
+
+// ports returns the Ports of the device, falling back to the Slaves property of iface for NetworkManager older than 1.34.
+func (d *device) ports(iface string) ([]Device, error) {
+	paths, err := d.GetAOProperty(DeviceIface + ".Ports")
+	if err != nil {
+		if paths, err = d.GetAOProperty(iface + ".Slaves"); err != nil {
+			return nil, err
+		}
+	}
+	return NewDevices(d.Conn, paths), nil
+}
+
+func (d *device) slaves(iface string) ([]Device, error) {
+	paths, err := d.GetAOProperty(iface + ".Slaves")
+	if err != nil {
+		return nil, err
+	}
+	return NewDevices(d.Conn, paths), nil
+}
+
+type (
+	// ControllerHealth is the health of a controller device and of its ports.
+	ControllerHealth struct {
+		// Controller is the controller device.
+		Controller ControllerDevice
+
+		// Carrier tells if the controller has a carrier.
+		Carrier bool
+
+		// Ports is the health of the ports of the controller.
+		Ports []PortHealth
+
+		// Missing are the interface names of the expected ports which are not ports of the controller.
+		Missing []string
+	}
+
+	// PortHealth is the health of a port of a controller device.
+	PortHealth struct {
+		// Device is the port device.
+		Device Device
+
+		// Interface is the interface name of the port.
+		Interface string
+
+		// Carrier tells if the port has a carrier.
+		//
+		// For ports without a Carrier property, Carrier tells if the port is activated.
+		Carrier bool
+	}
+)
+
+// Healthy tells if the controller and all its ports have a carrier, and no expected port is missing.
+func (h *ControllerHealth) Healthy() bool {
+	return h.Carrier && len(h.CarrierDown()) == 0 && len(h.Missing) == 0
+}
+
+// CarrierDown returns the ports which have no carrier.
+func (h *ControllerHealth) CarrierDown() []PortHealth {
+	var down []PortHealth
+	for _, port := range h.Ports {
+		if !port.Carrier {
+			down = append(down, port)
+		}
+	}
+	return down
+}
+
+// CheckControllerHealth returns the health of controller and its ports.
+//
+// expected are the interface names of the ports the controller should have.
+// If expected is nil and controller is a TeamDevice, the ports of its team configuration are expected.
+func CheckControllerHealth(controller ControllerDevice, expected []string) (*ControllerHealth, error) {
+	if expected == nil {
+		if team, ok := controller.(TeamDevice); ok {
+			config, err := team.TeamConfig()
+			if err != nil {
+				return nil, err
+			}
+			expected = config.PortNames()
+		}
+	}
+
+	carrier, err := controller.Carrier()
+	if err != nil {
+		return nil, err
+	}
+
+	ports, err := controller.Ports()
+	if err != nil {
+		return nil, err
+	}
+
+	h := &ControllerHealth{
+		Controller: controller,
+		Carrier:    carrier,
+		Ports:      make([]PortHealth, 0, len(ports)),
+	}
+
+	present := make(map[string]bool, len(ports))
+	for _, port := range ports {
+		iface, err := port.Interface()
+		if err != nil {
+			return nil, err
+		}
+		present[iface] = true
+
+		portCarrier, err := deviceCarrier(port)
+		if err != nil {
+			return nil, err
+		}
+
+		h.Ports = append(h.Ports, PortHealth{port, iface, portCarrier})
+	}
+
+	for _, iface := range expected {
+		if !present[iface] {
+			h.Missing = append(h.Missing, iface)
+		}
+	}
+
+	return h, nil
+}
+
+// deviceCarrier returns the Carrier of d if it has one, otherwise whether d is activated.
+func deviceCarrier(d Device) (bool, error) {
+	if c, ok := d.(interface{ Carrier() (bool, error) }); ok {
+		return c.Carrier()
+	}
+	state, err := d.State()
+	return state == DeviceStateActivated, err
+}
+
+var (
+	_ ControllerDevice = (*bondDevice)(nil)
+	_ ControllerDevice = (*bridgeDevice)(nil)
+	_ ControllerDevice = (*teamDevice)(nil)
+)
@@ -1,9 +1,13 @@
 package netmgr
 
 import (
+	"context"
+	"time"
+
 	"github.com/godbus/dbus/v5"
 
 	"github.com/nlepage/go-netmgr/internal/dbusext"
+	"github.com/nlepage/go-netmgr/internal/enums"
 )
 
 // DeviceIface is the base Device interface.
@@ -14,6 +18,24 @@ type (
 	Device interface {
 		dbus.BusObject
 
+		// Methods
+
+		// Reapply attempts to update the configuration of a device without deactivating it.
+		//
+		// connection replaces the applied connection, nil reapplies the settings connection unchanged.
+		// versionID must be the one returned by GetAppliedConnection, or 0 to skip the check.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.html#gdbus-method-org-freedesktop-NetworkManager-Device.Reapply for more information.
+		Reapply(connection ConnectionSettings, versionID uint64, flags DeviceReapplyFlags) error
+
+		// GetAppliedConnection gets the currently applied connection on the device, and its version id.
+		//
+		// The deprecated addresses and routes of the ipv4 and ipv6 settings are dropped when address-data and route-data are present,
+		// so that the connection can be given back to Reapply.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.html#gdbus-method-org-freedesktop-NetworkManager-Device.GetAppliedConnection for more information.
+		GetAppliedConnection() (ConnectionSettings, uint64, error)
+
 		// Properties
 
 		// Udi is the operating-system specific transient device hardware identifier.
@@ -45,20 +67,181 @@ type (
 		//
 		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.html#gdbus-property-org-freedesktop-NetworkManager-Device.FirmwareVersion for more information.
 		FirmwareVersion() (string, error)
+
+		// DeviceType is the general type of the network device.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.html#gdbus-property-org-freedesktop-NetworkManager-Device.DeviceType for more information.
+		DeviceType() (DeviceType, error)
+
+		// State is the current state of the device.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.html#gdbus-property-org-freedesktop-NetworkManager-Device.State for more information.
+		State() (DeviceState, error)
+
+		// StateReason is the current state and reason for that state.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.html#gdbus-property-org-freedesktop-NetworkManager-Device.StateReason for more information.
+		StateReason() (DeviceState, DeviceStateReason, error)
+
+		// ActiveConnection is the active connection of the device, nil if none.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.html#gdbus-property-org-freedesktop-NetworkManager-Device.ActiveConnection for more information.
+		ActiveConnection() (ConnectionActive, error)
+
+		// LldpNeighbors are the LLDP neighbors of the device.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.html#gdbus-property-org-freedesktop-NetworkManager-Device.LldpNeighbors for more information.
+		LldpNeighbors() ([]LLDPNeighbor, error)
+
+		// Signals
+
+		// StateChanged is emitted when the device changes state.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.html#gdbus-signal-org-freedesktop-NetworkManager-Device.StateChanged for more information.
+		StateChanged(ch chan<- DeviceStateChange) error
+
+		// Statistics
+
+		// RefreshRateMs is the refresh rate of the statistics, in milliseconds, 0 if disabled.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Statistics.html#gdbus-property-org-freedesktop-NetworkManager-Device-Statistics.RefreshRateMs for more information.
+		RefreshRateMs() (uint32, error)
+
+		// SetRefreshRateMs sets the refresh rate of the statistics, in milliseconds, 0 to disable them.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Statistics.html#gdbus-property-org-freedesktop-NetworkManager-Device-Statistics.RefreshRateMs for more information.
+		SetRefreshRateMs(value uint32) error
+
+		// TxBytes is the number of transmitted bytes.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Statistics.html#gdbus-property-org-freedesktop-NetworkManager-Device-Statistics.TxBytes for more information.
+		TxBytes() (uint64, error)
+
+		// RxBytes is the number of received bytes.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Statistics.html#gdbus-property-org-freedesktop-NetworkManager-Device-Statistics.RxBytes for more information.
+		RxBytes() (uint64, error)
+
+		// Helpers
+
+		// SampleThroughput sets the refresh rate of the statistics to interval, and calls fn with the throughput each time the counters are refreshed.
+		//
+		// The first refresh is the baseline of the throughput, so fn is first called on the second refresh.
+		// If fn is slower than interval, the refreshes received in the meantime are merged into the next call.
+		// It returns once ctx is done, after setting back the previous refresh rate.
+		SampleThroughput(ctx context.Context, interval time.Duration, fn func(ThroughputSample)) error
+
+		// EditAppliedConnection calls edit with the applied connection, and reapplies the edited connection.
+		//
+		// If the applied connection changed in the meantime, it is read and edited again, up to EditAppliedConnectionAttempts times,
+		// then a *ConflictError is returned.
+		EditAppliedConnection(ctx context.Context, flags DeviceReapplyFlags, edit func(ConnectionSettings) error) error
 	}
 
 	device struct {
 		dbusext.BusObject
 	}
+
+	// DeviceStateChange is the content of a Device StateChanged signal.
+	DeviceStateChange struct {
+		NewState DeviceState
+		OldState DeviceState
+		Reason   DeviceStateReason
+	}
 )
 
 var _ Device = (*device)(nil)
 
 // NewDevice returns the Device from conn corresponding to path.
+//
+// The returned Device may be asserted to the interface corresponding to its device type, such as WirelessDevice.
+// Only the devices of that type satisfy it, even if other device types have the same methods.
+// If the device type cannot be read, for example because the device was removed, the returned Device has only the base Device interface.
+// So do the devices of types without a specific interface: DeviceTypeUnknown, DeviceTypeUnused1, DeviceTypeUnused2 and DeviceTypeWimax, which is not supported anymore.
 func NewDevice(conn *dbus.Conn, path dbus.ObjectPath) Device {
-	// FIXME compose using device type
+	d := device{dbusext.NewBusObject(conn, BusName, path)}
 
-	return &device{dbusext.NewBusObject(conn, BusName, path)}
+	deviceType, err := d.DeviceType()
+	if err != nil {
+		return &d
+	}
+
+	switch deviceType {
+	case DeviceTypeEthernet:
+		return &wiredDevice{d}
+	case DeviceTypeBond:
+		return &bondDevice{d}
+	case DeviceTypeBridge:
+		return &bridgeDevice{d}
+	case DeviceTypeTeam:
+		return &teamDevice{d}
+	case DeviceTypeVlan:
+		return &vlanDevice{d}
+	case DeviceTypeIPTunnel:
+		return &ipTunnelDevice{d}
+	case DeviceTypeMacvlan:
+		return &macvlanDevice{d}
+	case DeviceTypeVxlan:
+		return &vxlanDevice{d}
+	case DeviceTypeModem:
+		return &modemDevice{d}
+	case DeviceTypeOvsInterface:
+		return &ovsInterfaceDevice{d}
+	case DeviceTypeOvsPort:
+		return &ovsPortDevice{d}
+	case DeviceTypeOvsBridge:
+		return &ovsBridgeDevice{d}
+	case DeviceTypeWifiP2P:
+		return &wifiP2PDevice{d}
+	case DeviceTypeWireGuard:
+		return &wireGuardDevice{d}
+	case DeviceTypeWifi:
+		return &wirelessDevice{d}
+	case DeviceTypeBt:
+		return &bluetoothDevice{d}
+	case DeviceTypeOlpcMesh:
+		return &olpcMeshDevice{d}
+	case DeviceTypeInfiniband:
+		return &infinibandDevice{d}
+	case DeviceTypeAdsl:
+		return &adslDevice{d}
+	case DeviceTypeGeneric:
+		return &genericDevice{d}
+	case DeviceTypeTun:
+		return &tunDevice{d}
+	case DeviceTypeVeth:
+		return &vethDevice{d}
+	case DeviceTypeMacsec:
+		return &macsecDevice{d}
+	case DeviceTypeDummy:
+		return &dummyDevice{d}
+	case DeviceTypePPP:
+		return &pppDevice{d}
+	case DeviceTypeWpan:
+		return &wpanDevice{d}
+	case DeviceType6LoWPAN:
+		return &lowpanDevice{d}
+	case DeviceTypeLoopback:
+		return &loopbackDevice{d}
+	case DeviceTypeVrf:
+		return &vrfDevice{d}
+	}
+
+	return &d
+}
+
+// parent returns the device of the Parent property of iface, nil if none.
+func (d *device) parent(iface string) (Device, error) {
+	return d.deviceProperty(iface + ".Parent")
+}
+
+// deviceProperty returns the device of the object path property name, nil if none.
+func (d *device) deviceProperty(name string) (Device, error) {
+	path, err := d.GetOProperty(name)
+	if err != nil || path == "/" {
+		return nil, err
+	}
+	return NewDevice(d.Conn, path), nil
 }
 
 // NewDevices returns the slice of Device from conn corresponding paths.
@@ -70,6 +253,14 @@ func NewDevices(conn *dbus.Conn, paths []dbus.ObjectPath) []Device {
 	return devices
 }
 
+// errUnknownObject is returned by NetworkManager for the objects which have been removed.
+var errUnknownObject = dbus.NewError("org.freedesktop.DBus.Error.UnknownObject", nil)
+
+// isRemovedObject tells if err was returned because the object has been removed from the bus.
+func isRemovedObject(err error) bool {
+	return isDBusError(err, errUnknownObject)
+}
+
 func (d *device) Udi() (string, error) {
 	return d.GetSProperty(DeviceIface + ".Udi")
 }
@@ -94,6 +285,49 @@ func (d *device) FirmwareVersion() (string, error) {
 	return d.GetSProperty(DeviceIface + ".FirmwareVersion")
 }
 
+func (d *device) DeviceType() (DeviceType, error) {
+	deviceType, err := d.GetUProperty(DeviceIface + ".DeviceType")
+	return DeviceType(deviceType), err
+}
+
+func (d *device) State() (DeviceState, error) {
+	state, err := d.GetUProperty(DeviceIface + ".State")
+	return DeviceState(state), err
+}
+
+func (d *device) StateReason() (DeviceState, DeviceStateReason, error) {
+	p, err := d.GetProperty(DeviceIface + ".StateReason")
+	if err != nil {
+		return DeviceStateUnknown, DeviceStateReasonUnknown, err
+	}
+	var state, reason uint32
+	if err := dbus.Store(p.Value().([]interface{}), &state, &reason); err != nil {
+		return DeviceStateUnknown, DeviceStateReasonUnknown, err
+	}
+	return DeviceState(state), DeviceStateReason(reason), nil
+}
+
+func (d *device) ActiveConnection() (ConnectionActive, error) {
+	path, err := d.GetOProperty(DeviceIface + ".ActiveConnection")
+	if err != nil || path == "/" {
+		return nil, err
+	}
+	return NewConnectionActive(d.Conn, path)
+}
+
+func (d *device) StateChanged(ch chan<- DeviceStateChange) error {
+	return d.BodySignal(DeviceIface, "StateChanged", ch, func(body []interface{}) DeviceStateChange {
+		var change DeviceStateChange
+		if len(body) == 3 {
+			newState, _ := body[0].(uint32)
+			oldState, _ := body[1].(uint32)
+			reason, _ := body[2].(uint32)
+			change = DeviceStateChange{DeviceState(newState), DeviceState(oldState), DeviceStateReason(reason)}
+		}
+		return change
+	})
+}
+
 // MeteredEnum has two different purposes:
 // one is to configure "connection.metered" setting of a connection profile in NMSettingConnection,
 // and the other is to express the actual metered state of the NMDevice at a given moment.
@@ -117,3 +351,597 @@ const (
 	// MeteredGuessNo is not metered, the value was guessed.
 	MeteredGuessNo
 )
+
+var meteredEnumNames = []enums.Name{
+	{Value: uint(MeteredUnknown), Name: "NM_METERED_UNKNOWN"},
+	{Value: uint(MeteredYes), Name: "NM_METERED_YES"},
+	{Value: uint(MeteredNo), Name: "NM_METERED_NO"},
+	{Value: uint(MeteredGuessYes), Name: "NM_METERED_GUESS_YES"},
+	{Value: uint(MeteredGuessNo), Name: "NM_METERED_GUESS_NO"},
+}
+
+func (m MeteredEnum) String() string {
+	return enums.String(uint(m), meteredEnumNames)
+}
+
+// ParseMeteredEnum returns the MeteredEnum named s, as returned by MeteredEnum.String.
+func ParseMeteredEnum(s string) (MeteredEnum, error) {
+	v, err := enums.Parse("MeteredEnum", s, meteredEnumNames)
+	return MeteredEnum(v), err
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (m MeteredEnum) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (m *MeteredEnum) UnmarshalText(text []byte) error {
+	v, err := ParseMeteredEnum(string(text))
+	if err != nil {
+		return err
+	}
+	*m = v
+	return nil
+}
+
+// DeviceType values indicate the type of hardware represented by a device object.
+//
+// See https://developer.gnome.org/NetworkManager/stable/nm-dbus-types.html#NMDeviceType for more information.
+type DeviceType uint
+
+const (
+	// DeviceTypeUnknown is unknown device.
+	DeviceTypeUnknown DeviceType = iota
+
+	// DeviceTypeEthernet is a wired ethernet device.
+	DeviceTypeEthernet
+
+	// DeviceTypeWifi is an 802.11 Wi-Fi device.
+	DeviceTypeWifi
+
+	// DeviceTypeUnused1 is not used.
+	DeviceTypeUnused1
+
+	// DeviceTypeUnused2 is not used.
+	DeviceTypeUnused2
+
+	// DeviceTypeBt is a Bluetooth device supporting PAN or DUN access protocols.
+	DeviceTypeBt
+
+	// DeviceTypeOlpcMesh is an OLPC XO mesh networking device.
+	DeviceTypeOlpcMesh
+
+	// DeviceTypeWimax is an 802.16e Mobile WiMAX broadband device.
+	DeviceTypeWimax
+
+	// DeviceTypeModem is a modem supporting analog telephone, CDMA/EVDO, GSM/UMTS, or LTE network access protocols.
+	DeviceTypeModem
+
+	// DeviceTypeInfiniband is an IP-over-InfiniBand device.
+	DeviceTypeInfiniband
+
+	// DeviceTypeBond is a bond controller interface.
+	DeviceTypeBond
+
+	// DeviceTypeVlan is an 802.1Q VLAN interface.
+	DeviceTypeVlan
+
+	// DeviceTypeAdsl is ADSL modem.
+	DeviceTypeAdsl
+
+	// DeviceTypeBridge is a bridge controller interface.
+	DeviceTypeBridge
+
+	// DeviceTypeGeneric is generic support for unrecognized device types.
+	DeviceTypeGeneric
+
+	// DeviceTypeTeam is a team controller interface.
+	DeviceTypeTeam
+
+	// DeviceTypeTun is a TUN or TAP interface.
+	DeviceTypeTun
+
+	// DeviceTypeIPTunnel is a IP tunnel interface.
+	DeviceTypeIPTunnel
+
+	// DeviceTypeMacvlan is a MACVLAN interface.
+	DeviceTypeMacvlan
+
+	// DeviceTypeVxlan is a VXLAN interface.
+	DeviceTypeVxlan
+
+	// DeviceTypeVeth is a VETH interface.
+	DeviceTypeVeth
+
+	// DeviceTypeMacsec is a MACsec interface.
+	DeviceTypeMacsec
+
+	// DeviceTypeDummy is a dummy interface.
+	DeviceTypeDummy
+
+	// DeviceTypePPP is a PPP interface.
+	DeviceTypePPP
+
+	// DeviceTypeOvsInterface is a Open vSwitch interface.
+	DeviceTypeOvsInterface
+
+	// DeviceTypeOvsPort is a Open vSwitch port.
+	DeviceTypeOvsPort
+
+	// DeviceTypeOvsBridge is a Open vSwitch bridge.
+	DeviceTypeOvsBridge
+
+	// DeviceTypeWpan is a IEEE 802.15.4 (WPAN) MAC Layer Device.
+	DeviceTypeWpan
+
+	// DeviceType6LoWPAN is 6LoWPAN interface.
+	DeviceType6LoWPAN
+
+	// DeviceTypeWireGuard is a WireGuard interface.
+	DeviceTypeWireGuard
+
+	// DeviceTypeWifiP2P is an 802.11 Wi-Fi P2P device.
+	DeviceTypeWifiP2P
+
+	// DeviceTypeVrf is a VRF (Virtual Routing and Forwarding) interface.
+	DeviceTypeVrf
+
+	// DeviceTypeLoopback is a loopback interface.
+	DeviceTypeLoopback
+)
+
+var deviceTypeNames = []enums.Name{
+	{Value: uint(DeviceTypeUnknown), Name: "NM_DEVICE_TYPE_UNKNOWN"},
+	{Value: uint(DeviceTypeEthernet), Name: "NM_DEVICE_TYPE_ETHERNET"},
+	{Value: uint(DeviceTypeWifi), Name: "NM_DEVICE_TYPE_WIFI"},
+	{Value: uint(DeviceTypeUnused1), Name: "NM_DEVICE_TYPE_UNUSED1"},
+	{Value: uint(DeviceTypeUnused2), Name: "NM_DEVICE_TYPE_UNUSED2"},
+	{Value: uint(DeviceTypeBt), Name: "NM_DEVICE_TYPE_BT"},
+	{Value: uint(DeviceTypeOlpcMesh), Name: "NM_DEVICE_TYPE_OLPC_MESH"},
+	{Value: uint(DeviceTypeWimax), Name: "NM_DEVICE_TYPE_WIMAX"},
+	{Value: uint(DeviceTypeModem), Name: "NM_DEVICE_TYPE_MODEM"},
+	{Value: uint(DeviceTypeInfiniband), Name: "NM_DEVICE_TYPE_INFINIBAND"},
+	{Value: uint(DeviceTypeBond), Name: "NM_DEVICE_TYPE_BOND"},
+	{Value: uint(DeviceTypeVlan), Name: "NM_DEVICE_TYPE_VLAN"},
+	{Value: uint(DeviceTypeAdsl), Name: "NM_DEVICE_TYPE_ADSL"},
+	{Value: uint(DeviceTypeBridge), Name: "NM_DEVICE_TYPE_BRIDGE"},
+	{Value: uint(DeviceTypeGeneric), Name: "NM_DEVICE_TYPE_GENERIC"},
+	{Value: uint(DeviceTypeTeam), Name: "NM_DEVICE_TYPE_TEAM"},
+	{Value: uint(DeviceTypeTun), Name: "NM_DEVICE_TYPE_TUN"},
+	{Value: uint(DeviceTypeIPTunnel), Name: "NM_DEVICE_TYPE_IP_TUNNEL"},
+	{Value: uint(DeviceTypeMacvlan), Name: "NM_DEVICE_TYPE_MACVLAN"},
+	{Value: uint(DeviceTypeVxlan), Name: "NM_DEVICE_TYPE_VXLAN"},
+	{Value: uint(DeviceTypeVeth), Name: "NM_DEVICE_TYPE_VETH"},
+	{Value: uint(DeviceTypeMacsec), Name: "NM_DEVICE_TYPE_MACSEC"},
+	{Value: uint(DeviceTypeDummy), Name: "NM_DEVICE_TYPE_DUMMY"},
+	{Value: uint(DeviceTypePPP), Name: "NM_DEVICE_TYPE_PPP"},
+	{Value: uint(DeviceTypeOvsInterface), Name: "NM_DEVICE_TYPE_OVS_INTERFACE"},
+	{Value: uint(DeviceTypeOvsPort), Name: "NM_DEVICE_TYPE_OVS_PORT"},
+	{Value: uint(DeviceTypeOvsBridge), Name: "NM_DEVICE_TYPE_OVS_BRIDGE"},
+	{Value: uint(DeviceTypeWpan), Name: "NM_DEVICE_TYPE_WPAN"},
+	{Value: uint(DeviceType6LoWPAN), Name: "NM_DEVICE_TYPE_6LOWPAN"},
+	{Value: uint(DeviceTypeWireGuard), Name: "NM_DEVICE_TYPE_WIREGUARD"},
+	{Value: uint(DeviceTypeWifiP2P), Name: "NM_DEVICE_TYPE_WIFI_P2P"},
+	{Value: uint(DeviceTypeVrf), Name: "NM_DEVICE_TYPE_VRF"},
+	{Value: uint(DeviceTypeLoopback), Name: "NM_DEVICE_TYPE_LOOPBACK"},
+}
+
+func (t DeviceType) String() string {
+	return enums.String(uint(t), deviceTypeNames)
+}
+
+// ParseDeviceType returns the DeviceType named s, as returned by DeviceType.String.
+func ParseDeviceType(s string) (DeviceType, error) {
+	v, err := enums.Parse("DeviceType", s, deviceTypeNames)
+	return DeviceType(v), err
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (t DeviceType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (t *DeviceType) UnmarshalText(text []byte) error {
+	v, err := ParseDeviceType(string(text))
+	if err != nil {
+		return err
+	}
+	*t = v
+	return nil
+}
+
+// DeviceState values indicate the state of a device.
+//
+// See https://developer.gnome.org/NetworkManager/stable/nm-dbus-types.html#NMDeviceState for more information.
+type DeviceState uint
+
+const (
+	// DeviceStateUnknown means the device's state is unknown.
+	DeviceStateUnknown DeviceState = iota * 10
+
+	// DeviceStateUnmanaged means the device is recognized, but not managed by NetworkManager.
+	DeviceStateUnmanaged
+
+	// DeviceStateUnavailable means the device is managed by NetworkManager, but is not available for use.
+	DeviceStateUnavailable
+
+	// DeviceStateDisconnected means the device can be activated, but is currently idle and not connected to a network.
+	DeviceStateDisconnected
+
+	// DeviceStatePrepare means the device is preparing the connection to the network.
+	DeviceStatePrepare
+
+	// DeviceStateConfig means the device is connecting to the requested network.
+	DeviceStateConfig
+
+	// DeviceStateNeedAuth means the device requires more information to continue connecting to the requested network.
+	DeviceStateNeedAuth
+
+	// DeviceStateIPConfig means the device is requesting IPv4 and/or IPv6 addresses and routing information from the network.
+	DeviceStateIPConfig
+
+	// DeviceStateIPCheck means the device is checking whether further action is required for the requested network connection.
+	DeviceStateIPCheck
+
+	// DeviceStateSecondaries means the device is waiting for a secondary connection (like a VPN) which must activated before the device can be activated.
+	DeviceStateSecondaries
+
+	// DeviceStateActivated means the device has a network connection, either local or global.
+	DeviceStateActivated
+
+	// DeviceStateDeactivating means a disconnection from the current network connection was requested, and the device is cleaning up resources used for that connection.
+	DeviceStateDeactivating
+
+	// DeviceStateFailed means the device failed to connect to the requested network and is cleaning up the connection request.
+	DeviceStateFailed
+)
+
+var deviceStateNames = []enums.Name{
+	{Value: uint(DeviceStateUnknown), Name: "NM_DEVICE_STATE_UNKNOWN"},
+	{Value: uint(DeviceStateUnmanaged), Name: "NM_DEVICE_STATE_UNMANAGED"},
+	{Value: uint(DeviceStateUnavailable), Name: "NM_DEVICE_STATE_UNAVAILABLE"},
+	{Value: uint(DeviceStateDisconnected), Name: "NM_DEVICE_STATE_DISCONNECTED"},
+	{Value: uint(DeviceStatePrepare), Name: "NM_DEVICE_STATE_PREPARE"},
+	{Value: uint(DeviceStateConfig), Name: "NM_DEVICE_STATE_CONFIG"},
+	{Value: uint(DeviceStateNeedAuth), Name: "NM_DEVICE_STATE_NEED_AUTH"},
+	{Value: uint(DeviceStateIPConfig), Name: "NM_DEVICE_STATE_IP_CONFIG"},
+	{Value: uint(DeviceStateIPCheck), Name: "NM_DEVICE_STATE_IP_CHECK"},
+	{Value: uint(DeviceStateSecondaries), Name: "NM_DEVICE_STATE_SECONDARIES"},
+	{Value: uint(DeviceStateActivated), Name: "NM_DEVICE_STATE_ACTIVATED"},
+	{Value: uint(DeviceStateDeactivating), Name: "NM_DEVICE_STATE_DEACTIVATING"},
+	{Value: uint(DeviceStateFailed), Name: "NM_DEVICE_STATE_FAILED"},
+}
+
+func (s DeviceState) String() string {
+	return enums.String(uint(s), deviceStateNames)
+}
+
+// ParseDeviceState returns the DeviceState named s, as returned by DeviceState.String.
+func ParseDeviceState(s string) (DeviceState, error) {
+	v, err := enums.Parse("DeviceState", s, deviceStateNames)
+	return DeviceState(v), err
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s DeviceState) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *DeviceState) UnmarshalText(text []byte) error {
+	v, err := ParseDeviceState(string(text))
+	if err != nil {
+		return err
+	}
+	*s = v
+	return nil
+}
+
+// DeviceStateReason values indicate the reason for a device state change.
+//
+// See https://developer.gnome.org/NetworkManager/stable/nm-dbus-types.html#NMDeviceStateReason for more information.
+type DeviceStateReason uint
+
+const (
+	// DeviceStateReasonNone means no reason given.
+	DeviceStateReasonNone DeviceStateReason = iota
+
+	// DeviceStateReasonUnknown means unknown error.
+	DeviceStateReasonUnknown
+
+	// DeviceStateReasonNowManaged means device is now managed.
+	DeviceStateReasonNowManaged
+
+	// DeviceStateReasonNowUnmanaged means device is now unmanaged.
+	DeviceStateReasonNowUnmanaged
+
+	// DeviceStateReasonConfigFailed means the device could not be readied for configuration.
+	DeviceStateReasonConfigFailed
+
+	// DeviceStateReasonIPConfigUnavailable means IP configuration could not be reserved (no available address, timeout, etc).
+	DeviceStateReasonIPConfigUnavailable
+
+	// DeviceStateReasonIPConfigExpired means the IP config is no longer valid.
+	DeviceStateReasonIPConfigExpired
+
+	// DeviceStateReasonNoSecrets means secrets were required, but not provided.
+	DeviceStateReasonNoSecrets
+
+	// DeviceStateReasonSupplicantDisconnect means 802.1x supplicant disconnected.
+	DeviceStateReasonSupplicantDisconnect
+
+	// DeviceStateReasonSupplicantConfigFailed means 802.1x supplicant configuration failed.
+	DeviceStateReasonSupplicantConfigFailed
+
+	// DeviceStateReasonSupplicantFailed means 802.1x supplicant failed.
+	DeviceStateReasonSupplicantFailed
+
+	// DeviceStateReasonSupplicantTimeout means 802.1x supplicant took too long to authenticate.
+	DeviceStateReasonSupplicantTimeout
+
+	// DeviceStateReasonPPPStartFailed means PPP service failed to start.
+	DeviceStateReasonPPPStartFailed
+
+	// DeviceStateReasonPPPDisconnect means PPP service disconnected.
+	DeviceStateReasonPPPDisconnect
+
+	// DeviceStateReasonPPPFailed means PPP failed.
+	DeviceStateReasonPPPFailed
+
+	// DeviceStateReasonDHCPStartFailed means DHCP client failed to start.
+	DeviceStateReasonDHCPStartFailed
+
+	// DeviceStateReasonDHCPError means DHCP client error.
+	DeviceStateReasonDHCPError
+
+	// DeviceStateReasonDHCPFailed means DHCP client failed.
+	DeviceStateReasonDHCPFailed
+
+	// DeviceStateReasonSharedStartFailed means shared connection service failed to start.
+	DeviceStateReasonSharedStartFailed
+
+	// DeviceStateReasonSharedFailed means shared connection service failed.
+	DeviceStateReasonSharedFailed
+
+	// DeviceStateReasonAutoIPStartFailed means AutoIP service failed to start.
+	DeviceStateReasonAutoIPStartFailed
+
+	// DeviceStateReasonAutoIPError means AutoIP service error.
+	DeviceStateReasonAutoIPError
+
+	// DeviceStateReasonAutoIPFailed means AutoIP service failed.
+	DeviceStateReasonAutoIPFailed
+
+	// DeviceStateReasonModemBusy means the line is busy.
+	DeviceStateReasonModemBusy
+
+	// DeviceStateReasonModemNoDialTone means no dial tone.
+	DeviceStateReasonModemNoDialTone
+
+	// DeviceStateReasonModemNoCarrier means no carrier could be established.
+	DeviceStateReasonModemNoCarrier
+
+	// DeviceStateReasonModemDialTimeout means the dialing request timed out.
+	DeviceStateReasonModemDialTimeout
+
+	// DeviceStateReasonModemDialFailed means the dialing attempt failed.
+	DeviceStateReasonModemDialFailed
+
+	// DeviceStateReasonModemInitFailed means modem initialization failed.
+	DeviceStateReasonModemInitFailed
+
+	// DeviceStateReasonGSMAPNFailed means failed to select the specified APN.
+	DeviceStateReasonGSMAPNFailed
+
+	// DeviceStateReasonGSMRegistrationNotSearching means not searching for networks.
+	DeviceStateReasonGSMRegistrationNotSearching
+
+	// DeviceStateReasonGSMRegistrationDenied means network registration denied.
+	DeviceStateReasonGSMRegistrationDenied
+
+	// DeviceStateReasonGSMRegistrationTimeout means network registration timed out.
+	DeviceStateReasonGSMRegistrationTimeout
+
+	// DeviceStateReasonGSMRegistrationFailed means failed to register with the requested network.
+	DeviceStateReasonGSMRegistrationFailed
+
+	// DeviceStateReasonGSMPINCheckFailed means PIN check failed.
+	DeviceStateReasonGSMPINCheckFailed
+
+	// DeviceStateReasonFirmwareMissing means necessary firmware for the device may be missing.
+	DeviceStateReasonFirmwareMissing
+
+	// DeviceStateReasonRemoved means the device was removed.
+	DeviceStateReasonRemoved
+
+	// DeviceStateReasonSleeping means NetworkManager went to sleep.
+	DeviceStateReasonSleeping
+
+	// DeviceStateReasonConnectionRemoved means the device's active connection disappeared.
+	DeviceStateReasonConnectionRemoved
+
+	// DeviceStateReasonUserRequested means device disconnected by user or client.
+	DeviceStateReasonUserRequested
+
+	// DeviceStateReasonCarrier means carrier/link changed.
+	DeviceStateReasonCarrier
+
+	// DeviceStateReasonConnectionAssumed means the device's existing connection was assumed.
+	DeviceStateReasonConnectionAssumed
+
+	// DeviceStateReasonSupplicantAvailable means the supplicant is now available.
+	DeviceStateReasonSupplicantAvailable
+
+	// DeviceStateReasonModemNotFound means the modem could not be found.
+	DeviceStateReasonModemNotFound
+
+	// DeviceStateReasonBTFailed means the Bluetooth connection failed or timed out.
+	DeviceStateReasonBTFailed
+
+	// DeviceStateReasonGSMSIMNotInserted means GSM Modem's SIM card not inserted.
+	DeviceStateReasonGSMSIMNotInserted
+
+	// DeviceStateReasonGSMSIMPINRequired means GSM Modem's SIM PIN required.
+	DeviceStateReasonGSMSIMPINRequired
+
+	// DeviceStateReasonGSMSIMPUKRequired means GSM Modem's SIM PUK required.
+	DeviceStateReasonGSMSIMPUKRequired
+
+	// DeviceStateReasonGSMSIMWrong means GSM Modem's SIM wrong.
+	DeviceStateReasonGSMSIMWrong
+
+	// DeviceStateReasonInfinibandMode means InfiniBand device does not support connected mode.
+	DeviceStateReasonInfinibandMode
+
+	// DeviceStateReasonDependencyFailed means a dependency of the connection failed.
+	DeviceStateReasonDependencyFailed
+
+	// DeviceStateReasonBR2684Failed means problem with the RFC 2684 Ethernet over ADSL bridge.
+	DeviceStateReasonBR2684Failed
+
+	// DeviceStateReasonModemManagerUnavailable means ModemManager not running.
+	DeviceStateReasonModemManagerUnavailable
+
+	// DeviceStateReasonSSIDNotFound means the Wi-Fi network could not be found.
+	DeviceStateReasonSSIDNotFound
+
+	// DeviceStateReasonSecondaryConnectionFailed means a secondary connection of the base connection failed.
+	DeviceStateReasonSecondaryConnectionFailed
+
+	// DeviceStateReasonDCBFCoEFailed means DCB or FCoE setup failed.
+	DeviceStateReasonDCBFCoEFailed
+
+	// DeviceStateReasonTeamdControlFailed means teamd control failed.
+	DeviceStateReasonTeamdControlFailed
+
+	// DeviceStateReasonModemFailed means modem failed or no longer available.
+	DeviceStateReasonModemFailed
+
+	// DeviceStateReasonModemAvailable means modem now ready and available.
+	DeviceStateReasonModemAvailable
+
+	// DeviceStateReasonSIMPINIncorrect means SIM PIN was incorrect.
+	DeviceStateReasonSIMPINIncorrect
+
+	// DeviceStateReasonNewActivation means new connection activation was enqueued.
+	DeviceStateReasonNewActivation
+
+	// DeviceStateReasonParentChanged means the device's parent changed.
+	DeviceStateReasonParentChanged
+
+	// DeviceStateReasonParentManagedChanged means the device parent's management changed.
+	DeviceStateReasonParentManagedChanged
+
+	// DeviceStateReasonOVSDBFailed means problem communicating with Open vSwitch database.
+	DeviceStateReasonOVSDBFailed
+
+	// DeviceStateReasonIPAddressDuplicate means a duplicate IP address was detected.
+	DeviceStateReasonIPAddressDuplicate
+
+	// DeviceStateReasonIPMethodUnsupported means the selected IP method is not supported.
+	DeviceStateReasonIPMethodUnsupported
+
+	// DeviceStateReasonSRIOVConfigurationFailed means configuration of SR-IOV parameters failed.
+	DeviceStateReasonSRIOVConfigurationFailed
+
+	// DeviceStateReasonPeerNotFound means the Wi-Fi P2P peer could not be found.
+	DeviceStateReasonPeerNotFound
+)
+
+var deviceStateReasonNames = []enums.Name{
+	{Value: uint(DeviceStateReasonNone), Name: "NM_DEVICE_STATE_REASON_NONE"},
+	{Value: uint(DeviceStateReasonUnknown), Name: "NM_DEVICE_STATE_REASON_UNKNOWN"},
+	{Value: uint(DeviceStateReasonNowManaged), Name: "NM_DEVICE_STATE_REASON_NOW_MANAGED"},
+	{Value: uint(DeviceStateReasonNowUnmanaged), Name: "NM_DEVICE_STATE_REASON_NOW_UNMANAGED"},
+	{Value: uint(DeviceStateReasonConfigFailed), Name: "NM_DEVICE_STATE_REASON_CONFIG_FAILED"},
+	{Value: uint(DeviceStateReasonIPConfigUnavailable), Name: "NM_DEVICE_STATE_REASON_IP_CONFIG_UNAVAILABLE"},
+	{Value: uint(DeviceStateReasonIPConfigExpired), Name: "NM_DEVICE_STATE_REASON_IP_CONFIG_EXPIRED"},
+	{Value: uint(DeviceStateReasonNoSecrets), Name: "NM_DEVICE_STATE_REASON_NO_SECRETS"},
+	{Value: uint(DeviceStateReasonSupplicantDisconnect), Name: "NM_DEVICE_STATE_REASON_SUPPLICANT_DISCONNECT"},
+	{Value: uint(DeviceStateReasonSupplicantConfigFailed), Name: "NM_DEVICE_STATE_REASON_SUPPLICANT_CONFIG_FAILED"},
+	{Value: uint(DeviceStateReasonSupplicantFailed), Name: "NM_DEVICE_STATE_REASON_SUPPLICANT_FAILED"},
+	{Value: uint(DeviceStateReasonSupplicantTimeout), Name: "NM_DEVICE_STATE_REASON_SUPPLICANT_TIMEOUT"},
+	{Value: uint(DeviceStateReasonPPPStartFailed), Name: "NM_DEVICE_STATE_REASON_PPP_START_FAILED"},
+	{Value: uint(DeviceStateReasonPPPDisconnect), Name: "NM_DEVICE_STATE_REASON_PPP_DISCONNECT"},
+	{Value: uint(DeviceStateReasonPPPFailed), Name: "NM_DEVICE_STATE_REASON_PPP_FAILED"},
+	{Value: uint(DeviceStateReasonDHCPStartFailed), Name: "NM_DEVICE_STATE_REASON_DHCP_START_FAILED"},
+	{Value: uint(DeviceStateReasonDHCPError), Name: "NM_DEVICE_STATE_REASON_DHCP_ERROR"},
+	{Value: uint(DeviceStateReasonDHCPFailed), Name: "NM_DEVICE_STATE_REASON_DHCP_FAILED"},
+	{Value: uint(DeviceStateReasonSharedStartFailed), Name: "NM_DEVICE_STATE_REASON_SHARED_START_FAILED"},
+	{Value: uint(DeviceStateReasonSharedFailed), Name: "NM_DEVICE_STATE_REASON_SHARED_FAILED"},
+	{Value: uint(DeviceStateReasonAutoIPStartFailed), Name: "NM_DEVICE_STATE_REASON_AUTOIP_START_FAILED"},
+	{Value: uint(DeviceStateReasonAutoIPError), Name: "NM_DEVICE_STATE_REASON_AUTOIP_ERROR"},
+	{Value: uint(DeviceStateReasonAutoIPFailed), Name: "NM_DEVICE_STATE_REASON_AUTOIP_FAILED"},
+	{Value: uint(DeviceStateReasonModemBusy), Name: "NM_DEVICE_STATE_REASON_MODEM_BUSY"},
+	{Value: uint(DeviceStateReasonModemNoDialTone), Name: "NM_DEVICE_STATE_REASON_MODEM_NO_DIAL_TONE"},
+	{Value: uint(DeviceStateReasonModemNoCarrier), Name: "NM_DEVICE_STATE_REASON_MODEM_NO_CARRIER"},
+	{Value: uint(DeviceStateReasonModemDialTimeout), Name: "NM_DEVICE_STATE_REASON_MODEM_DIAL_TIMEOUT"},
+	{Value: uint(DeviceStateReasonModemDialFailed), Name: "NM_DEVICE_STATE_REASON_MODEM_DIAL_FAILED"},
+	{Value: uint(DeviceStateReasonModemInitFailed), Name: "NM_DEVICE_STATE_REASON_MODEM_INIT_FAILED"},
+	{Value: uint(DeviceStateReasonGSMAPNFailed), Name: "NM_DEVICE_STATE_REASON_GSM_APN_FAILED"},
+	{Value: uint(DeviceStateReasonGSMRegistrationNotSearching), Name: "NM_DEVICE_STATE_REASON_GSM_REGISTRATION_NOT_SEARCHING"},
+	{Value: uint(DeviceStateReasonGSMRegistrationDenied), Name: "NM_DEVICE_STATE_REASON_GSM_REGISTRATION_DENIED"},
+	{Value: uint(DeviceStateReasonGSMRegistrationTimeout), Name: "NM_DEVICE_STATE_REASON_GSM_REGISTRATION_TIMEOUT"},
+	{Value: uint(DeviceStateReasonGSMRegistrationFailed), Name: "NM_DEVICE_STATE_REASON_GSM_REGISTRATION_FAILED"},
+	{Value: uint(DeviceStateReasonGSMPINCheckFailed), Name: "NM_DEVICE_STATE_REASON_GSM_PIN_CHECK_FAILED"},
+	{Value: uint(DeviceStateReasonFirmwareMissing), Name: "NM_DEVICE_STATE_REASON_FIRMWARE_MISSING"},
+	{Value: uint(DeviceStateReasonRemoved), Name: "NM_DEVICE_STATE_REASON_REMOVED"},
+	{Value: uint(DeviceStateReasonSleeping), Name: "NM_DEVICE_STATE_REASON_SLEEPING"},
+	{Value: uint(DeviceStateReasonConnectionRemoved), Name: "NM_DEVICE_STATE_REASON_CONNECTION_REMOVED"},
+	{Value: uint(DeviceStateReasonUserRequested), Name: "NM_DEVICE_STATE_REASON_USER_REQUESTED"},
+	{Value: uint(DeviceStateReasonCarrier), Name: "NM_DEVICE_STATE_REASON_CARRIER"},
+	{Value: uint(DeviceStateReasonConnectionAssumed), Name: "NM_DEVICE_STATE_REASON_CONNECTION_ASSUMED"},
+	{Value: uint(DeviceStateReasonSupplicantAvailable), Name: "NM_DEVICE_STATE_REASON_SUPPLICANT_AVAILABLE"},
+	{Value: uint(DeviceStateReasonModemNotFound), Name: "NM_DEVICE_STATE_REASON_MODEM_NOT_FOUND"},
+	{Value: uint(DeviceStateReasonBTFailed), Name: "NM_DEVICE_STATE_REASON_BT_FAILED"},
+	{Value: uint(DeviceStateReasonGSMSIMNotInserted), Name: "NM_DEVICE_STATE_REASON_GSM_SIM_NOT_INSERTED"},
+	{Value: uint(DeviceStateReasonGSMSIMPINRequired), Name: "NM_DEVICE_STATE_REASON_GSM_SIM_PIN_REQUIRED"},
+	{Value: uint(DeviceStateReasonGSMSIMPUKRequired), Name: "NM_DEVICE_STATE_REASON_GSM_SIM_PUK_REQUIRED"},
+	{Value: uint(DeviceStateReasonGSMSIMWrong), Name: "NM_DEVICE_STATE_REASON_GSM_SIM_WRONG"},
+	{Value: uint(DeviceStateReasonInfinibandMode), Name: "NM_DEVICE_STATE_REASON_INFINIBAND_MODE"},
+	{Value: uint(DeviceStateReasonDependencyFailed), Name: "NM_DEVICE_STATE_REASON_DEPENDENCY_FAILED"},
+	{Value: uint(DeviceStateReasonBR2684Failed), Name: "NM_DEVICE_STATE_REASON_BR2684_FAILED"},
+	{Value: uint(DeviceStateReasonModemManagerUnavailable), Name: "NM_DEVICE_STATE_REASON_MODEM_MANAGER_UNAVAILABLE"},
+	{Value: uint(DeviceStateReasonSSIDNotFound), Name: "NM_DEVICE_STATE_REASON_SSID_NOT_FOUND"},
+	{Value: uint(DeviceStateReasonSecondaryConnectionFailed), Name: "NM_DEVICE_STATE_REASON_SECONDARY_CONNECTION_FAILED"},
+	{Value: uint(DeviceStateReasonDCBFCoEFailed), Name: "NM_DEVICE_STATE_REASON_DCB_FCOE_FAILED"},
+	{Value: uint(DeviceStateReasonTeamdControlFailed), Name: "NM_DEVICE_STATE_REASON_TEAMD_CONTROL_FAILED"},
+	{Value: uint(DeviceStateReasonModemFailed), Name: "NM_DEVICE_STATE_REASON_MODEM_FAILED"},
+	{Value: uint(DeviceStateReasonModemAvailable), Name: "NM_DEVICE_STATE_REASON_MODEM_AVAILABLE"},
+	{Value: uint(DeviceStateReasonSIMPINIncorrect), Name: "NM_DEVICE_STATE_REASON_SIM_PIN_INCORRECT"},
+	{Value: uint(DeviceStateReasonNewActivation), Name: "NM_DEVICE_STATE_REASON_NEW_ACTIVATION"},
+	{Value: uint(DeviceStateReasonParentChanged), Name: "NM_DEVICE_STATE_REASON_PARENT_CHANGED"},
+	{Value: uint(DeviceStateReasonParentManagedChanged), Name: "NM_DEVICE_STATE_REASON_PARENT_MANAGED_CHANGED"},
+	{Value: uint(DeviceStateReasonOVSDBFailed), Name: "NM_DEVICE_STATE_REASON_OVSDB_FAILED"},
+	{Value: uint(DeviceStateReasonIPAddressDuplicate), Name: "NM_DEVICE_STATE_REASON_IP_ADDRESS_DUPLICATE"},
+	{Value: uint(DeviceStateReasonIPMethodUnsupported), Name: "NM_DEVICE_STATE_REASON_IP_METHOD_UNSUPPORTED"},
+	{Value: uint(DeviceStateReasonSRIOVConfigurationFailed), Name: "NM_DEVICE_STATE_REASON_SRIOV_CONFIGURATION_FAILED"},
+	{Value: uint(DeviceStateReasonPeerNotFound), Name: "NM_DEVICE_STATE_REASON_PEER_NOT_FOUND"},
+}
+
+func (r DeviceStateReason) String() string {
+	return enums.String(uint(r), deviceStateReasonNames)
+}
+
+// ParseDeviceStateReason returns the DeviceStateReason named s, as returned by DeviceStateReason.String.
+func ParseDeviceStateReason(s string) (DeviceStateReason, error) {
+	v, err := enums.Parse("DeviceStateReason", s, deviceStateReasonNames)
+	return DeviceStateReason(v), err
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (r DeviceStateReason) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (r *DeviceStateReason) UnmarshalText(text []byte) error {
+	v, err := ParseDeviceStateReason(string(text))
+	if err != nil {
+		return err
+	}
+	*r = v
+	return nil
+}
@@ -0,0 +1,189 @@
+package netmgr
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+
+	"github.com/nlepage/go-netmgr/internal/dbusext"
+)
+
+// DeviceStatisticsIface is the Device Statistics interface.
+const DeviceStatisticsIface = "org.freedesktop.NetworkManager.Device.Statistics"
+
+// ThroughputSample is a sample of the traffic counters of a device, with the throughput since the previous sample.
+type ThroughputSample struct {
+	// Time is the time the sample was received.
+	Time time.Time
+
+	// TxBytes and RxBytes are the transmitted and received bytes counters.
+	TxBytes, RxBytes uint64
+
+	// TxRate and RxRate are the transmit and receive throughputs since the previous sample, in bytes per second.
+	TxRate, RxRate float64
+}
+
+func (d *device) RefreshRateMs() (uint32, error) {
+	return d.GetUProperty(DeviceStatisticsIface + ".RefreshRateMs")
+}
+
+func (d *device) SetRefreshRateMs(value uint32) error {
+	return d.SetProperty(DeviceStatisticsIface+".RefreshRateMs", dbus.MakeVariant(value))
+}
+
+func (d *device) TxBytes() (uint64, error) {
+	return d.GetTProperty(DeviceStatisticsIface + ".TxBytes")
+}
+
+func (d *device) RxBytes() (uint64, error) {
+	return d.GetTProperty(DeviceStatisticsIface + ".RxBytes")
+}
+
+// countersChange is a change of the statistics counters, ok is false for changes of other interfaces.
+type countersChange struct {
+	txBytes, rxBytes *uint64
+	ok               bool
+}
+
+func (d *device) SampleThroughput(ctx context.Context, interval time.Duration, fn func(ThroughputSample)) (err error) {
+	if interval < time.Millisecond {
+		return fmt.Errorf("sampling interval must be at least 1ms, got %s", interval)
+	}
+
+	previousRate, err := d.RefreshRateMs()
+	if err != nil {
+		return err
+	}
+
+	// the counters are refreshed together, so the whole PropertiesChanged signal is watched instead of each property
+	changes := make(chan countersChange)
+	if err := d.BodySignal(dbusext.PropertiesIface, "PropertiesChanged", changes, func(body []interface{}) countersChange {
+		var change countersChange
+		if len(body) < 2 {
+			return change
+		}
+		if iface, _ := body[0].(string); iface != DeviceStatisticsIface {
+			return change
+		}
+		changed, _ := body[1].(map[string]dbus.Variant)
+		if txBytes, ok := changed["TxBytes"].Value().(uint64); ok {
+			change.txBytes = &txBytes
+		}
+		if rxBytes, ok := changed["RxBytes"].Value().(uint64); ok {
+			change.rxBytes = &rxBytes
+		}
+		change.ok = change.txBytes != nil || change.rxBytes != nil
+		return change
+	}); err != nil {
+		return err
+	}
+	defer d.RemovePropertyChanged(changes)
+
+	// the refresh rate is set first, the counters are only refreshed at the new rate from then on
+	if err := d.SetRefreshRateMs(uint32(interval / time.Millisecond)); err != nil {
+		return err
+	}
+	defer func() {
+		// restoring the refresh rate emits PropertiesChanged, which must not be sent to changes as it is not read anymore
+		d.RemovePropertyChanged(changes)
+
+		if restoreErr := d.SetRefreshRateMs(previousRate); restoreErr != nil {
+			if err != nil {
+				err = fmt.Errorf("%w (restoring refresh rate failed: %v)", err, restoreErr)
+			} else {
+				err = fmt.Errorf("restoring refresh rate failed: %w", restoreErr)
+			}
+		}
+	}()
+
+	// the current counters are only used for a counter missing from the first refresh, not as a baseline
+	var counters ThroughputSample
+	if counters.TxBytes, err = d.TxBytes(); err != nil {
+		return err
+	}
+	if counters.RxBytes, err = d.RxBytes(); err != nil {
+		return err
+	}
+
+	return sampleThroughput(ctx, counters, mergeChanges(ctx, changes), time.Now, fn)
+}
+
+// mergeChanges reads changes until ctx is done, so that a slow fn does not block the signals of the connection.
+//
+// The changes received while the previous one is not read yet are merged, the counters being cumulative.
+func mergeChanges(ctx context.Context, changes <-chan countersChange) <-chan countersChange {
+	merged := make(chan countersChange)
+	go func() {
+		var pending countersChange
+		for {
+			// nil while nothing is pending, so that nothing is sent
+			var out chan<- countersChange
+			if pending.ok {
+				out = merged
+			}
+			select {
+			case change := <-changes:
+				if change.ok {
+					pending = pending.merge(change)
+				}
+			case out <- pending:
+				pending = countersChange{}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return merged
+}
+
+// merge returns next, with the counters of c missing from next.
+func (c countersChange) merge(next countersChange) countersChange {
+	if next.txBytes == nil {
+		next.txBytes = c.txBytes
+	}
+	if next.rxBytes == nil {
+		next.rxBytes = c.rxBytes
+	}
+	return next
+}
+
+// sampleThroughput calls fn for each change received on changes after the first one, which is used as the baseline.
+func sampleThroughput(ctx context.Context, last ThroughputSample, changes <-chan countersChange, now func() time.Time, fn func(ThroughputSample)) error {
+	baseline := true
+	for {
+		select {
+		case change := <-changes:
+			if !change.ok {
+				continue
+			}
+			sample := ThroughputSample{Time: now(), TxBytes: last.TxBytes, RxBytes: last.RxBytes}
+			if change.txBytes != nil {
+				sample.TxBytes = *change.txBytes
+			}
+			if change.rxBytes != nil {
+				sample.RxBytes = *change.rxBytes
+			}
+			if baseline {
+				baseline = false
+				last = sample
+				continue
+			}
+			sample.TxRate = byteRate(last.TxBytes, sample.TxBytes, sample.Time.Sub(last.Time))
+			sample.RxRate = byteRate(last.RxBytes, sample.RxBytes, sample.Time.Sub(last.Time))
+			fn(sample)
+			last = sample
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// byteRate returns the throughput in bytes per second from counter previous to current during elapsed, 0 if the counter was reset.
+func byteRate(previous, current uint64, elapsed time.Duration) float64 {
+	if current < previous || elapsed <= 0 {
+		return 0
+	}
+	return float64(current-previous) / elapsed.Seconds()
+}
@@ -0,0 +1,138 @@
+package netmgr
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/godbus/dbus/v5/prop"
+
+	"github.com/nlepage/go-netmgr/internal/dbusext"
+)
+
+func TestSampleThroughput(t *testing.T) {
+	conn := privateBus(t)
+
+	const devicePath = dbus.ObjectPath(NetworkManagerPath + "/Devices/1")
+
+	export(t, conn, BusName, devicePath, map[string]map[string]*prop.Prop{
+		DeviceIface: {
+			"DeviceType": {Value: uint32(DeviceTypeGeneric)},
+		},
+		DeviceStatisticsIface: {
+			"RefreshRateMs": {Value: uint32(0), Writable: true, Emit: prop.EmitTrue, Callback: func(c *prop.Change) *dbus.Error {
+				if c.Value.(uint32) != 0 {
+					// the counters are refreshed twice once sampling starts, each time in a single signal as NetworkManager does,
+					// with a delay as signals sent at once may be delivered out of order
+					go func() {
+						for _, n := range []uint64{1000, 3000} {
+							time.Sleep(50 * time.Millisecond)
+							conn.Emit(devicePath, dbusext.PropertiesIface+".PropertiesChanged", DeviceStatisticsIface, map[string]dbus.Variant{
+								"TxBytes": dbus.MakeVariant(n),
+								"RxBytes": dbus.MakeVariant(2 * n),
+							}, []string{})
+						}
+					}()
+				}
+				return nil
+			}},
+			"TxBytes": {Value: uint64(0)},
+			"RxBytes": {Value: uint64(0)},
+		},
+	})
+
+	d := NewDevice(conn, devicePath)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var samples []ThroughputSample
+	if err := d.SampleThroughput(ctx, time.Second, func(sample ThroughputSample) {
+		samples = append(samples, sample)
+		cancel()
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(samples) != 1 {
+		t.Fatalf("expected 1 sample, got %d", len(samples))
+	}
+	if sample := samples[0]; sample.TxBytes != 3000 || sample.RxBytes != 6000 || sample.TxRate <= 0 || sample.RxRate != 2*sample.TxRate {
+		t.Errorf("unexpected sample %+v", sample)
+	}
+	if rate, err := d.RefreshRateMs(); err != nil || rate != 0 {
+		t.Errorf("RefreshRateMs() = %d, %v, expected the previous rate 0", rate, err)
+	}
+}
+
+func TestSampleThroughputRate(t *testing.T) {
+	start := time.Now()
+	times := []time.Time{start, start.Add(2 * time.Second), start.Add(3 * time.Second)}
+	now := func() time.Time {
+		next := times[0]
+		times = times[1:]
+		return next
+	}
+
+	changes := make(chan countersChange)
+	go func() {
+		for _, counters := range [][2]uint64{{1000, 500}, {5000, 4500}, {5500, 100}} {
+			txBytes, rxBytes := counters[0], counters[1]
+			changes <- countersChange{txBytes: &txBytes, rxBytes: &rxBytes, ok: true}
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var samples []ThroughputSample
+	if err := sampleThroughput(ctx, ThroughputSample{}, changes, now, func(sample ThroughputSample) {
+		if samples = append(samples, sample); len(samples) == 2 {
+			cancel()
+		}
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(samples) != 2 {
+		t.Fatalf("expected 2 samples, got %d", len(samples))
+	}
+	// the first change is the baseline, the rates are computed from it
+	if sample := samples[0]; sample.TxRate != 2000 || sample.RxRate != 2000 {
+		t.Errorf("unexpected first sample %+v", sample)
+	}
+	// RxBytes was reset, so its rate is unknown
+	if sample := samples[1]; sample.TxRate != 500 || sample.RxRate != 0 {
+		t.Errorf("unexpected second sample %+v", sample)
+	}
+}
+
+func TestMergeChanges(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	changes := make(chan countersChange)
+	merged := mergeChanges(ctx, changes)
+
+	// both changes are received while merged is not read
+	tx1, rx1, tx2 := uint64(1000), uint64(2000), uint64(3000)
+	changes <- countersChange{txBytes: &tx1, rxBytes: &rx1, ok: true}
+	changes <- countersChange{ok: false}
+	changes <- countersChange{txBytes: &tx2, ok: true}
+
+	select {
+	case change := <-merged:
+		if !change.ok || change.txBytes == nil || *change.txBytes != 3000 || change.rxBytes == nil || *change.rxBytes != 2000 {
+			t.Errorf("unexpected merged change %+v", change)
+		}
+	case <-ctx.Done():
+		t.Fatal("timeout waiting for the merged change")
+	}
+
+	select {
+	case change := <-merged:
+		t.Errorf("unexpected change %+v", change)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
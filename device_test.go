@@ -0,0 +1,24 @@
+package netmgr
+
+import (
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+)
+
+func TestNewDevicesRemovedDevice(t *testing.T) {
+	conn := privateBus(t)
+
+	// no object is exported on the paths, as if the devices had been removed after being listed
+	paths := []dbus.ObjectPath{NetworkManagerPath + "/Devices/1", NetworkManagerPath + "/Devices/2"}
+
+	devices := NewDevices(conn, paths)
+	if len(devices) != len(paths) {
+		t.Fatalf("expected %d devices, got %d", len(paths), len(devices))
+	}
+	for i, d := range devices {
+		if _, ok := d.(*device); !ok {
+			t.Errorf("expected a base device for %s, got %T", paths[i], d)
+		}
+	}
+}
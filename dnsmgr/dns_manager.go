@@ -2,9 +2,15 @@
 package dnsmgr
 
 import (
+	"fmt"
+	"net"
+	"reflect"
+	"strings"
+
 	"github.com/godbus/dbus/v5"
 
 	"github.com/nlepage/go-netmgr/internal/dbusext"
+	netmgrutil "github.com/nlepage/go-netmgr/util"
 )
 
 // BusName of NetworkManager.
@@ -27,12 +33,36 @@ type (
 
 		Mode() (string, error)
 		RcManager() (string, error)
-		Configuration() ([]map[string]interface{}, error)
+		Configuration() ([]DNSConfigEntry, error)
+
+		// Properties changes
+
+		ModeChanged(ch chan<- string) error
+		RcManagerChanged(ch chan<- string) error
+		ConfigurationChanged(ch chan<- []DNSConfigEntry) error
 	}
 
 	dnsManager struct {
 		dbusext.BusObject
 	}
+
+	// DNSConfigEntry is an entry of the DNS configuration, for a connection.
+	DNSConfigEntry struct {
+		// Nameservers are the IP addresses of the name servers.
+		Nameservers []net.IP
+
+		// Domains are the search domains, and the domains for which the name servers are used.
+		Domains []string
+
+		// Interface is the name of the interface of the connection, empty if none.
+		Interface string
+
+		// Priority is the priority of the entry, lower values are preferred.
+		Priority int32
+
+		// Vpn indicates the connection is a VPN.
+		Vpn bool
+	}
 )
 
 // New returns the DNS Manager from conn.
@@ -43,13 +73,13 @@ func New(conn *dbus.Conn) DNSManager {
 // System returns the DNS Manager from conn.
 //
 // It is equivalent to:
-//  conn, err := dbus.SystemBus()
+//  conn, err := netmgrutil.SystemBus()
 //  if err != nil {
 //      // Manage error
 //  }
-//  nm := dnsmgr.New(conn)
+//  dm := dnsmgr.New(conn)
 func System() (DNSManager, error) {
-	conn, err := dbus.SystemBus()
+	conn, err := netmgrutil.SystemBus()
 	if err != nil {
 		return nil, err
 	}
@@ -86,17 +116,97 @@ func RcManager() (string, error) {
 	return dm.RcManager()
 }
 
-func (dm *dnsManager) Configuration() ([]map[string]interface{}, error) {
-	return dm.GetAASVProperty(DNSManagerIface + ".Configuration")
+func (dm *dnsManager) Configuration() ([]DNSConfigEntry, error) {
+	p, err := dm.GetProperty(DNSManagerIface + ".Configuration")
+	if err != nil {
+		return nil, err
+	}
+	entries, ok := p.Value().([]map[string]dbus.Variant)
+	if !ok {
+		return nil, fmt.Errorf("unexpected Configuration type %s", p.Signature())
+	}
+	return newDNSConfigEntries(entries), nil
 }
 
 // Configuration is the current DNS configuration represented as an array of dictionaries.
 //
 // See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.DnsManager.html#gdbus-property-org-freedesktop-NetworkManager-DnsManager.Configuration for more information.
-func Configuration() ([]map[string]interface{}, error) {
+func Configuration() ([]DNSConfigEntry, error) {
 	dm, err := System()
 	if err != nil {
 		return nil, err
 	}
 	return dm.Configuration()
 }
+
+func (dm *dnsManager) ModeChanged(ch chan<- string) error {
+	return dm.PropertyChanged(DNSManagerIface, "Mode", reflect.TypeOf(""), ch, nil)
+}
+
+// ModeChanged sends the new DNS processing mode to ch each time it changes.
+//
+// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.DnsManager.html#gdbus-property-org-freedesktop-NetworkManager-DnsManager.Mode for more information.
+func ModeChanged(ch chan<- string) error {
+	dm, err := System()
+	if err != nil {
+		return err
+	}
+	return dm.ModeChanged(ch)
+}
+
+func (dm *dnsManager) RcManagerChanged(ch chan<- string) error {
+	return dm.PropertyChanged(DNSManagerIface, "RcManager", reflect.TypeOf(""), ch, nil)
+}
+
+// RcManagerChanged sends the new resolv.conf management mode to ch each time it changes.
+//
+// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.DnsManager.html#gdbus-property-org-freedesktop-NetworkManager-DnsManager.RcManager for more information.
+func RcManagerChanged(ch chan<- string) error {
+	dm, err := System()
+	if err != nil {
+		return err
+	}
+	return dm.RcManagerChanged(ch)
+}
+
+func (dm *dnsManager) ConfigurationChanged(ch chan<- []DNSConfigEntry) error {
+	return dm.PropertyChanged(DNSManagerIface, "Configuration", reflect.TypeOf([]map[string]dbus.Variant(nil)), ch, newDNSConfigEntries)
+}
+
+// ConfigurationChanged sends the new DNS configuration to ch each time it changes.
+//
+// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.DnsManager.html#gdbus-property-org-freedesktop-NetworkManager-DnsManager.Configuration for more information.
+func ConfigurationChanged(ch chan<- []DNSConfigEntry) error {
+	dm, err := System()
+	if err != nil {
+		return err
+	}
+	return dm.ConfigurationChanged(ch)
+}
+
+func newDNSConfigEntries(aasv []map[string]dbus.Variant) []DNSConfigEntry {
+	entries := make([]DNSConfigEntry, len(aasv))
+	for i, asv := range aasv {
+		entries[i] = newDNSConfigEntry(asv)
+	}
+	return entries
+}
+
+func newDNSConfigEntry(asv map[string]dbus.Variant) DNSConfigEntry {
+	var entry DNSConfigEntry
+	nameservers, _ := asv["nameservers"].Value().([]string)
+	for _, nameserver := range nameservers {
+		// link-local name servers may have a zone, such as fe80::1%eth0
+		if i := strings.IndexByte(nameserver, '%'); i != -1 {
+			nameserver = nameserver[:i]
+		}
+		if ip := net.ParseIP(nameserver); ip != nil {
+			entry.Nameservers = append(entry.Nameservers, ip)
+		}
+	}
+	entry.Domains, _ = asv["domains"].Value().([]string)
+	entry.Interface, _ = asv["interface"].Value().(string)
+	entry.Priority, _ = asv["priority"].Value().(int32)
+	entry.Vpn, _ = asv["vpn"].Value().(bool)
+	return entry
+}
@@ -0,0 +1,104 @@
+package dnsmgr
+
+import (
+	"bufio"
+	"net"
+	"os/exec"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/godbus/dbus/v5/prop"
+)
+
+func TestNewDNSConfigEntries(t *testing.T) {
+	entries := newDNSConfigEntries([]map[string]dbus.Variant{
+		{
+			"nameservers": dbus.MakeVariant([]string{"192.168.1.1", "fe80::1%eth0", "not an ip"}),
+			"domains":     dbus.MakeVariant([]string{"example.com"}),
+			"interface":   dbus.MakeVariant("eth0"),
+			"priority":    dbus.MakeVariant(int32(100)),
+			"vpn":         dbus.MakeVariant(false),
+		},
+		{
+			"nameservers": dbus.MakeVariant([]string{"10.0.0.1"}),
+			"priority":    dbus.MakeVariant(int32(50)),
+			"vpn":         dbus.MakeVariant(true),
+		},
+	})
+
+	expected := []DNSConfigEntry{
+		{
+			// the zone is stripped, and the invalid name server is skipped
+			Nameservers: []net.IP{net.ParseIP("192.168.1.1"), net.ParseIP("fe80::1")},
+			Domains:     []string{"example.com"},
+			Interface:   "eth0",
+			Priority:    100,
+		},
+		{
+			Nameservers: []net.IP{net.ParseIP("10.0.0.1")},
+			Priority:    50,
+			Vpn:         true,
+		},
+	}
+	if !reflect.DeepEqual(entries, expected) {
+		t.Errorf("expected %+v, got %+v", expected, entries)
+	}
+}
+
+func TestConfigurationUnexpectedType(t *testing.T) {
+	conn := privateBus(t)
+
+	if reply, err := conn.RequestName(BusName, dbus.NameFlagDoNotQueue); err != nil || reply != dbus.RequestNameReplyPrimaryOwner {
+		t.Fatalf("could not own %s: %v", BusName, err)
+	}
+	if _, err := prop.Export(conn, DNSManagerPath, map[string]map[string]*prop.Prop{
+		DNSManagerIface: {"Configuration": {Value: "not an array"}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := New(conn).Configuration(); err == nil || !strings.Contains(err.Error(), "unexpected Configuration type") {
+		t.Errorf("expected an unexpected type error, got %v", err)
+	}
+}
+
+// privateBus starts a private dbus-daemon and connects to it, the test is skipped if dbus-daemon is unavailable.
+func privateBus(t *testing.T) *dbus.Conn {
+	daemon, err := exec.LookPath("dbus-daemon")
+	if err != nil {
+		t.Skip("dbus-daemon is unavailable")
+	}
+
+	cmd := exec.Command(daemon, "--session", "--nofork", "--print-address=1")
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cmd.Start(); err != nil {
+		t.Skipf("dbus-daemon could not be started: %v", err)
+	}
+	t.Cleanup(func() {
+		cmd.Process.Kill()
+		cmd.Wait()
+	})
+
+	address, err := bufio.NewReader(stdout).ReadString('\n')
+	if err != nil {
+		t.Skipf("dbus-daemon did not print its address: %v", err)
+	}
+
+	conn, err := dbus.Dial(strings.TrimSpace(address))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	if err := conn.Auth(nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := conn.Hello(); err != nil {
+		t.Fatal(err)
+	}
+	return conn
+}
@@ -0,0 +1,73 @@
+package netmgr
+
+const (
+	// DummyDeviceIface is the Dummy Device interface.
+	DummyDeviceIface = "org.freedesktop.NetworkManager.Device.Dummy"
+
+	// LoopbackDeviceIface is the Loopback Device interface.
+	LoopbackDeviceIface = "org.freedesktop.NetworkManager.Device.Loopback"
+
+	// PppDeviceIface is the PPP Device interface.
+	PppDeviceIface = "org.freedesktop.NetworkManager.Device.Ppp"
+)
+
+type (
+	// DummyDevice represents a dummy device.
+	//
+	// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Dummy.html for more information.
+	DummyDevice interface {
+		Device
+		isDummyDevice()
+
+		// Properties
+
+		// HwAddress is the hardware address of the device.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Dummy.html#gdbus-property-org-freedesktop-NetworkManager-Device-Dummy.HwAddress for more information.
+		HwAddress() (string, error)
+	}
+
+	dummyDevice struct {
+		device
+	}
+
+	// LoopbackDevice represents a loopback device, it has no specific properties.
+	//
+	// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Loopback.html for more information.
+	LoopbackDevice interface {
+		Device
+		isLoopbackDevice()
+	}
+
+	loopbackDevice struct {
+		device
+	}
+
+	// PppDevice represents a PPP device, it has no specific properties.
+	//
+	// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Ppp.html for more information.
+	PppDevice interface {
+		Device
+		isPppDevice()
+	}
+
+	pppDevice struct {
+		device
+	}
+)
+
+var (
+	_ DummyDevice    = (*dummyDevice)(nil)
+	_ LoopbackDevice = (*loopbackDevice)(nil)
+	_ PppDevice      = (*pppDevice)(nil)
+)
+
+func (*dummyDevice) isDummyDevice() {}
+
+func (*loopbackDevice) isLoopbackDevice() {}
+
+func (*pppDevice) isPppDevice() {}
+
+func (d *dummyDevice) HwAddress() (string, error) {
+	return d.GetSProperty(DummyDeviceIface + ".HwAddress")
+}
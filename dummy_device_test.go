@@ -0,0 +1,23 @@
+package netmgr
+
+import "testing"
+
+func TestDeviceTypeAssertions(t *testing.T) {
+	// the devices having the methods of another device type must not be asserted to it
+	var wired Device = &wiredDevice{}
+	if _, ok := wired.(DummyDevice); ok {
+		t.Error("WiredDevice satisfies DummyDevice")
+	}
+	var lowpan Device = &lowpanDevice{}
+	if _, ok := lowpan.(WpanDevice); ok {
+		t.Error("LowpanDevice satisfies WpanDevice")
+	}
+	var ovsPort Device = &ovsPortDevice{}
+	if _, ok := ovsPort.(OvsBridgeDevice); ok {
+		t.Error("OvsPortDevice satisfies OvsBridgeDevice")
+	}
+	var generic Device = &genericDevice{}
+	if _, ok := generic.(LoopbackDevice); ok {
+		t.Error("GenericDevice satisfies LoopbackDevice")
+	}
+}
@@ -0,0 +1,42 @@
+package netmgr
+
+// GenericDeviceIface is the Generic Device interface.
+const GenericDeviceIface = "org.freedesktop.NetworkManager.Device.Generic"
+
+type (
+	// GenericDevice represents a device of a type not otherwise supported by NetworkManager.
+	//
+	// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Generic.html for more information.
+	GenericDevice interface {
+		Device
+		isGenericDevice()
+
+		// Properties
+
+		// HwAddress is the hardware address of the device.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Generic.html#gdbus-property-org-freedesktop-NetworkManager-Device-Generic.HwAddress for more information.
+		HwAddress() (string, error)
+
+		// TypeDescription is a descriptive name of the type of the device.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Generic.html#gdbus-property-org-freedesktop-NetworkManager-Device-Generic.TypeDescription for more information.
+		TypeDescription() (string, error)
+	}
+
+	genericDevice struct {
+		device
+	}
+)
+
+var _ GenericDevice = (*genericDevice)(nil)
+
+func (*genericDevice) isGenericDevice() {}
+
+func (d *genericDevice) HwAddress() (string, error) {
+	return d.GetSProperty(GenericDeviceIface + ".HwAddress")
+}
+
+func (d *genericDevice) TypeDescription() (string, error) {
+	return d.GetSProperty(GenericDeviceIface + ".TypeDescription")
+}
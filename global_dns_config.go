@@ -0,0 +1,114 @@
+package netmgr
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// GlobalDNSDefaultDomain is the name of the default domain of the global DNS configuration, used for all queries not matching another domain.
+const GlobalDNSDefaultDomain = "*"
+
+type (
+	// GlobalDNSConfig is the global DNS configuration, overriding the DNS configuration of connections.
+	//
+	// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.html#gdbus-property-org-freedesktop-NetworkManager.GlobalDnsConfiguration for more information.
+	GlobalDNSConfig struct {
+		// Searches are the search domains.
+		Searches []string
+
+		// Options are the resolver options.
+		Options []string
+
+		// Domains are the per-domain name servers and options, GlobalDNSDefaultDomain must be present if Domains is not empty.
+		Domains []GlobalDNSDomain
+	}
+
+	// GlobalDNSDomain is the configuration of the name servers for a domain in the global DNS configuration.
+	GlobalDNSDomain struct {
+		// Name is the domain name, or GlobalDNSDefaultDomain.
+		Name string
+
+		// Servers are the IP addresses of the name servers.
+		Servers []string
+
+		// Options are the resolver options for the domain.
+		Options []string
+	}
+)
+
+// Validate checks the global DNS configuration is accepted by NetworkManager.
+func (c GlobalDNSConfig) Validate() error {
+	if len(c.Domains) == 0 {
+		return nil
+	}
+	names := make(map[string]bool, len(c.Domains))
+	for _, domain := range c.Domains {
+		if domain.Name == "" {
+			return errors.New("global DNS domain name is empty")
+		}
+		if names[domain.Name] {
+			return fmt.Errorf("global DNS domain %s is duplicated", domain.Name)
+		}
+		names[domain.Name] = true
+		for _, server := range domain.Servers {
+			if net.ParseIP(server) == nil {
+				return fmt.Errorf("global DNS server %q of domain %s is not an IP address", server, domain.Name)
+			}
+		}
+	}
+	if !names[GlobalDNSDefaultDomain] {
+		return fmt.Errorf("global DNS domain %s is required when domains are given", GlobalDNSDefaultDomain)
+	}
+	return nil
+}
+
+// Encode returns the D-Bus representation of c.
+func (c GlobalDNSConfig) Encode() map[string]dbus.Variant {
+	m := map[string]dbus.Variant{}
+	if len(c.Searches) != 0 {
+		m["searches"] = dbus.MakeVariant(c.Searches)
+	}
+	if len(c.Options) != 0 {
+		m["options"] = dbus.MakeVariant(c.Options)
+	}
+	if len(c.Domains) != 0 {
+		domains := make(map[string]dbus.Variant, len(c.Domains))
+		for _, domain := range c.Domains {
+			d := map[string]dbus.Variant{}
+			if len(domain.Servers) != 0 {
+				d["servers"] = dbus.MakeVariant(domain.Servers)
+			}
+			if len(domain.Options) != 0 {
+				d["options"] = dbus.MakeVariant(domain.Options)
+			}
+			domains[domain.Name] = dbus.MakeVariant(d)
+		}
+		m["domains"] = dbus.MakeVariant(domains)
+	}
+	return m
+}
+
+// DecodeGlobalDNSConfig returns the GlobalDNSConfig corresponding to the D-Bus representation m.
+//
+// Domains are sorted by name.
+func DecodeGlobalDNSConfig(m map[string]dbus.Variant) GlobalDNSConfig {
+	var c GlobalDNSConfig
+	c.Searches, _ = m["searches"].Value().([]string)
+	c.Options, _ = m["options"].Value().([]string)
+	domains, _ := m["domains"].Value().(map[string]dbus.Variant)
+	for name, v := range domains {
+		d, _ := v.Value().(map[string]dbus.Variant)
+		domain := GlobalDNSDomain{Name: name}
+		domain.Servers, _ = d["servers"].Value().([]string)
+		domain.Options, _ = d["options"].Value().([]string)
+		c.Domains = append(c.Domains, domain)
+	}
+	sort.Slice(c.Domains, func(i, j int) bool {
+		return c.Domains[i].Name < c.Domains[j].Name
+	})
+	return c
+}
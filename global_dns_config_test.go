@@ -0,0 +1,69 @@
+package netmgr
+
+import (
+	"bytes"
+	"encoding/binary"
+	"reflect"
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+)
+
+func TestGlobalDNSConfigRoundTrip(t *testing.T) {
+	c := GlobalDNSConfig{
+		Searches: []string{"example.com"},
+		Options:  []string{"rotate", "timeout:2"},
+		Domains: []GlobalDNSDomain{
+			{Name: GlobalDNSDefaultDomain, Servers: []string{"1.1.1.1", "2606:4700:4700::1111"}},
+			{Name: "corp.example.com", Servers: []string{"10.0.0.53"}, Options: []string{"ndots:2"}},
+		},
+	}
+
+	// send the encoded configuration through a D-Bus message, as the property is
+	msg := &dbus.Message{
+		Type: dbus.TypeSignal,
+		Headers: map[dbus.HeaderField]dbus.Variant{
+			dbus.FieldPath:      dbus.MakeVariant(dbus.ObjectPath(NetworkManagerPath)),
+			dbus.FieldInterface: dbus.MakeVariant(NetworkManagerInterface),
+			dbus.FieldMember:    dbus.MakeVariant("Test"),
+		},
+		Body: []interface{}{dbus.MakeVariant(c.Encode())},
+	}
+	msg.Headers[dbus.FieldSignature] = dbus.MakeVariant(dbus.SignatureOf(msg.Body...))
+	var buf bytes.Buffer
+	if err := msg.EncodeTo(&buf, binary.LittleEndian); err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := dbus.DecodeMessage(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := DecodeGlobalDNSConfig(decoded.Body[0].(dbus.Variant).Value().(map[string]dbus.Variant))
+
+	// c.Domains is sorted by name, as decoded domains are
+	if !reflect.DeepEqual(got, c) {
+		t.Errorf("DecodeGlobalDNSConfig(c.Encode()) = %#v, expected %#v", got, c)
+	}
+}
+
+func TestGlobalDNSConfigValidate(t *testing.T) {
+	tests := []struct {
+		c     GlobalDNSConfig
+		valid bool
+	}{
+		{GlobalDNSConfig{}, true},
+		{GlobalDNSConfig{Searches: []string{"example.com"}}, true},
+		{GlobalDNSConfig{Domains: []GlobalDNSDomain{{Name: "*", Servers: []string{"8.8.8.8"}}}}, true},
+		{GlobalDNSConfig{Domains: []GlobalDNSDomain{{Name: "example.com", Servers: []string{"8.8.8.8"}}}}, false},
+		{GlobalDNSConfig{Domains: []GlobalDNSDomain{{Name: "*", Servers: []string{"dns.google"}}}}, false},
+		{GlobalDNSConfig{Domains: []GlobalDNSDomain{{Name: "*"}, {Name: "*"}}}, false},
+		{GlobalDNSConfig{Domains: []GlobalDNSDomain{{Name: "*"}, {Name: ""}}}, false},
+	}
+
+	for _, test := range tests {
+		if err := test.c.Validate(); (err == nil) != test.valid {
+			t.Errorf("%#v.Validate() returned %v, expected valid=%v", test.c, err, test.valid)
+		}
+	}
+}
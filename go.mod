@@ -2,4 +2,7 @@ module github.com/nlepage/go-netmgr
 
 go 1.14
 
-require github.com/godbus/dbus/v5 v5.0.3
+require (
+	github.com/godbus/dbus/v5 v5.0.3
+	golang.org/x/crypto v0.0.0-20211117183948-ae814b36b871
+)
@@ -62,6 +62,18 @@ type ComplexityRoot struct {
 		Path      func(childComplexity int) int
 	}
 
+	GlobalDNSConfig struct {
+		Domains  func(childComplexity int) int
+		Options  func(childComplexity int) int
+		Searches func(childComplexity int) int
+	}
+
+	GlobalDNSDomain struct {
+		Name    func(childComplexity int) int
+		Options func(childComplexity int) int
+		Servers func(childComplexity int) int
+	}
+
 	Mutation struct {
 		NetworkManager func(childComplexity int, input model.NetworkManagerInput) int
 	}
@@ -77,6 +89,7 @@ type ComplexityRoot struct {
 		ConnectivityCheckEnabled   func(childComplexity int) int
 		ConnectivityCheckURI       func(childComplexity int) int
 		Devices                    func(childComplexity int) int
+		GlobalDNSConfiguration     func(childComplexity int) int
 		Metered                    func(childComplexity int) int
 		NetworkingEnabled          func(childComplexity int) int
 		PrimaryConnection          func(childComplexity int) int
@@ -161,6 +174,48 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Device.Path(childComplexity), true
 
+	case "GlobalDNSConfig.domains":
+		if e.complexity.GlobalDNSConfig.Domains == nil {
+			break
+		}
+
+		return e.complexity.GlobalDNSConfig.Domains(childComplexity), true
+
+	case "GlobalDNSConfig.options":
+		if e.complexity.GlobalDNSConfig.Options == nil {
+			break
+		}
+
+		return e.complexity.GlobalDNSConfig.Options(childComplexity), true
+
+	case "GlobalDNSConfig.searches":
+		if e.complexity.GlobalDNSConfig.Searches == nil {
+			break
+		}
+
+		return e.complexity.GlobalDNSConfig.Searches(childComplexity), true
+
+	case "GlobalDNSDomain.name":
+		if e.complexity.GlobalDNSDomain.Name == nil {
+			break
+		}
+
+		return e.complexity.GlobalDNSDomain.Name(childComplexity), true
+
+	case "GlobalDNSDomain.options":
+		if e.complexity.GlobalDNSDomain.Options == nil {
+			break
+		}
+
+		return e.complexity.GlobalDNSDomain.Options(childComplexity), true
+
+	case "GlobalDNSDomain.servers":
+		if e.complexity.GlobalDNSDomain.Servers == nil {
+			break
+		}
+
+		return e.complexity.GlobalDNSDomain.Servers(childComplexity), true
+
 	case "Mutation.networkManager":
 		if e.complexity.Mutation.NetworkManager == nil {
 			break
@@ -243,6 +298,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.NetworkManager.Devices(childComplexity), true
 
+	case "NetworkManager.globalDNSConfiguration":
+		if e.complexity.NetworkManager.GlobalDNSConfiguration == nil {
+			break
+		}
+
+		return e.complexity.NetworkManager.GlobalDNSConfiguration(childComplexity), true
+
 	case "NetworkManager.metered":
 		if e.complexity.NetworkManager.Metered == nil {
 			break
@@ -408,7 +470,7 @@ func (ec *executionContext) introspectType(name string) (*introspection.Type, er
 }
 
 var sources = []*ast.Source{
-	&ast.Source{Name: "schema.graphqls", Input: `type Query {
+	{Name: "schema.graphqls", Input: `type Query {
   networkManager: NetworkManager!
 }
 
@@ -441,14 +503,38 @@ type NetworkManager {
   connectivityCheckAvailable: Boolean!
   connectivityCheckEnabled: Boolean!
   connectivityCheckURI: String!
-  # FIXME globalDNSConfiguration
+  globalDNSConfiguration: GlobalDNSConfig!
 }
 
 input NetworkManagerInput {
   wirelessEnabled: Boolean
   wwanEnabled: Boolean
   connectivityCheckEnabled: Boolean
-  # FIXME globalDNSConfiguration
+  globalDNSConfiguration: GlobalDNSConfigInput
+}
+
+type GlobalDNSConfig {
+  searches: [String!]!
+  options: [String!]!
+  domains: [GlobalDNSDomain!]!
+}
+
+type GlobalDNSDomain {
+  name: String!
+  servers: [String!]!
+  options: [String!]!
+}
+
+input GlobalDNSConfigInput {
+  searches: [String!]
+  options: [String!]
+  domains: [GlobalDNSDomainInput!]
+}
+
+input GlobalDNSDomainInput {
+  name: String!
+  servers: [String!]
+  options: [String!]
 }
 
 type Device {
@@ -508,6 +594,7 @@ func (ec *executionContext) field_Mutation_networkManager_args(ctx context.Conte
 	args := map[string]interface{}{}
 	var arg0 model.NetworkManagerInput
 	if tmp, ok := rawArgs["input"]; ok {
+		ctx := graphql.WithFieldInputContext(ctx, graphql.NewFieldInputWithField("input"))
 		arg0, err = ec.unmarshalNNetworkManagerInput2githubᚗcomᚋnlepageᚋgoᚑnetmgrᚋgqlᚋmodelᚐNetworkManagerInput(ctx, tmp)
 		if err != nil {
 			return nil, err
@@ -522,6 +609,7 @@ func (ec *executionContext) field_Query___type_args(ctx context.Context, rawArgs
 	args := map[string]interface{}{}
 	var arg0 string
 	if tmp, ok := rawArgs["name"]; ok {
+		ctx := graphql.WithFieldInputContext(ctx, graphql.NewFieldInputWithField("name"))
 		arg0, err = ec.unmarshalNString2string(ctx, tmp)
 		if err != nil {
 			return nil, err
@@ -536,6 +624,7 @@ func (ec *executionContext) field___Type_enumValues_args(ctx context.Context, ra
 	args := map[string]interface{}{}
 	var arg0 bool
 	if tmp, ok := rawArgs["includeDeprecated"]; ok {
+		ctx := graphql.WithFieldInputContext(ctx, graphql.NewFieldInputWithField("includeDeprecated"))
 		arg0, err = ec.unmarshalOBoolean2bool(ctx, tmp)
 		if err != nil {
 			return nil, err
@@ -550,6 +639,7 @@ func (ec *executionContext) field___Type_fields_args(ctx context.Context, rawArg
 	args := map[string]interface{}{}
 	var arg0 bool
 	if tmp, ok := rawArgs["includeDeprecated"]; ok {
+		ctx := graphql.WithFieldInputContext(ctx, graphql.NewFieldInputWithField("includeDeprecated"))
 		arg0, err = ec.unmarshalOBoolean2bool(ctx, tmp)
 		if err != nil {
 			return nil, err
@@ -737,6 +827,210 @@ func (ec *executionContext) _Device_interface(ctx context.Context, field graphql
 	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
+func (ec *executionContext) _GlobalDNSConfig_searches(ctx context.Context, field graphql.CollectedField, obj *netmgr.GlobalDNSConfig) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:   "GlobalDNSConfig",
+		Field:    field,
+		Args:     nil,
+		IsMethod: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Searches, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]string)
+	fc.Result = res
+	return ec.marshalNString2ᚕstringᚄ(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _GlobalDNSConfig_options(ctx context.Context, field graphql.CollectedField, obj *netmgr.GlobalDNSConfig) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:   "GlobalDNSConfig",
+		Field:    field,
+		Args:     nil,
+		IsMethod: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Options, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]string)
+	fc.Result = res
+	return ec.marshalNString2ᚕstringᚄ(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _GlobalDNSConfig_domains(ctx context.Context, field graphql.CollectedField, obj *netmgr.GlobalDNSConfig) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:   "GlobalDNSConfig",
+		Field:    field,
+		Args:     nil,
+		IsMethod: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Domains, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]netmgr.GlobalDNSDomain)
+	fc.Result = res
+	return ec.marshalNGlobalDNSDomain2ᚕgithubᚗcomᚋnlepageᚋgoᚑnetmgrᚐGlobalDNSDomainᚄ(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _GlobalDNSDomain_name(ctx context.Context, field graphql.CollectedField, obj *netmgr.GlobalDNSDomain) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:   "GlobalDNSDomain",
+		Field:    field,
+		Args:     nil,
+		IsMethod: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Name, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _GlobalDNSDomain_servers(ctx context.Context, field graphql.CollectedField, obj *netmgr.GlobalDNSDomain) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:   "GlobalDNSDomain",
+		Field:    field,
+		Args:     nil,
+		IsMethod: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Servers, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]string)
+	fc.Result = res
+	return ec.marshalNString2ᚕstringᚄ(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _GlobalDNSDomain_options(ctx context.Context, field graphql.CollectedField, obj *netmgr.GlobalDNSDomain) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:   "GlobalDNSDomain",
+		Field:    field,
+		Args:     nil,
+		IsMethod: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Options, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]string)
+	fc.Result = res
+	return ec.marshalNString2ᚕstringᚄ(ctx, field.Selections, res)
+}
+
 func (ec *executionContext) _Mutation_networkManager(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
@@ -1458,6 +1752,40 @@ func (ec *executionContext) _NetworkManager_connectivityCheckURI(ctx context.Con
 	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
+func (ec *executionContext) _NetworkManager_globalDNSConfiguration(ctx context.Context, field graphql.CollectedField, obj netmgr.NetworkManager) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:   "NetworkManager",
+		Field:    field,
+		Args:     nil,
+		IsMethod: true,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.GlobalDNSConfiguration()
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(netmgr.GlobalDNSConfig)
+	fc.Result = res
+	return ec.marshalNGlobalDNSConfig2githubᚗcomᚋnlepageᚋgoᚑnetmgrᚐGlobalDNSConfig(ctx, field.Selections, res)
+}
+
 func (ec *executionContext) _Query_networkManager(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
@@ -2660,6 +2988,78 @@ func (ec *executionContext) ___Type_ofType(ctx context.Context, field graphql.Co
 
 // region    **************************** input.gotpl *****************************
 
+func (ec *executionContext) unmarshalInputGlobalDNSConfigInput(ctx context.Context, obj interface{}) (netmgr.GlobalDNSConfig, error) {
+	var it netmgr.GlobalDNSConfig
+	var asMap = obj.(map[string]interface{})
+
+	for k, v := range asMap {
+		switch k {
+		case "searches":
+			var err error
+
+			ctx := graphql.WithFieldInputContext(ctx, graphql.NewFieldInputWithField("searches"))
+			it.Searches, err = ec.unmarshalOString2ᚕstringᚄ(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "options":
+			var err error
+
+			ctx := graphql.WithFieldInputContext(ctx, graphql.NewFieldInputWithField("options"))
+			it.Options, err = ec.unmarshalOString2ᚕstringᚄ(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "domains":
+			var err error
+
+			ctx := graphql.WithFieldInputContext(ctx, graphql.NewFieldInputWithField("domains"))
+			it.Domains, err = ec.unmarshalOGlobalDNSDomainInput2ᚕgithubᚗcomᚋnlepageᚋgoᚑnetmgrᚐGlobalDNSDomainᚄ(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		}
+	}
+
+	return it, nil
+}
+
+func (ec *executionContext) unmarshalInputGlobalDNSDomainInput(ctx context.Context, obj interface{}) (netmgr.GlobalDNSDomain, error) {
+	var it netmgr.GlobalDNSDomain
+	var asMap = obj.(map[string]interface{})
+
+	for k, v := range asMap {
+		switch k {
+		case "name":
+			var err error
+
+			ctx := graphql.WithFieldInputContext(ctx, graphql.NewFieldInputWithField("name"))
+			it.Name, err = ec.unmarshalNString2string(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "servers":
+			var err error
+
+			ctx := graphql.WithFieldInputContext(ctx, graphql.NewFieldInputWithField("servers"))
+			it.Servers, err = ec.unmarshalOString2ᚕstringᚄ(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "options":
+			var err error
+
+			ctx := graphql.WithFieldInputContext(ctx, graphql.NewFieldInputWithField("options"))
+			it.Options, err = ec.unmarshalOString2ᚕstringᚄ(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		}
+	}
+
+	return it, nil
+}
+
 func (ec *executionContext) unmarshalInputNetworkManagerInput(ctx context.Context, obj interface{}) (model.NetworkManagerInput, error) {
 	var it model.NetworkManagerInput
 	var asMap = obj.(map[string]interface{})
@@ -2668,22 +3068,36 @@ func (ec *executionContext) unmarshalInputNetworkManagerInput(ctx context.Contex
 		switch k {
 		case "wirelessEnabled":
 			var err error
+
+			ctx := graphql.WithFieldInputContext(ctx, graphql.NewFieldInputWithField("wirelessEnabled"))
 			it.WirelessEnabled, err = ec.unmarshalOBoolean2ᚖbool(ctx, v)
 			if err != nil {
 				return it, err
 			}
 		case "wwanEnabled":
 			var err error
+
+			ctx := graphql.WithFieldInputContext(ctx, graphql.NewFieldInputWithField("wwanEnabled"))
 			it.WwanEnabled, err = ec.unmarshalOBoolean2ᚖbool(ctx, v)
 			if err != nil {
 				return it, err
 			}
 		case "connectivityCheckEnabled":
 			var err error
+
+			ctx := graphql.WithFieldInputContext(ctx, graphql.NewFieldInputWithField("connectivityCheckEnabled"))
 			it.ConnectivityCheckEnabled, err = ec.unmarshalOBoolean2ᚖbool(ctx, v)
 			if err != nil {
 				return it, err
 			}
+		case "globalDNSConfiguration":
+			var err error
+
+			ctx := graphql.WithFieldInputContext(ctx, graphql.NewFieldInputWithField("globalDNSConfiguration"))
+			it.GlobalDNSConfiguration, err = ec.unmarshalOGlobalDNSConfigInput2ᚖgithubᚗcomᚋnlepageᚋgoᚑnetmgrᚐGlobalDNSConfig(ctx, v)
+			if err != nil {
+				return it, err
+			}
 		}
 	}
 
@@ -2798,6 +3212,80 @@ func (ec *executionContext) _Device(ctx context.Context, sel ast.SelectionSet, o
 	return out
 }
 
+var globalDNSConfigImplementors = []string{"GlobalDNSConfig"}
+
+func (ec *executionContext) _GlobalDNSConfig(ctx context.Context, sel ast.SelectionSet, obj *netmgr.GlobalDNSConfig) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, globalDNSConfigImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	var invalids uint32
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("GlobalDNSConfig")
+		case "searches":
+			out.Values[i] = ec._GlobalDNSConfig_searches(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "options":
+			out.Values[i] = ec._GlobalDNSConfig_options(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "domains":
+			out.Values[i] = ec._GlobalDNSConfig_domains(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch()
+	if invalids > 0 {
+		return graphql.Null
+	}
+	return out
+}
+
+var globalDNSDomainImplementors = []string{"GlobalDNSDomain"}
+
+func (ec *executionContext) _GlobalDNSDomain(ctx context.Context, sel ast.SelectionSet, obj *netmgr.GlobalDNSDomain) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, globalDNSDomainImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	var invalids uint32
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("GlobalDNSDomain")
+		case "name":
+			out.Values[i] = ec._GlobalDNSDomain_name(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "servers":
+			out.Values[i] = ec._GlobalDNSDomain_servers(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "options":
+			out.Values[i] = ec._GlobalDNSDomain_options(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch()
+	if invalids > 0 {
+		return graphql.Null
+	}
+	return out
+}
+
 var mutationImplementors = []string{"Mutation"}
 
 func (ec *executionContext) _Mutation(ctx context.Context, sel ast.SelectionSet) graphql.Marshaler {
@@ -2940,6 +3428,11 @@ func (ec *executionContext) _NetworkManager(ctx context.Context, sel ast.Selecti
 			if out.Values[i] == graphql.Null {
 				invalids++
 			}
+		case "globalDNSConfiguration":
+			out.Values[i] = ec._NetworkManager_globalDNSConfiguration(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -3261,7 +3754,8 @@ func (ec *executionContext) ___Type(ctx context.Context, sel ast.SelectionSet, o
 // region    ***************************** type.gotpl *****************************
 
 func (ec *executionContext) unmarshalNBoolean2bool(ctx context.Context, v interface{}) (bool, error) {
-	return graphql.UnmarshalBoolean(v)
+	res, err := graphql.UnmarshalBoolean(v)
+	return res, graphql.WrapErrorWithInputPath(ctx, err)
 }
 
 func (ec *executionContext) marshalNBoolean2bool(ctx context.Context, sel ast.SelectionSet, v bool) graphql.Marshaler {
@@ -3286,9 +3780,10 @@ func (ec *executionContext) unmarshalNCapability2ᚕgithubᚗcomᚋnlepageᚋgo
 	var err error
 	res := make([]netmgr.Capability, len(vSlice))
 	for i := range vSlice {
+		ctx := graphql.WithFieldInputContext(ctx, graphql.NewFieldInputWithIndex(i))
 		res[i], err = ec.unmarshalOCapability2githubᚗcomᚋnlepageᚋgoᚑnetmgrᚐCapability(ctx, vSlice[i])
 		if err != nil {
-			return nil, err
+			return nil, graphql.WrapErrorWithInputPath(ctx, err)
 		}
 	}
 	return res, nil
@@ -3426,7 +3921,8 @@ func (ec *executionContext) marshalNConnectionActive2ᚕgithubᚗcomᚋnlepage
 }
 
 func (ec *executionContext) unmarshalNConnectivityState2githubᚗcomᚋnlepageᚋgoᚑnetmgrᚐConnectivityState(ctx context.Context, v interface{}) (netmgr.ConnectivityState, error) {
-	return model.UnmarshalConnectivityState(v)
+	res, err := model.UnmarshalConnectivityState(v)
+	return res, graphql.WrapErrorWithInputPath(ctx, err)
 }
 
 func (ec *executionContext) marshalNConnectivityState2githubᚗcomᚋnlepageᚋgoᚑnetmgrᚐConnectivityState(ctx context.Context, sel ast.SelectionSet, v netmgr.ConnectivityState) graphql.Marshaler {
@@ -3486,8 +3982,59 @@ func (ec *executionContext) marshalNDevice2ᚕgithubᚗcomᚋnlepageᚋgoᚑnetm
 	return ret
 }
 
+func (ec *executionContext) marshalNGlobalDNSConfig2githubᚗcomᚋnlepageᚋgoᚑnetmgrᚐGlobalDNSConfig(ctx context.Context, sel ast.SelectionSet, v netmgr.GlobalDNSConfig) graphql.Marshaler {
+	return ec._GlobalDNSConfig(ctx, sel, &v)
+}
+
+func (ec *executionContext) marshalNGlobalDNSDomain2githubᚗcomᚋnlepageᚋgoᚑnetmgrᚐGlobalDNSDomain(ctx context.Context, sel ast.SelectionSet, v netmgr.GlobalDNSDomain) graphql.Marshaler {
+	return ec._GlobalDNSDomain(ctx, sel, &v)
+}
+
+func (ec *executionContext) marshalNGlobalDNSDomain2ᚕgithubᚗcomᚋnlepageᚋgoᚑnetmgrᚐGlobalDNSDomainᚄ(ctx context.Context, sel ast.SelectionSet, v []netmgr.GlobalDNSDomain) graphql.Marshaler {
+	ret := make(graphql.Array, len(v))
+	var wg sync.WaitGroup
+	isLen1 := len(v) == 1
+	if !isLen1 {
+		wg.Add(len(v))
+	}
+	for i := range v {
+		i := i
+		fc := &graphql.FieldContext{
+			Index:  &i,
+			Result: &v[i],
+		}
+		ctx := graphql.WithFieldContext(ctx, fc)
+		f := func(i int) {
+			defer func() {
+				if r := recover(); r != nil {
+					ec.Error(ctx, ec.Recover(ctx, r))
+					ret = nil
+				}
+			}()
+			if !isLen1 {
+				defer wg.Done()
+			}
+			ret[i] = ec.marshalNGlobalDNSDomain2githubᚗcomᚋnlepageᚋgoᚑnetmgrᚐGlobalDNSDomain(ctx, sel, v[i])
+		}
+		if isLen1 {
+			f(i)
+		} else {
+			go f(i)
+		}
+
+	}
+	wg.Wait()
+	return ret
+}
+
+func (ec *executionContext) unmarshalNGlobalDNSDomainInput2githubᚗcomᚋnlepageᚋgoᚑnetmgrᚐGlobalDNSDomain(ctx context.Context, v interface{}) (netmgr.GlobalDNSDomain, error) {
+	res, err := ec.unmarshalInputGlobalDNSDomainInput(ctx, v)
+	return res, graphql.WrapErrorWithInputPath(ctx, err)
+}
+
 func (ec *executionContext) unmarshalNID2string(ctx context.Context, v interface{}) (string, error) {
-	return graphql.UnmarshalID(v)
+	res, err := graphql.UnmarshalID(v)
+	return res, graphql.WrapErrorWithInputPath(ctx, err)
 }
 
 func (ec *executionContext) marshalNID2string(ctx context.Context, sel ast.SelectionSet, v string) graphql.Marshaler {
@@ -3501,7 +4048,8 @@ func (ec *executionContext) marshalNID2string(ctx context.Context, sel ast.Selec
 }
 
 func (ec *executionContext) unmarshalNMetered2githubᚗcomᚋnlepageᚋgoᚑnetmgrᚐMeteredEnum(ctx context.Context, v interface{}) (netmgr.MeteredEnum, error) {
-	return model.UnmarshalMetered(v)
+	res, err := model.UnmarshalMetered(v)
+	return res, graphql.WrapErrorWithInputPath(ctx, err)
 }
 
 func (ec *executionContext) marshalNMetered2githubᚗcomᚋnlepageᚋgoᚑnetmgrᚐMeteredEnum(ctx context.Context, sel ast.SelectionSet, v netmgr.MeteredEnum) graphql.Marshaler {
@@ -3525,11 +4073,13 @@ func (ec *executionContext) marshalNNetworkManager2githubᚗcomᚋnlepageᚋgo
 }
 
 func (ec *executionContext) unmarshalNNetworkManagerInput2githubᚗcomᚋnlepageᚋgoᚑnetmgrᚋgqlᚋmodelᚐNetworkManagerInput(ctx context.Context, v interface{}) (model.NetworkManagerInput, error) {
-	return ec.unmarshalInputNetworkManagerInput(ctx, v)
+	res, err := ec.unmarshalInputNetworkManagerInput(ctx, v)
+	return res, graphql.WrapErrorWithInputPath(ctx, err)
 }
 
 func (ec *executionContext) unmarshalNState2githubᚗcomᚋnlepageᚋgoᚑnetmgrᚐStateEnum(ctx context.Context, v interface{}) (netmgr.StateEnum, error) {
-	return model.UnmarshalState(v)
+	res, err := model.UnmarshalState(v)
+	return res, graphql.WrapErrorWithInputPath(ctx, err)
 }
 
 func (ec *executionContext) marshalNState2githubᚗcomᚋnlepageᚋgoᚑnetmgrᚐStateEnum(ctx context.Context, sel ast.SelectionSet, v netmgr.StateEnum) graphql.Marshaler {
@@ -3544,7 +4094,8 @@ func (ec *executionContext) marshalNState2githubᚗcomᚋnlepageᚋgoᚑnetmgr
 
 func (ec *executionContext) unmarshalNString2githubᚗcomᚋgodbusᚋdbusᚋv5ᚐObjectPath(ctx context.Context, v interface{}) (dbus.ObjectPath, error) {
 	tmp, err := graphql.UnmarshalString(v)
-	return dbus.ObjectPath(tmp), err
+	res := dbus.ObjectPath(tmp)
+	return res, graphql.WrapErrorWithInputPath(ctx, err)
 }
 
 func (ec *executionContext) marshalNString2githubᚗcomᚋgodbusᚋdbusᚋv5ᚐObjectPath(ctx context.Context, sel ast.SelectionSet, v dbus.ObjectPath) graphql.Marshaler {
@@ -3558,7 +4109,8 @@ func (ec *executionContext) marshalNString2githubᚗcomᚋgodbusᚋdbusᚋv5ᚐO
 }
 
 func (ec *executionContext) unmarshalNString2string(ctx context.Context, v interface{}) (string, error) {
-	return graphql.UnmarshalString(v)
+	res, err := graphql.UnmarshalString(v)
+	return res, graphql.WrapErrorWithInputPath(ctx, err)
 }
 
 func (ec *executionContext) marshalNString2string(ctx context.Context, sel ast.SelectionSet, v string) graphql.Marshaler {
@@ -3571,6 +4123,36 @@ func (ec *executionContext) marshalNString2string(ctx context.Context, sel ast.S
 	return res
 }
 
+func (ec *executionContext) unmarshalNString2ᚕstringᚄ(ctx context.Context, v interface{}) ([]string, error) {
+	var vSlice []interface{}
+	if v != nil {
+		if tmp1, ok := v.([]interface{}); ok {
+			vSlice = tmp1
+		} else {
+			vSlice = []interface{}{v}
+		}
+	}
+	var err error
+	res := make([]string, len(vSlice))
+	for i := range vSlice {
+		ctx := graphql.WithFieldInputContext(ctx, graphql.NewFieldInputWithIndex(i))
+		res[i], err = ec.unmarshalNString2string(ctx, vSlice[i])
+		if err != nil {
+			return nil, graphql.WrapErrorWithInputPath(ctx, err)
+		}
+	}
+	return res, nil
+}
+
+func (ec *executionContext) marshalNString2ᚕstringᚄ(ctx context.Context, sel ast.SelectionSet, v []string) graphql.Marshaler {
+	ret := make(graphql.Array, len(v))
+	for i := range v {
+		ret[i] = ec.marshalNString2string(ctx, sel, v[i])
+	}
+
+	return ret
+}
+
 func (ec *executionContext) marshalN__Directive2githubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐDirective(ctx context.Context, sel ast.SelectionSet, v introspection.Directive) graphql.Marshaler {
 	return ec.___Directive(ctx, sel, &v)
 }
@@ -3613,7 +4195,8 @@ func (ec *executionContext) marshalN__Directive2ᚕgithubᚗcomᚋ99designsᚋgq
 }
 
 func (ec *executionContext) unmarshalN__DirectiveLocation2string(ctx context.Context, v interface{}) (string, error) {
-	return graphql.UnmarshalString(v)
+	res, err := graphql.UnmarshalString(v)
+	return res, graphql.WrapErrorWithInputPath(ctx, err)
 }
 
 func (ec *executionContext) marshalN__DirectiveLocation2string(ctx context.Context, sel ast.SelectionSet, v string) graphql.Marshaler {
@@ -3638,9 +4221,10 @@ func (ec *executionContext) unmarshalN__DirectiveLocation2ᚕstringᚄ(ctx conte
 	var err error
 	res := make([]string, len(vSlice))
 	for i := range vSlice {
+		ctx := graphql.WithFieldInputContext(ctx, graphql.NewFieldInputWithIndex(i))
 		res[i], err = ec.unmarshalN__DirectiveLocation2string(ctx, vSlice[i])
 		if err != nil {
-			return nil, err
+			return nil, graphql.WrapErrorWithInputPath(ctx, err)
 		}
 	}
 	return res, nil
@@ -3784,7 +4368,8 @@ func (ec *executionContext) marshalN__Type2ᚖgithubᚗcomᚋ99designsᚋgqlgen
 }
 
 func (ec *executionContext) unmarshalN__TypeKind2string(ctx context.Context, v interface{}) (string, error) {
-	return graphql.UnmarshalString(v)
+	res, err := graphql.UnmarshalString(v)
+	return res, graphql.WrapErrorWithInputPath(ctx, err)
 }
 
 func (ec *executionContext) marshalN__TypeKind2string(ctx context.Context, sel ast.SelectionSet, v string) graphql.Marshaler {
@@ -3798,7 +4383,8 @@ func (ec *executionContext) marshalN__TypeKind2string(ctx context.Context, sel a
 }
 
 func (ec *executionContext) unmarshalOBoolean2bool(ctx context.Context, v interface{}) (bool, error) {
-	return graphql.UnmarshalBoolean(v)
+	res, err := graphql.UnmarshalBoolean(v)
+	return res, graphql.WrapErrorWithInputPath(ctx, err)
 }
 
 func (ec *executionContext) marshalOBoolean2bool(ctx context.Context, sel ast.SelectionSet, v bool) graphql.Marshaler {
@@ -3809,19 +4395,20 @@ func (ec *executionContext) unmarshalOBoolean2ᚖbool(ctx context.Context, v int
 	if v == nil {
 		return nil, nil
 	}
-	res, err := ec.unmarshalOBoolean2bool(ctx, v)
-	return &res, err
+	res, err := graphql.UnmarshalBoolean(v)
+	return &res, graphql.WrapErrorWithInputPath(ctx, err)
 }
 
 func (ec *executionContext) marshalOBoolean2ᚖbool(ctx context.Context, sel ast.SelectionSet, v *bool) graphql.Marshaler {
 	if v == nil {
 		return graphql.Null
 	}
-	return ec.marshalOBoolean2bool(ctx, sel, *v)
+	return graphql.MarshalBoolean(*v)
 }
 
 func (ec *executionContext) unmarshalOCapability2githubᚗcomᚋnlepageᚋgoᚑnetmgrᚐCapability(ctx context.Context, v interface{}) (netmgr.Capability, error) {
-	return model.UnmarshalCapability(v)
+	res, err := model.UnmarshalCapability(v)
+	return res, graphql.WrapErrorWithInputPath(ctx, err)
 }
 
 func (ec *executionContext) marshalOCapability2githubᚗcomᚋnlepageᚋgoᚑnetmgrᚐCapability(ctx context.Context, sel ast.SelectionSet, v netmgr.Capability) graphql.Marshaler {
@@ -3835,27 +4422,96 @@ func (ec *executionContext) marshalOConnectionActive2githubᚗcomᚋnlepageᚋgo
 	return ec._ConnectionActive(ctx, sel, v)
 }
 
+func (ec *executionContext) unmarshalOGlobalDNSConfigInput2ᚖgithubᚗcomᚋnlepageᚋgoᚑnetmgrᚐGlobalDNSConfig(ctx context.Context, v interface{}) (*netmgr.GlobalDNSConfig, error) {
+	if v == nil {
+		return nil, nil
+	}
+	res, err := ec.unmarshalInputGlobalDNSConfigInput(ctx, v)
+	return &res, graphql.WrapErrorWithInputPath(ctx, err)
+}
+
+func (ec *executionContext) unmarshalOGlobalDNSDomainInput2ᚕgithubᚗcomᚋnlepageᚋgoᚑnetmgrᚐGlobalDNSDomainᚄ(ctx context.Context, v interface{}) ([]netmgr.GlobalDNSDomain, error) {
+	if v == nil {
+		return nil, nil
+	}
+	var vSlice []interface{}
+	if v != nil {
+		if tmp1, ok := v.([]interface{}); ok {
+			vSlice = tmp1
+		} else {
+			vSlice = []interface{}{v}
+		}
+	}
+	var err error
+	res := make([]netmgr.GlobalDNSDomain, len(vSlice))
+	for i := range vSlice {
+		ctx := graphql.WithFieldInputContext(ctx, graphql.NewFieldInputWithIndex(i))
+		res[i], err = ec.unmarshalNGlobalDNSDomainInput2githubᚗcomᚋnlepageᚋgoᚑnetmgrᚐGlobalDNSDomain(ctx, vSlice[i])
+		if err != nil {
+			return nil, graphql.WrapErrorWithInputPath(ctx, err)
+		}
+	}
+	return res, nil
+}
+
 func (ec *executionContext) unmarshalOString2string(ctx context.Context, v interface{}) (string, error) {
-	return graphql.UnmarshalString(v)
+	res, err := graphql.UnmarshalString(v)
+	return res, graphql.WrapErrorWithInputPath(ctx, err)
 }
 
 func (ec *executionContext) marshalOString2string(ctx context.Context, sel ast.SelectionSet, v string) graphql.Marshaler {
 	return graphql.MarshalString(v)
 }
 
+func (ec *executionContext) unmarshalOString2ᚕstringᚄ(ctx context.Context, v interface{}) ([]string, error) {
+	if v == nil {
+		return nil, nil
+	}
+	var vSlice []interface{}
+	if v != nil {
+		if tmp1, ok := v.([]interface{}); ok {
+			vSlice = tmp1
+		} else {
+			vSlice = []interface{}{v}
+		}
+	}
+	var err error
+	res := make([]string, len(vSlice))
+	for i := range vSlice {
+		ctx := graphql.WithFieldInputContext(ctx, graphql.NewFieldInputWithIndex(i))
+		res[i], err = ec.unmarshalNString2string(ctx, vSlice[i])
+		if err != nil {
+			return nil, graphql.WrapErrorWithInputPath(ctx, err)
+		}
+	}
+	return res, nil
+}
+
+func (ec *executionContext) marshalOString2ᚕstringᚄ(ctx context.Context, sel ast.SelectionSet, v []string) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	ret := make(graphql.Array, len(v))
+	for i := range v {
+		ret[i] = ec.marshalNString2string(ctx, sel, v[i])
+	}
+
+	return ret
+}
+
 func (ec *executionContext) unmarshalOString2ᚖstring(ctx context.Context, v interface{}) (*string, error) {
 	if v == nil {
 		return nil, nil
 	}
-	res, err := ec.unmarshalOString2string(ctx, v)
-	return &res, err
+	res, err := graphql.UnmarshalString(v)
+	return &res, graphql.WrapErrorWithInputPath(ctx, err)
 }
 
 func (ec *executionContext) marshalOString2ᚖstring(ctx context.Context, sel ast.SelectionSet, v *string) graphql.Marshaler {
 	if v == nil {
 		return graphql.Null
 	}
-	return ec.marshalOString2string(ctx, sel, *v)
+	return graphql.MarshalString(*v)
 }
 
 func (ec *executionContext) marshalO__EnumValue2ᚕgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐEnumValueᚄ(ctx context.Context, sel ast.SelectionSet, v []introspection.EnumValue) graphql.Marshaler {
@@ -3978,10 +4634,6 @@ func (ec *executionContext) marshalO__InputValue2ᚕgithubᚗcomᚋ99designsᚋg
 	return ret
 }
 
-func (ec *executionContext) marshalO__Schema2githubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐSchema(ctx context.Context, sel ast.SelectionSet, v introspection.Schema) graphql.Marshaler {
-	return ec.___Schema(ctx, sel, &v)
-}
-
 func (ec *executionContext) marshalO__Schema2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐSchema(ctx context.Context, sel ast.SelectionSet, v *introspection.Schema) graphql.Marshaler {
 	if v == nil {
 		return graphql.Null
@@ -3989,10 +4641,6 @@ func (ec *executionContext) marshalO__Schema2ᚖgithubᚗcomᚋ99designsᚋgqlge
 	return ec.___Schema(ctx, sel, v)
 }
 
-func (ec *executionContext) marshalO__Type2githubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐType(ctx context.Context, sel ast.SelectionSet, v introspection.Type) graphql.Marshaler {
-	return ec.___Type(ctx, sel, &v)
-}
-
 func (ec *executionContext) marshalO__Type2ᚕgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐTypeᚄ(ctx context.Context, sel ast.SelectionSet, v []introspection.Type) graphql.Marshaler {
 	if v == nil {
 		return graphql.Null
@@ -1,10 +1,6 @@
 package model
 
 import (
-	"fmt"
-	"io"
-	"strconv"
-
 	"github.com/99designs/gqlgen/graphql"
 
 	"github.com/nlepage/go-netmgr"
@@ -12,28 +8,16 @@ import (
 
 type Capability = netmgr.Capability
 
+var capabilityEnum = enum{"Capability", "NM_CAPABILITY_", []string{"Team", "OVS"}}
+
 func MarshalCapability(capability Capability) graphql.Marshaler {
-	return graphql.WriterFunc(func(w io.Writer) {
-		var s string
-		switch capability {
-		case netmgr.CapabilityTeam:
-			s = "Team"
-		case netmgr.CapabilityOVS:
-			s = "OVS"
-		default:
-			panic("Unknown netmgr.Capability value: " + strconv.Itoa(int(capability)))
-		}
-		w.Write([]byte(strconv.Quote(s)))
-	})
+	return capabilityEnum.marshal(uint(capability), capability.String())
 }
 
 func UnmarshalCapability(v interface{}) (Capability, error) {
-	s := v.(string)
-	switch s {
-	case "Team":
-		return netmgr.CapabilityTeam, nil
-	case "OVS":
-		return netmgr.CapabilityOVS, nil
+	name, err := capabilityEnum.unmarshal(v)
+	if err != nil {
+		return 0, err
 	}
-	return 0, fmt.Errorf("Unknown Capability enum value: %#v", s)
+	return netmgr.ParseCapability(name)
 }
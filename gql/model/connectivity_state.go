@@ -1,10 +1,6 @@
 package model
 
 import (
-	"fmt"
-	"io"
-	"strconv"
-
 	"github.com/99designs/gqlgen/graphql"
 
 	"github.com/nlepage/go-netmgr"
@@ -12,40 +8,16 @@ import (
 
 type ConnectivityState = netmgr.ConnectivityState
 
+var connectivityStateEnum = enum{"ConnectivityState", "NM_CONNECTIVITY_", []string{"Unknown", "None", "Portal", "Limited", "Full"}}
+
 func MarshalConnectivityState(connectivity ConnectivityState) graphql.Marshaler {
-	return graphql.WriterFunc(func(w io.Writer) {
-		var s string
-		switch connectivity {
-		case netmgr.ConnectivityUnknown:
-			s = "Unknown"
-		case netmgr.ConnectivityNone:
-			s = "None"
-		case netmgr.ConnectivityPortal:
-			s = "Portal"
-		case netmgr.ConnectivityLimited:
-			s = "Limited"
-		case netmgr.ConnectivityFull:
-			s = "Full"
-		default:
-			panic("Unknown netmgr.ConnectivityState value: " + strconv.Itoa(int(connectivity)))
-		}
-		w.Write([]byte(strconv.Quote(s)))
-	})
+	return connectivityStateEnum.marshal(uint(connectivity), connectivity.String())
 }
 
 func UnmarshalConnectivityState(v interface{}) (ConnectivityState, error) {
-	s := v.(string)
-	switch s {
-	case "Unknown":
-		return netmgr.ConnectivityUnknown, nil
-	case "None":
-		return netmgr.ConnectivityNone, nil
-	case "Portal":
-		return netmgr.ConnectivityPortal, nil
-	case "Limited":
-		return netmgr.ConnectivityLimited, nil
-	case "Full":
-		return netmgr.ConnectivityFull, nil
+	name, err := connectivityStateEnum.unmarshal(v)
+	if err != nil {
+		return 0, err
 	}
-	return 0, fmt.Errorf("Unknown ConnectivityState enum value: %#v", s)
+	return netmgr.ParseConnectivityState(name)
 }
@@ -0,0 +1,63 @@
+package model
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/99designs/gqlgen/graphql"
+)
+
+// enum maps the values of a GraphQL enum to the names of a netmgr enum type,
+// which are prefix followed by the GraphQL value in upper snake case, such as NM_STATE_CONNECTED_GLOBAL for ConnectedGlobal.
+type enum struct {
+	typeName string
+	prefix   string
+	values   []string
+}
+
+// marshal returns the GraphQL value of the netmgr value v, whose String is name.
+//
+// A value unknown to the schema, possibly added by a newer NetworkManager, is marshaled as a decimal number,
+// which is accepted back by unmarshal.
+func (e enum) marshal(v uint, name string) graphql.Marshaler {
+	for _, value := range e.values {
+		if e.netmgrName(value) == name {
+			return graphql.MarshalString(value)
+		}
+	}
+	return graphql.MarshalString(strconv.FormatUint(uint64(v), 10))
+}
+
+// unmarshal returns the netmgr name of the GraphQL value v, to be given to the Parse function of the netmgr type.
+//
+// A decimal number is returned as is, as the Parse functions of netmgr accept it.
+func (e enum) unmarshal(v interface{}) (string, error) {
+	s, ok := v.(string)
+	if !ok {
+		return "", fmt.Errorf("%s enum value must be a string, got %T", e.typeName, v)
+	}
+	for _, value := range e.values {
+		if value == s {
+			return e.netmgrName(value), nil
+		}
+	}
+	if _, err := strconv.ParseUint(s, 10, 0); err != nil {
+		return "", fmt.Errorf("Unknown %s enum value: %#v", e.typeName, s)
+	}
+	return s, nil
+}
+
+// netmgrName returns the netmgr name of the GraphQL value, GuessYes is GUESS_YES, OVS is OVS.
+func (e enum) netmgrName(value string) string {
+	var b strings.Builder
+	b.WriteString(e.prefix)
+	for i, r := range value {
+		if i > 0 && unicode.IsUpper(r) && unicode.IsLower(rune(value[i-1])) {
+			b.WriteByte('_')
+		}
+		b.WriteRune(unicode.ToUpper(r))
+	}
+	return b.String()
+}
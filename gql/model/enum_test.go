@@ -0,0 +1,50 @@
+package model
+
+import (
+	"bytes"
+	"strconv"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+)
+
+func marshaled(m graphql.Marshaler) string {
+	var b bytes.Buffer
+	m.MarshalGQL(&b)
+	s, _ := strconv.Unquote(b.String())
+	return s
+}
+
+func TestStateRoundTrip(t *testing.T) {
+	// each value of the schema has a netmgr name, and an unknown value survives as a number
+	for _, value := range append(stateEnum.values, "42") {
+		state, err := UnmarshalState(value)
+		if err != nil {
+			t.Errorf("UnmarshalState(%q) failed: %v", value, err)
+			continue
+		}
+		if s := marshaled(MarshalState(state)); s != value {
+			t.Errorf("MarshalState(UnmarshalState(%q)) = %q", value, s)
+		}
+	}
+	if _, err := UnmarshalState("Bogus"); err == nil {
+		t.Error("UnmarshalState(\"Bogus\") should fail")
+	}
+}
+
+func TestEnumsNetmgrNames(t *testing.T) {
+	for _, e := range []struct {
+		enum
+		parse func(interface{}) error
+	}{
+		{capabilityEnum, func(v interface{}) error { _, err := UnmarshalCapability(v); return err }},
+		{meteredEnum, func(v interface{}) error { _, err := UnmarshalMetered(v); return err }},
+		{connectivityStateEnum, func(v interface{}) error { _, err := UnmarshalConnectivityState(v); return err }},
+	} {
+		for _, value := range e.values {
+			if err := e.parse(value); err != nil {
+				t.Errorf("%s value %q has no netmgr name: %v", e.typeName, value, err)
+			}
+		}
+	}
+}
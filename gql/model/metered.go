@@ -1,10 +1,6 @@
 package model
 
 import (
-	"fmt"
-	"io"
-	"strconv"
-
 	"github.com/99designs/gqlgen/graphql"
 
 	"github.com/nlepage/go-netmgr"
@@ -12,40 +8,16 @@ import (
 
 type Metered = netmgr.MeteredEnum
 
+var meteredEnum = enum{"Metered", "NM_METERED_", []string{"Unknown", "Yes", "No", "GuessYes", "GuessNo"}}
+
 func MarshalMetered(metered Metered) graphql.Marshaler {
-	return graphql.WriterFunc(func(w io.Writer) {
-		var s string
-		switch metered {
-		case netmgr.MeteredUnknown:
-			s = "Unknown"
-		case netmgr.MeteredYes:
-			s = "Yes"
-		case netmgr.MeteredNo:
-			s = "No"
-		case netmgr.MeteredGuessYes:
-			s = "GuessYes"
-		case netmgr.MeteredGuessNo:
-			s = "GuessNo"
-		default:
-			panic("Unknown netmgr.MeteredEnum value: " + strconv.Itoa(int(metered)))
-		}
-		w.Write([]byte(strconv.Quote(s)))
-	})
+	return meteredEnum.marshal(uint(metered), metered.String())
 }
 
 func UnmarshalMetered(v interface{}) (Metered, error) {
-	s := v.(string)
-	switch s {
-	case "Unknown":
-		return netmgr.MeteredUnknown, nil
-	case "Yes":
-		return netmgr.MeteredYes, nil
-	case "No":
-		return netmgr.MeteredNo, nil
-	case "GuessYes":
-		return netmgr.MeteredGuessYes, nil
-	case "GuessNo":
-		return netmgr.MeteredGuessNo, nil
+	name, err := meteredEnum.unmarshal(v)
+	if err != nil {
+		return 0, err
 	}
-	return 0, fmt.Errorf("Unknown Metered enum value: %#v", s)
+	return netmgr.ParseMeteredEnum(name)
 }
@@ -2,8 +2,13 @@
 
 package model
 
+import (
+	"github.com/nlepage/go-netmgr"
+)
+
 type NetworkManagerInput struct {
-	WirelessEnabled          *bool `json:"wirelessEnabled"`
-	WwanEnabled              *bool `json:"wwanEnabled"`
-	ConnectivityCheckEnabled *bool `json:"connectivityCheckEnabled"`
+	WirelessEnabled          *bool                   `json:"wirelessEnabled"`
+	WwanEnabled              *bool                   `json:"wwanEnabled"`
+	ConnectivityCheckEnabled *bool                   `json:"connectivityCheckEnabled"`
+	GlobalDNSConfiguration   *netmgr.GlobalDNSConfig `json:"globalDNSConfiguration"`
 }
@@ -38,6 +38,11 @@ func (r *mutationResolver) NetworkManager(ctx context.Context, input model.Netwo
 			return nil, err
 		}
 	}
+	if input.GlobalDNSConfiguration != nil {
+		if err := netmgr.SetGlobalDNSConfiguration(*input.GlobalDNSConfiguration); err != nil {
+			return nil, err
+		}
+	}
 	nm, err := netmgr.System()
 	if err != nil {
 		return nil, err
@@ -0,0 +1,238 @@
+package netmgr
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrNoAccessPointCapability is returned when a wireless device does not support Access Point mode.
+var ErrNoAccessPointCapability = errors.New("device does not support access point mode")
+
+// hotspotCleanupTimeout bounds the Stop of a hotspot which failed to start, as the context of StartHotspot may be done already.
+const hotspotCleanupTimeout = 30 * time.Second
+
+// WifiBand is the frequency band of a Wi-Fi network, as in the band property of the 802-11-wireless setting.
+//
+// See https://developer.gnome.org/NetworkManager/stable/settings-802-11-wireless.html for more information.
+type WifiBand string
+
+const (
+	// WifiBandAuto lets NetworkManager choose the band.
+	WifiBandAuto WifiBand = ""
+
+	// WifiBandA is the 5GHz band.
+	WifiBandA WifiBand = "a"
+
+	// WifiBandBG is the 2.4GHz band.
+	WifiBandBG WifiBand = "bg"
+)
+
+type (
+	// HotspotOptions are the options of StartHotspot.
+	HotspotOptions struct {
+		// Device is the wireless device to use, nil to use the first wireless device supporting Access Point mode.
+		Device interface{}
+
+		// Band is the frequency band of the hotspot.
+		Band WifiBand
+
+		// Channel is the channel of the hotspot, 0 to let NetworkManager choose, a Band must be given along with it.
+		Channel uint32
+	}
+
+	// Hotspot is a Wi-Fi hotspot started by StartHotspot.
+	Hotspot struct {
+		nm                 *networkManager
+		device             WirelessDevice
+		settingsConnection SettingsConnection
+		connectionActive   ConnectionActive
+		previous           SettingsConnection
+
+		// l guards deleted and restored, the steps of Stop which already succeeded
+		l                 sync.Mutex
+		deleted, restored bool
+	}
+)
+
+// HotspotConnectionSettings returns the settings of a profile exposing a hotspot for ssid.
+//
+// The hotspot uses WPA2 with passphrase, or is open if passphrase is empty.
+func HotspotConnectionSettings(ssid, passphrase string, options HotspotOptions) (ConnectionSettings, error) {
+	if options.Channel != 0 && options.Band == WifiBandAuto {
+		return nil, errors.New("a band is required along with a channel")
+	}
+	if passphrase != "" && !validWPAPSK(passphrase) {
+		return nil, errors.New("passphrase must be 8 to 63 printable ASCII characters, or 64 hexadecimal digits")
+	}
+
+	wireless := map[string]interface{}{
+		"ssid": []byte(ssid),
+		"mode": "ap",
+	}
+	if options.Band != WifiBandAuto {
+		wireless["band"] = string(options.Band)
+	}
+	if options.Channel != 0 {
+		wireless["channel"] = options.Channel
+	}
+
+	settings := ConnectionSettings{
+		"connection": {
+			"id":          ssid,
+			"type":        "802-11-wireless",
+			"autoconnect": false,
+		},
+		"802-11-wireless": wireless,
+		"ipv4": {
+			"method": "shared",
+		},
+	}
+
+	if passphrase != "" {
+		settings["802-11-wireless-security"] = map[string]interface{}{
+			"key-mgmt": string(WifiKeyMgmtWPAPSK),
+			"psk":      passphrase,
+			"proto":    []string{"rsn"},
+			"pairwise": []string{"ccmp"},
+			"group":    []string{"ccmp"},
+		}
+	}
+
+	return settings, nil
+}
+
+// validWPAPSK tells if psk is a WPA passphrase of 8 to 63 printable ASCII characters, or a raw key of 64 hexadecimal digits.
+func validWPAPSK(psk string) bool {
+	if len(psk) == 64 {
+		_, err := hex.DecodeString(psk)
+		return err == nil
+	}
+	if len(psk) < 8 || len(psk) > 63 {
+		return false
+	}
+	for i := 0; i < len(psk); i++ {
+		if psk[i] < ' ' || psk[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+func (nm *networkManager) StartHotspot(ctx context.Context, ssid, passphrase string, options HotspotOptions) (*Hotspot, error) {
+	settings, err := HotspotConnectionSettings(ssid, passphrase, options)
+	if err != nil {
+		return nil, err
+	}
+
+	device, err := nm.hotspotDevice(options.Device)
+	if err != nil {
+		return nil, err
+	}
+
+	h := &Hotspot{nm: nm, device: device}
+
+	previousActive, err := device.ActiveConnection()
+	if err != nil {
+		return nil, err
+	}
+	if previousActive != nil {
+		if h.previous, err = previousActive.Connection(); err != nil {
+			return nil, err
+		}
+	}
+
+	// the profile is kept in memory only, it is deleted by Stop
+	addOptions := map[string]interface{}{"persist": string(PersistMemory)}
+
+	h.settingsConnection, h.connectionActive, err = nm.AddAndActivateConnection2(settings, device, nil, addOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.connectionActive.WaitActivated(ctx); err != nil {
+		stopCtx, cancel := context.WithTimeout(context.Background(), hotspotCleanupTimeout)
+		defer cancel()
+		if stopErr := h.Stop(stopCtx); stopErr != nil {
+			return nil, fmt.Errorf("%w (stop failed: %v)", err, stopErr)
+		}
+		return nil, err
+	}
+
+	return h, nil
+}
+
+// StartHotspot starts a Wi-Fi hotspot for ssid, and waits until it is activated.
+//
+// IPv4 is shared with the clients of the hotspot.
+// The connection previously active on the device is reactivated by Hotspot.Stop.
+func StartHotspot(ctx context.Context, ssid, passphrase string, options HotspotOptions) (*Hotspot, error) {
+	nm, err := System()
+	if err != nil {
+		return nil, err
+	}
+	return nm.StartHotspot(ctx, ssid, passphrase, options)
+}
+
+func (nm *networkManager) hotspotDevice(device interface{}) (WirelessDevice, error) {
+	devices, err := nm.wirelessDevices(device)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range devices {
+		capabilities, err := d.WirelessCapabilities()
+		if err != nil {
+			return nil, err
+		}
+		if capabilities&WifiDeviceCapAp != 0 {
+			return d, nil
+		}
+	}
+	if device != nil {
+		return nil, ErrNoAccessPointCapability
+	}
+	return nil, fmt.Errorf("%w: no wireless device supports access point mode", ErrNoWirelessDevice)
+}
+
+// Device is the wireless device exposing the hotspot.
+func (h *Hotspot) Device() WirelessDevice {
+	return h.device
+}
+
+// SettingsConnection is the profile of the hotspot.
+func (h *Hotspot) SettingsConnection() SettingsConnection {
+	return h.settingsConnection
+}
+
+// ConnectionActive is the active connection of the hotspot.
+func (h *Hotspot) ConnectionActive() ConnectionActive {
+	return h.connectionActive
+}
+
+// Stop tears the hotspot down by deleting its profile, then reactivates the connection previously active on the device, if any.
+//
+// Stop may be called several times, the steps which already succeeded are not done again.
+func (h *Hotspot) Stop(ctx context.Context) error {
+	h.l.Lock()
+	defer h.l.Unlock()
+
+	if !h.deleted {
+		if err := h.settingsConnection.Delete(); err != nil {
+			return err
+		}
+		h.deleted = true
+	}
+
+	if h.previous == nil || h.restored {
+		return nil
+	}
+
+	if _, err := h.nm.ActivateConnectionAndWait(ctx, h.previous, h.device, nil); err != nil {
+		return err
+	}
+	h.restored = true
+	return nil
+}
@@ -0,0 +1,67 @@
+package netmgr
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+)
+
+func TestHotspotConnectionSettingsPassphrase(t *testing.T) {
+	for _, test := range []struct {
+		passphrase string
+		valid      bool
+	}{
+		{"", true},
+		{"password", true},
+		{strings.Repeat("a", 63), true},
+		{strings.Repeat("0123456789abcdef", 4), true},
+		{"short", false},
+		{strings.Repeat("g", 64), false},
+		{strings.Repeat("a", 65), false},
+		{"pass\nword", false},
+		{"mot de passé", false},
+	} {
+		_, err := HotspotConnectionSettings("hotspot", test.passphrase, HotspotOptions{})
+		if valid := err == nil; valid != test.valid {
+			t.Errorf("HotspotConnectionSettings with passphrase %q returned %v", test.passphrase, err)
+		}
+	}
+}
+
+// fakeDeletable implements the Delete method of a settings connection, which fails once the connection is deleted.
+type fakeDeletable struct {
+	deletes int
+}
+
+func (f *fakeDeletable) Delete() *dbus.Error {
+	if f.deletes++; f.deletes > 1 {
+		return errUnknownObject
+	}
+	return nil
+}
+
+func TestHotspotStopTwice(t *testing.T) {
+	conn := privateBus(t)
+
+	const path = dbus.ObjectPath(NetworkManagerPath + "/Settings/1")
+
+	if reply, err := conn.RequestName(BusName, dbus.NameFlagDoNotQueue); err != nil || reply != dbus.RequestNameReplyPrimaryOwner {
+		t.Fatalf("could not own %s: %v", BusName, err)
+	}
+	fake := &fakeDeletable{}
+	if err := conn.Export(fake, path, SettingsConnectionIface); err != nil {
+		t.Fatal(err)
+	}
+
+	h := &Hotspot{settingsConnection: NewSettingsConnection(conn, path)}
+	for i := 0; i < 2; i++ {
+		if err := h.Stop(context.Background()); err != nil {
+			t.Fatalf("Stop call %d failed: %v", i+1, err)
+		}
+	}
+	if fake.deletes != 1 {
+		t.Errorf("expected 1 delete, got %d", fake.deletes)
+	}
+}
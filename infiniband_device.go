@@ -0,0 +1,42 @@
+package netmgr
+
+// InfinibandDeviceIface is the Infiniband Device interface.
+const InfinibandDeviceIface = "org.freedesktop.NetworkManager.Device.Infiniband"
+
+type (
+	// InfinibandDevice represents an InfiniBand device.
+	//
+	// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Infiniband.html for more information.
+	InfinibandDevice interface {
+		Device
+		isInfinibandDevice()
+
+		// Properties
+
+		// HwAddress is the hardware address of the device.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Infiniband.html#gdbus-property-org-freedesktop-NetworkManager-Device-Infiniband.HwAddress for more information.
+		HwAddress() (string, error)
+
+		// Carrier tells if the device has a carrier.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Infiniband.html#gdbus-property-org-freedesktop-NetworkManager-Device-Infiniband.Carrier for more information.
+		Carrier() (bool, error)
+	}
+
+	infinibandDevice struct {
+		device
+	}
+)
+
+var _ InfinibandDevice = (*infinibandDevice)(nil)
+
+func (*infinibandDevice) isInfinibandDevice() {}
+
+func (d *infinibandDevice) HwAddress() (string, error) {
+	return d.GetSProperty(InfinibandDeviceIface + ".HwAddress")
+}
+
+func (d *infinibandDevice) Carrier() (bool, error) {
+	return d.GetBProperty(InfinibandDeviceIface + ".Carrier")
+}
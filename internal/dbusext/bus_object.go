@@ -1,6 +1,7 @@
 package dbusext
 
 import (
+	"context"
 	"errors"
 	"reflect"
 
@@ -29,13 +30,26 @@ func (o *BusObject) CallAndStore(method string, in Args, out Args) error {
 }
 
 func (o *BusObject) SignalDispatcher() (*SignalDispatcher, error) {
-	v := o.Conn.Context().Value(SignalDispatcherKey)
+	return ConnSignalDispatcher(o.Conn)
+}
+
+func ConnSignalDispatcher(conn *dbus.Conn) (*SignalDispatcher, error) {
+	v := conn.Context().Value(SignalDispatcherKey)
 	if v == nil {
 		return nil, errors.New("no SignalDispatcher is attached to the DBus connection, use netmgrutil.WithSignalDispatcher")
 	}
 	return v.(*SignalDispatcher), nil
 }
 
+// RemoveSignal stops sending the signals matching path, iface and member of conn to out.
+func RemoveSignal(conn *dbus.Conn, path dbus.ObjectPath, iface string, member string, out interface{}) error {
+	sd, err := ConnSignalDispatcher(conn)
+	if err != nil {
+		return err
+	}
+	return sd.RemoveSignal(conn, path, iface, member, out)
+}
+
 func (o *BusObject) Signal(iface string, member string, elemType reflect.Type, out interface{}, convert interface{}) error {
 	sd, err := o.SignalDispatcher()
 	if err != nil {
@@ -48,6 +62,51 @@ func (o *BusObject) USignal(iface string, member string, out interface{}, conver
 	return o.Signal(iface, member, reflect.TypeOf(uint32(0)), out, convert)
 }
 
+func (o *BusObject) BodySignal(iface string, member string, out interface{}, convert interface{}) error {
+	sd, err := o.SignalDispatcher()
+	if err != nil {
+		return err
+	}
+	return sd.BodySignal(o.Conn, o.Path(), iface, member, out, convert)
+}
+
+func (o *BusObject) BodySignalArg0(iface string, member string, arg0 string, out interface{}, convert interface{}) error {
+	sd, err := o.SignalDispatcher()
+	if err != nil {
+		return err
+	}
+	return sd.BodySignalArg0(o.Conn, o.Path(), iface, member, arg0, out, convert)
+}
+
+func (o *BusObject) PropertyChanged(iface string, property string, elemType reflect.Type, out interface{}, convert interface{}) error {
+	sd, err := o.SignalDispatcher()
+	if err != nil {
+		return err
+	}
+	return sd.PropertyChanged(o.Conn, o.Path(), iface, property, elemType, out, convert)
+}
+
+func (o *BusObject) RemoveSignal(iface string, member string, out interface{}) error {
+	sd, err := o.SignalDispatcher()
+	if err != nil {
+		return err
+	}
+	return sd.RemoveSignal(o.Conn, o.Path(), iface, member, out)
+}
+
+func (o *BusObject) RemovePropertyChanged(out interface{}) error {
+	return o.RemoveSignal(PropertiesIface, "PropertiesChanged", out)
+}
+
+// CloseOnDone removes out from the signal matching iface and member and closes it once ctx is done.
+func (o *BusObject) CloseOnDone(ctx context.Context, iface string, member string, out interface{}) {
+	go func() {
+		<-ctx.Done()
+		o.RemoveSignal(iface, member, out)
+		reflect.ValueOf(out).Close()
+	}()
+}
+
 func (o *BusObject) GetSProperty(name string) (string, error) {
 	p, err := o.GetProperty(name)
 	if err != nil {
@@ -102,6 +161,14 @@ func (o *BusObject) GetAOProperty(name string) ([]dbus.ObjectPath, error) {
 	return p.Value().([]dbus.ObjectPath), nil
 }
 
+func (o *BusObject) GetIProperty(name string) (int32, error) {
+	p, err := o.GetProperty(name)
+	if err != nil {
+		return 0, err
+	}
+	return p.Value().(int32), nil
+}
+
 func (o *BusObject) GetUProperty(name string) (uint32, error) {
 	p, err := o.GetProperty(name)
 	if err != nil {
@@ -110,6 +177,46 @@ func (o *BusObject) GetUProperty(name string) (uint32, error) {
 	return p.Value().(uint32), nil
 }
 
+func (o *BusObject) GetQProperty(name string) (uint16, error) {
+	p, err := o.GetProperty(name)
+	if err != nil {
+		return 0, err
+	}
+	return p.Value().(uint16), nil
+}
+
+func (o *BusObject) GetYProperty(name string) (byte, error) {
+	p, err := o.GetProperty(name)
+	if err != nil {
+		return 0, err
+	}
+	return p.Value().(byte), nil
+}
+
+func (o *BusObject) GetAYProperty(name string) ([]byte, error) {
+	p, err := o.GetProperty(name)
+	if err != nil {
+		return nil, err
+	}
+	return p.Value().([]byte), nil
+}
+
+func (o *BusObject) GetXProperty(name string) (int64, error) {
+	p, err := o.GetProperty(name)
+	if err != nil {
+		return 0, err
+	}
+	return p.Value().(int64), nil
+}
+
+func (o *BusObject) GetTProperty(name string) (uint64, error) {
+	p, err := o.GetProperty(name)
+	if err != nil {
+		return 0, err
+	}
+	return p.Value().(uint64), nil
+}
+
 func (o *BusObject) GetAUProperty(name string) ([]uint32, error) {
 	p, err := o.GetProperty(name)
 	if err != nil {
@@ -118,6 +225,14 @@ func (o *BusObject) GetAUProperty(name string) ([]uint32, error) {
 	return p.Value().([]uint32), nil
 }
 
+func (o *BusObject) GetASProperty(name string) ([]string, error) {
+	p, err := o.GetProperty(name)
+	if err != nil {
+		return nil, err
+	}
+	return p.Value().([]string), nil
+}
+
 func ASV2ASI(asv map[string]dbus.Variant) map[string]interface{} {
 	asi := make(map[string]interface{}, len(asv))
 	for s, v := range asv {
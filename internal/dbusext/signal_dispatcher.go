@@ -4,38 +4,145 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 	"sync"
 
 	"github.com/godbus/dbus/v5"
 )
 
+// PropertiesIface is the standard D-Bus properties interface.
+const PropertiesIface = "org.freedesktop.DBus.Properties"
+
 type (
+	// SignalKey identifies a match rule, arg0 is empty unless the signals are filtered on their first argument.
 	SignalKey struct {
 		path dbus.ObjectPath
 		name string
+		arg0 string
+	}
+
+	// outKey identifies a subscription of a channel, property is empty unless it is a property subscription.
+	outKey struct {
+		out      interface{}
+		property string
 	}
 
 	outChan struct {
 		value   reflect.Value
+		inType  reflect.Type
 		convert func(interface{}) reflect.Value
+		extract func(body []interface{}) []interface{}
+		removed chan struct{}
+		once    *sync.Once
+	}
+
+	// subscription identifies a subscription of a channel to a signal.
+	subscription struct {
+		signal SignalKey
+		outKey
 	}
 
 	SignalDispatcher struct {
 		l    sync.RWMutex
 		in   <-chan *dbus.Signal
-		outs map[SignalKey]map[interface{}]outChan
+		outs map[SignalKey]map[outKey]outChan
+
+		// subscriptions holds the same outChans as outs, so that RemoveSignal may unblock a pending send without waiting for l
+		subscriptions sync.Map
 	}
 )
 
 var SignalDispatcherKey = struct{}{}
 
+// BodyType is the element type of channels receiving whole signal bodies.
+var BodyType = reflect.TypeOf([]interface{}(nil))
+
 func NewSignalDispatcher() *SignalDispatcher {
 	return &SignalDispatcher{
-		outs: make(map[SignalKey]map[interface{}]outChan),
+		outs: make(map[SignalKey]map[outKey]outChan),
 	}
 }
 
+// Signal sends each value of the body of the signals matching path, iface and member to out.
 func (sm *SignalDispatcher) Signal(conn *dbus.Conn, path dbus.ObjectPath, iface, member string, elemType reflect.Type, out interface{}, convert interface{}) error {
+	return sm.subscribe(conn, SignalKey{path, iface + "." + member, ""}, "", elemType, out, convert, eachValue)
+}
+
+// BodySignal sends the whole body of the signals matching path, iface and member to out.
+func (sm *SignalDispatcher) BodySignal(conn *dbus.Conn, path dbus.ObjectPath, iface, member string, out interface{}, convert interface{}) error {
+	return sm.subscribe(conn, SignalKey{path, iface + "." + member, ""}, "", BodyType, out, convert, wholeBody)
+}
+
+// BodySignalArg0 sends the whole body of the signals matching path, iface and member, and whose first argument is arg0, to out.
+//
+// The signals are filtered by the bus, out does not receive the other signals.
+func (sm *SignalDispatcher) BodySignalArg0(conn *dbus.Conn, path dbus.ObjectPath, iface, member, arg0 string, out interface{}, convert interface{}) error {
+	return sm.subscribe(conn, SignalKey{path, iface + "." + member, arg0}, "", BodyType, out, convert, wholeBody)
+}
+
+// PropertyChanged sends the new values of property of iface on path to out.
+//
+// The same out may receive several properties of path.
+func (sm *SignalDispatcher) PropertyChanged(conn *dbus.Conn, path dbus.ObjectPath, iface, property string, elemType reflect.Type, out interface{}, convert interface{}) error {
+	return sm.subscribe(conn, SignalKey{path, PropertiesIface + ".PropertiesChanged", ""}, iface+"."+property, elemType, out, convert, changedValue(iface, property))
+}
+
+// RemoveSignal stops sending the signals matching path, iface and member to out, including all the properties subscribed by out,
+// whatever their first argument.
+func (sm *SignalDispatcher) RemoveSignal(conn *dbus.Conn, path dbus.ObjectPath, iface, member string, out interface{}) error {
+	var name = iface + "." + member
+
+	// unblocks a pending send to out before waiting for the lock, which may be held by a subscribe waiting for the bus
+	var removed []subscription
+	sm.subscriptions.Range(func(key, value interface{}) bool {
+		if sub := key.(subscription); sub.signal.path == path && sub.signal.name == name && sub.out == out {
+			oc := value.(outChan)
+			oc.once.Do(func() { close(oc.removed) })
+			removed = append(removed, sub)
+		}
+		return true
+	})
+	if len(removed) == 0 {
+		return nil
+	}
+
+	sm.l.Lock()
+	defer sm.l.Unlock()
+
+	var err error
+	for _, sub := range removed {
+		k := sub.signal
+		sm.subscriptions.Delete(sub)
+		if _, ok := sm.outs[k]; !ok {
+			// already removed with another property of out
+			continue
+		}
+		delete(sm.outs[k], sub.outKey)
+		if len(sm.outs[k]) != 0 {
+			continue
+		}
+		delete(sm.outs, k)
+		if rmErr := conn.RemoveMatchSignal(matchOptions(k, iface, member)...); rmErr != nil && err == nil {
+			err = rmErr
+		}
+	}
+	return err
+}
+
+// matchOptions returns the options of the match rule of k.
+func matchOptions(k SignalKey, iface, member string) []dbus.MatchOption {
+	options := []dbus.MatchOption{
+		dbus.WithMatchObjectPath(k.path),
+		dbus.WithMatchInterface(iface),
+		dbus.WithMatchMember(member),
+	}
+	if k.arg0 != "" {
+		options = append(options, dbus.WithMatchOption("arg0", k.arg0))
+	}
+	return options
+}
+
+func (sm *SignalDispatcher) subscribe(conn *dbus.Conn, k SignalKey, property string, elemType reflect.Type, out interface{}, convert interface{}, extract func([]interface{}) []interface{}) error {
 	sm.l.Lock()
 	defer sm.l.Unlock()
 
@@ -46,25 +153,22 @@ func (sm *SignalDispatcher) Signal(conn *dbus.Conn, path dbus.ObjectPath, iface,
 		go sm.pipe(conn.Context().Done())
 	}
 
-	var k = SignalKey{path, iface + "." + member}
-
 	if _, ok := sm.outs[k]; !ok {
-		if err := conn.AddMatchSignal(
-			dbus.WithMatchObjectPath(path),
-			dbus.WithMatchInterface(iface),
-			dbus.WithMatchMember(member),
-		); err != nil {
+		dot := strings.LastIndex(k.name, ".")
+		if err := conn.AddMatchSignal(matchOptions(k, k.name[:dot], k.name[dot+1:])...); err != nil {
 			return err
 		}
-		sm.outs[k] = make(map[interface{}]outChan)
+		sm.outs[k] = make(map[outKey]outChan)
 	}
-	if _, ok := sm.outs[k][out]; !ok {
+	if _, ok := sm.outs[k][outKey{out, property}]; !ok {
 		oc, err := newOutChan(out, elemType, convert)
 		if err != nil {
 			return err
 		}
+		oc.extract = extract
 
-		sm.outs[k][out] = oc
+		sm.outs[k][outKey{out, property}] = oc
+		sm.subscriptions.Store(subscription{k, outKey{out, property}}, oc)
 	}
 
 	return nil
@@ -73,7 +177,11 @@ func (sm *SignalDispatcher) Signal(conn *dbus.Conn, path dbus.ObjectPath, iface,
 func (sm *SignalDispatcher) pipe(done <-chan struct{}) {
 	for {
 		select {
-		case s := <-sm.in:
+		case s, ok := <-sm.in:
+			if !ok {
+				// the connection was closed
+				return
+			}
 			sm.pipeSignal(s)
 		case <-done:
 			return
@@ -82,15 +190,59 @@ func (sm *SignalDispatcher) pipe(done <-chan struct{}) {
 }
 
 func (sm *SignalDispatcher) pipeSignal(s *dbus.Signal) {
+	// the lock is not held while sending, so that a blocked send does not block subscribe and RemoveSignal
+	keys := []SignalKey{{s.Path, s.Name, ""}}
+	if arg0, ok := firstString(s.Body); ok && arg0 != "" {
+		keys = append(keys, SignalKey{s.Path, s.Name, arg0})
+	}
+
 	sm.l.RLock()
-	defer sm.l.RUnlock()
+	var outs []outChan
+	for _, k := range keys {
+		for _, ch := range sm.outs[k] {
+			outs = append(outs, ch)
+		}
+	}
+	sm.l.RUnlock()
 
-	if outs, ok := sm.outs[SignalKey{s.Path, s.Name}]; ok {
-		for _, ch := range outs {
-			for _, v := range s.Body {
-				ch.Send(v)
-			}
+	for _, ch := range outs {
+		for _, v := range ch.extract(s.Body) {
+			ch.Send(v)
+		}
+	}
+}
+
+// firstString returns the first argument of body if it is a string.
+func firstString(body []interface{}) (string, bool) {
+	if len(body) == 0 {
+		return "", false
+	}
+	s, ok := body[0].(string)
+	return s, ok
+}
+
+func eachValue(body []interface{}) []interface{} {
+	return body
+}
+
+func wholeBody(body []interface{}) []interface{} {
+	return []interface{}{body}
+}
+
+func changedValue(iface, property string) func([]interface{}) []interface{} {
+	return func(body []interface{}) []interface{} {
+		if len(body) < 2 || body[0] != iface {
+			return nil
 		}
+		changed, ok := body[1].(map[string]dbus.Variant)
+		if !ok {
+			return nil
+		}
+		v, ok := changed[property]
+		if !ok {
+			return nil
+		}
+		return []interface{}{v.Value()}
 	}
 }
 
@@ -101,7 +253,10 @@ func newOutChan(ch interface{}, inType reflect.Type, convert interface{}) (outCh
 	}
 
 	oc := outChan{
-		value: reflect.ValueOf(ch),
+		value:   reflect.ValueOf(ch),
+		inType:  inType,
+		removed: make(chan struct{}),
+		once:    new(sync.Once),
 	}
 
 	chElemType := chType.Elem()
@@ -133,6 +288,16 @@ func newOutChan(ch interface{}, inType reflect.Type, convert interface{}) (outCh
 	return oc, nil
 }
 
+// Send sends v to the channel, until the channel is removed.
+//
+// v is dropped if its dynamic type is not inType, for example a property of an unexpected type.
+// Signals are sent one at a time, so a channel which is not read blocks the signals of the whole connection until it is removed.
 func (oc outChan) Send(v interface{}) {
-	oc.value.Send(oc.convert(v))
+	if reflect.TypeOf(v) != oc.inType {
+		return
+	}
+	reflect.Select([]reflect.SelectCase{
+		{Dir: reflect.SelectSend, Chan: oc.value, Send: oc.convert(v)},
+		{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(oc.removed)},
+	})
 }
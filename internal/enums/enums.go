@@ -0,0 +1,85 @@
+// Package enums implements String, parsing and bits decomposition for the enum and flag types of NetworkManager.
+package enums
+
+import (
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+// Name is the name of an enum value or of a flag.
+type Name struct {
+	Value uint
+	Name  string
+}
+
+// String returns the name of v in names, or v as a decimal number if it has no name.
+func String(v uint, names []Name) string {
+	for _, n := range names {
+		if n.Value == v {
+			return n.Name
+		}
+	}
+	return strconv.FormatUint(uint64(v), 10)
+}
+
+// Parse returns the value named s in names, s may also be a number as returned by String for unknown values.
+func Parse(typeName string, s string, names []Name) (uint, error) {
+	for _, n := range names {
+		if n.Name == s {
+			return n.Value, nil
+		}
+	}
+	if v, err := strconv.ParseUint(s, 0, 0); err == nil {
+		return uint(v), nil
+	}
+	return 0, fmt.Errorf("invalid %s: %q", typeName, s)
+}
+
+// FlagsString joins the names of the flags set in v with " | ", unknown flags are kept as a hexadecimal number.
+//
+// none is returned if v is 0.
+func FlagsString(v uint, none string, names []Name) string {
+	if v == 0 {
+		return none
+	}
+	var parts []string
+	for _, n := range names {
+		if v&n.Value != 0 {
+			parts = append(parts, n.Name)
+			v &^= n.Value
+		}
+	}
+	if v != 0 {
+		parts = append(parts, "0x"+strconv.FormatUint(uint64(v), 16))
+	}
+	return strings.Join(parts, " | ")
+}
+
+// ParseFlags parses flags as returned by FlagsString.
+func ParseFlags(typeName string, s string, none string, names []Name) (uint, error) {
+	if s == none {
+		return 0, nil
+	}
+	var v uint
+	for _, part := range strings.Split(s, "|") {
+		f, err := Parse(typeName, strings.TrimSpace(part), names)
+		if err != nil {
+			return 0, err
+		}
+		v |= f
+	}
+	return v, nil
+}
+
+// Bits decomposes v into its single bits, in ascending order.
+func Bits(v uint) []uint {
+	var b []uint
+	for v != 0 {
+		bit := uint(1) << bits.TrailingZeros(v)
+		b = append(b, bit)
+		v &^= bit
+	}
+	return b
+}
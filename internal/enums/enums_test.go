@@ -0,0 +1,57 @@
+package enums
+
+import (
+	"reflect"
+	"testing"
+)
+
+var testNames = []Name{
+	{Value: 0x1, Name: "A"},
+	{Value: 0x2, Name: "B"},
+	{Value: 0x8, Name: "D"},
+}
+
+func TestEnumRoundTrip(t *testing.T) {
+	for _, v := range []uint{1, 2, 8, 42} {
+		s := String(v, testNames)
+		got, err := Parse("test", s, testNames)
+		if err != nil || got != v {
+			t.Errorf("Parse(String(%d)) = (%d, %v), expected (%d, nil)", v, got, err, v)
+		}
+	}
+
+	if _, err := Parse("test", "C", testNames); err == nil {
+		t.Errorf("Parse(\"C\") should fail")
+	}
+}
+
+func TestFlagsRoundTrip(t *testing.T) {
+	tests := []struct {
+		v uint
+		s string
+	}{
+		{0, "NONE"},
+		{0x1, "A"},
+		{0x3, "A | B"},
+		{0x1b, "A | B | D | 0x10"},
+		{0x30, "0x30"},
+	}
+
+	for _, test := range tests {
+		if s := FlagsString(test.v, "NONE", testNames); s != test.s {
+			t.Errorf("FlagsString(%#x) = %q, expected %q", test.v, s, test.s)
+		}
+		if v, err := ParseFlags("test", test.s, "NONE", testNames); err != nil || v != test.v {
+			t.Errorf("ParseFlags(%q) = (%#x, %v), expected (%#x, nil)", test.s, v, err, test.v)
+		}
+	}
+}
+
+func TestBits(t *testing.T) {
+	if bits := Bits(0x1b); !reflect.DeepEqual(bits, []uint{0x1, 0x2, 0x8, 0x10}) {
+		t.Errorf("Bits(0x1b) = %#v", bits)
+	}
+	if bits := Bits(0); bits != nil {
+		t.Errorf("Bits(0) = %#v, expected nil", bits)
+	}
+}
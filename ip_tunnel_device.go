@@ -0,0 +1,302 @@
+package netmgr
+
+import (
+	"github.com/nlepage/go-netmgr/internal/enums"
+)
+
+// IPTunnelDeviceIface is the IPTunnel Device interface.
+const IPTunnelDeviceIface = "org.freedesktop.NetworkManager.Device.IPTunnel"
+
+type (
+	// IPTunnelDevice represents an IP tunnel device.
+	//
+	// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.IPTunnel.html for more information.
+	IPTunnelDevice interface {
+		Device
+		isIPTunnelDevice()
+
+		// Properties
+
+		// HwAddress is the hardware address of the device.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.IPTunnel.html#gdbus-property-org-freedesktop-NetworkManager-Device-IPTunnel.HwAddress for more information.
+		HwAddress() (string, error)
+
+		// Mode is the tunneling mode.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.IPTunnel.html#gdbus-property-org-freedesktop-NetworkManager-Device-IPTunnel.Mode for more information.
+		Mode() (IPTunnelMode, error)
+
+		// Parent is the parent device of the tunnel, nil if none.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.IPTunnel.html#gdbus-property-org-freedesktop-NetworkManager-Device-IPTunnel.Parent for more information.
+		Parent() (Device, error)
+
+		// Local is the local endpoint of the tunnel.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.IPTunnel.html#gdbus-property-org-freedesktop-NetworkManager-Device-IPTunnel.Local for more information.
+		Local() (string, error)
+
+		// Remote is the remote endpoint of the tunnel.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.IPTunnel.html#gdbus-property-org-freedesktop-NetworkManager-Device-IPTunnel.Remote for more information.
+		Remote() (string, error)
+
+		// TTL is the TTL assigned to tunneled packets, 0 means inheriting it from the encapsulated packets.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.IPTunnel.html#gdbus-property-org-freedesktop-NetworkManager-Device-IPTunnel.Ttl for more information.
+		TTL() (byte, error)
+
+		// Tos is the type of service (IPv4) or traffic class (IPv6) assigned to tunneled packets.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.IPTunnel.html#gdbus-property-org-freedesktop-NetworkManager-Device-IPTunnel.Tos for more information.
+		Tos() (byte, error)
+
+		// PathMtuDiscovery tells if path MTU discovery is enabled on this tunnel.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.IPTunnel.html#gdbus-property-org-freedesktop-NetworkManager-Device-IPTunnel.PathMtuDiscovery for more information.
+		PathMtuDiscovery() (bool, error)
+
+		// InputKey is the key used for incoming packets.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.IPTunnel.html#gdbus-property-org-freedesktop-NetworkManager-Device-IPTunnel.InputKey for more information.
+		InputKey() (string, error)
+
+		// OutputKey is the key used for outgoing packets.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.IPTunnel.html#gdbus-property-org-freedesktop-NetworkManager-Device-IPTunnel.OutputKey for more information.
+		OutputKey() (string, error)
+
+		// EncapsulationLimit is how many additional levels of encapsulation are permitted to be prepended to packets, only for IPv6 tunnels.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.IPTunnel.html#gdbus-property-org-freedesktop-NetworkManager-Device-IPTunnel.EncapsulationLimit for more information.
+		EncapsulationLimit() (byte, error)
+
+		// FlowLabel is the flow label to assign to tunnel packets, only for IPv6 tunnels.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.IPTunnel.html#gdbus-property-org-freedesktop-NetworkManager-Device-IPTunnel.FlowLabel for more information.
+		FlowLabel() (uint32, error)
+
+		// Flags are the tunnel flags.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.IPTunnel.html#gdbus-property-org-freedesktop-NetworkManager-Device-IPTunnel.Flags for more information.
+		Flags() (IPTunnelFlags, error)
+	}
+
+	ipTunnelDevice struct {
+		device
+	}
+)
+
+var _ IPTunnelDevice = (*ipTunnelDevice)(nil)
+
+func (*ipTunnelDevice) isIPTunnelDevice() {}
+
+func (d *ipTunnelDevice) HwAddress() (string, error) {
+	return d.GetSProperty(IPTunnelDeviceIface + ".HwAddress")
+}
+
+func (d *ipTunnelDevice) Mode() (IPTunnelMode, error) {
+	mode, err := d.GetUProperty(IPTunnelDeviceIface + ".Mode")
+	return IPTunnelMode(mode), err
+}
+
+func (d *ipTunnelDevice) Parent() (Device, error) {
+	return d.parent(IPTunnelDeviceIface)
+}
+
+func (d *ipTunnelDevice) Local() (string, error) {
+	return d.GetSProperty(IPTunnelDeviceIface + ".Local")
+}
+
+func (d *ipTunnelDevice) Remote() (string, error) {
+	return d.GetSProperty(IPTunnelDeviceIface + ".Remote")
+}
+
+func (d *ipTunnelDevice) TTL() (byte, error) {
+	return d.GetYProperty(IPTunnelDeviceIface + ".Ttl")
+}
+
+func (d *ipTunnelDevice) Tos() (byte, error) {
+	return d.GetYProperty(IPTunnelDeviceIface + ".Tos")
+}
+
+func (d *ipTunnelDevice) PathMtuDiscovery() (bool, error) {
+	return d.GetBProperty(IPTunnelDeviceIface + ".PathMtuDiscovery")
+}
+
+func (d *ipTunnelDevice) InputKey() (string, error) {
+	return d.GetSProperty(IPTunnelDeviceIface + ".InputKey")
+}
+
+func (d *ipTunnelDevice) OutputKey() (string, error) {
+	return d.GetSProperty(IPTunnelDeviceIface + ".OutputKey")
+}
+
+func (d *ipTunnelDevice) EncapsulationLimit() (byte, error) {
+	return d.GetYProperty(IPTunnelDeviceIface + ".EncapsulationLimit")
+}
+
+func (d *ipTunnelDevice) FlowLabel() (uint32, error) {
+	return d.GetUProperty(IPTunnelDeviceIface + ".FlowLabel")
+}
+
+func (d *ipTunnelDevice) Flags() (IPTunnelFlags, error) {
+	flags, err := d.GetUProperty(IPTunnelDeviceIface + ".Flags")
+	return IPTunnelFlags(flags), err
+}
+
+// IPTunnelMode is the tunneling mode of an IP tunnel.
+//
+// See https://developer.gnome.org/NetworkManager/stable/nm-dbus-types.html#NMIPTunnelMode for more information.
+type IPTunnelMode uint
+
+const (
+	// IPTunnelModeUnknown means the tunnel mode is unknown.
+	IPTunnelModeUnknown IPTunnelMode = iota
+
+	// IPTunnelModeIPIP is IP in IP tunnel.
+	IPTunnelModeIPIP
+
+	// IPTunnelModeGRE is GRE tunnel.
+	IPTunnelModeGRE
+
+	// IPTunnelModeSIT is SIT tunnel.
+	IPTunnelModeSIT
+
+	// IPTunnelModeISATAP is ISATAP tunnel.
+	IPTunnelModeISATAP
+
+	// IPTunnelModeVTI is VTI tunnel.
+	IPTunnelModeVTI
+
+	// IPTunnelModeIP6IP6 is IPv6 in IPv6 tunnel.
+	IPTunnelModeIP6IP6
+
+	// IPTunnelModeIPIP6 is IPv4 in IPv6 tunnel.
+	IPTunnelModeIPIP6
+
+	// IPTunnelModeIP6GRE is IPv6 GRE tunnel.
+	IPTunnelModeIP6GRE
+
+	// IPTunnelModeVTI6 is IPv6 VTI tunnel.
+	IPTunnelModeVTI6
+
+	// IPTunnelModeGRETAP is GRETAP tunnel.
+	IPTunnelModeGRETAP
+
+	// IPTunnelModeIP6GRETAP is IPv6 GRETAP tunnel.
+	IPTunnelModeIP6GRETAP
+)
+
+var ipTunnelModeNames = []enums.Name{
+	{Value: uint(IPTunnelModeUnknown), Name: "NM_IP_TUNNEL_MODE_UNKNOWN"},
+	{Value: uint(IPTunnelModeIPIP), Name: "NM_IP_TUNNEL_MODE_IPIP"},
+	{Value: uint(IPTunnelModeGRE), Name: "NM_IP_TUNNEL_MODE_GRE"},
+	{Value: uint(IPTunnelModeSIT), Name: "NM_IP_TUNNEL_MODE_SIT"},
+	{Value: uint(IPTunnelModeISATAP), Name: "NM_IP_TUNNEL_MODE_ISATAP"},
+	{Value: uint(IPTunnelModeVTI), Name: "NM_IP_TUNNEL_MODE_VTI"},
+	{Value: uint(IPTunnelModeIP6IP6), Name: "NM_IP_TUNNEL_MODE_IP6IP6"},
+	{Value: uint(IPTunnelModeIPIP6), Name: "NM_IP_TUNNEL_MODE_IPIP6"},
+	{Value: uint(IPTunnelModeIP6GRE), Name: "NM_IP_TUNNEL_MODE_IP6GRE"},
+	{Value: uint(IPTunnelModeVTI6), Name: "NM_IP_TUNNEL_MODE_VTI6"},
+	{Value: uint(IPTunnelModeGRETAP), Name: "NM_IP_TUNNEL_MODE_GRETAP"},
+	{Value: uint(IPTunnelModeIP6GRETAP), Name: "NM_IP_TUNNEL_MODE_IP6GRETAP"},
+}
+
+func (m IPTunnelMode) String() string {
+	return enums.String(uint(m), ipTunnelModeNames)
+}
+
+// ParseIPTunnelMode returns the IPTunnelMode named s, as returned by IPTunnelMode.String.
+func ParseIPTunnelMode(s string) (IPTunnelMode, error) {
+	v, err := enums.Parse("IPTunnelMode", s, ipTunnelModeNames)
+	return IPTunnelMode(v), err
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (m IPTunnelMode) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (m *IPTunnelMode) UnmarshalText(text []byte) error {
+	v, err := ParseIPTunnelMode(string(text))
+	if err != nil {
+		return err
+	}
+	*m = v
+	return nil
+}
+
+// IPTunnelFlags are the flags of an IP tunnel.
+//
+// See https://developer.gnome.org/NetworkManager/stable/nm-dbus-types.html#NMIPTunnelFlags for more information.
+type IPTunnelFlags uint
+
+const (
+	// IPTunnelFlagNone means no flags.
+	IPTunnelFlagNone IPTunnelFlags = 0
+
+	// IPTunnelFlagIP6IgnEncapLimit means the encapsulation limit is ignored.
+	IPTunnelFlagIP6IgnEncapLimit IPTunnelFlags = 1 << (iota - 1)
+
+	// IPTunnelFlagIP6UseOrigTclass means the traffic class is copied from the inner packet.
+	IPTunnelFlagIP6UseOrigTclass
+
+	// IPTunnelFlagIP6UseOrigFlowlabel means the flow label is copied from the inner packet.
+	IPTunnelFlagIP6UseOrigFlowlabel
+
+	// IPTunnelFlagIP6Mip6Dev means the tunnel is used for Mobile IPv6.
+	IPTunnelFlagIP6Mip6Dev
+
+	// IPTunnelFlagIP6RcvDscpCopy means the DSCP field is copied from the outer packet when decapsulating.
+	IPTunnelFlagIP6RcvDscpCopy
+
+	// IPTunnelFlagIP6UseOrigFwmark means the firewall mark is copied from the inner packet.
+	IPTunnelFlagIP6UseOrigFwmark
+)
+
+var ipTunnelFlagsNames = []enums.Name{
+	{Value: uint(IPTunnelFlagIP6IgnEncapLimit), Name: "NM_IP_TUNNEL_FLAG_IP6_IGN_ENCAP_LIMIT"},
+	{Value: uint(IPTunnelFlagIP6UseOrigTclass), Name: "NM_IP_TUNNEL_FLAG_IP6_USE_ORIG_TCLASS"},
+	{Value: uint(IPTunnelFlagIP6UseOrigFlowlabel), Name: "NM_IP_TUNNEL_FLAG_IP6_USE_ORIG_FLOWLABEL"},
+	{Value: uint(IPTunnelFlagIP6Mip6Dev), Name: "NM_IP_TUNNEL_FLAG_IP6_MIP6_DEV"},
+	{Value: uint(IPTunnelFlagIP6RcvDscpCopy), Name: "NM_IP_TUNNEL_FLAG_IP6_RCV_DSCP_COPY"},
+	{Value: uint(IPTunnelFlagIP6UseOrigFwmark), Name: "NM_IP_TUNNEL_FLAG_IP6_USE_ORIG_FWMARK"},
+}
+
+func (f IPTunnelFlags) String() string {
+	return enums.FlagsString(uint(f), "NM_IP_TUNNEL_FLAG_NONE", ipTunnelFlagsNames)
+}
+
+// ParseIPTunnelFlags returns the IPTunnelFlags corresponding to s, as returned by IPTunnelFlags.String.
+func ParseIPTunnelFlags(s string) (IPTunnelFlags, error) {
+	v, err := enums.ParseFlags("IPTunnelFlags", s, "NM_IP_TUNNEL_FLAG_NONE", ipTunnelFlagsNames)
+	return IPTunnelFlags(v), err
+}
+
+// Bits decomposes f into its single flags.
+func (f IPTunnelFlags) Bits() []IPTunnelFlags {
+	bits := enums.Bits(uint(f))
+	flags := make([]IPTunnelFlags, len(bits))
+	for i, bit := range bits {
+		flags[i] = IPTunnelFlags(bit)
+	}
+	return flags
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (f IPTunnelFlags) MarshalText() ([]byte, error) {
+	return []byte(f.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (f *IPTunnelFlags) UnmarshalText(text []byte) error {
+	v, err := ParseIPTunnelFlags(string(text))
+	if err != nil {
+		return err
+	}
+	*f = v
+	return nil
+}
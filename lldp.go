@@ -0,0 +1,477 @@
+package netmgr
+
+import (
+	"net"
+
+	"github.com/godbus/dbus/v5"
+
+	"github.com/nlepage/go-netmgr/internal/enums"
+)
+
+type (
+	// LLDPNeighbor is a neighbor discovered with LLDP, as in the LldpNeighbors property of devices.
+	//
+	// Fields are zero if the neighbor did not advertise them.
+	//
+	// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.html#gdbus-property-org-freedesktop-NetworkManager-Device.LldpNeighbors for more information.
+	LLDPNeighbor struct {
+		// Raw is the raw LLDP frame.
+		Raw []byte
+
+		// Destination is the destination of the LLDP frame, such as "nearest-bridge".
+		Destination string
+
+		// ChassisIDType is the type of ChassisID.
+		ChassisIDType LLDPChassisIDType
+
+		// ChassisID identifies the chassis of the neighbor, such as a MAC address.
+		ChassisID string
+
+		// PortIDType is the type of PortID.
+		PortIDType LLDPPortIDType
+
+		// PortID identifies the port of the neighbor, such as an interface name.
+		PortID string
+
+		// PortDescription is the description of the port of the neighbor.
+		PortDescription string
+
+		// SystemName is the administratively assigned name of the neighbor.
+		SystemName string
+
+		// SystemDescription is the description of the neighbor, such as its hardware and software versions.
+		SystemDescription string
+
+		// SystemCapabilities are the capabilities of the neighbor.
+		SystemCapabilities LLDPSystemCapabilities
+
+		// ManagementAddresses are the addresses to manage the neighbor.
+		ManagementAddresses []LLDPManagementAddress
+
+		// PVID is the port VLAN identifier.
+		PVID uint32
+
+		// PPVIDs are the port and protocol VLAN identifiers.
+		PPVIDs []LLDPPPVID
+
+		// VLANs are the VLANs of the port.
+		VLANs []LLDPVLAN
+
+		// MACPHYConf is the IEEE 802.3 MAC/PHY configuration and status, nil if not advertised.
+		MACPHYConf *LLDPMACPHYConf
+
+		// PowerViaMDI is the IEEE 802.3 power via MDI, nil if not advertised.
+		PowerViaMDI *LLDPPowerViaMDI
+
+		// MaxFrameSize is the IEEE 802.3 maximum frame size.
+		MaxFrameSize uint32
+
+		// MUDURL is the Manufacturer Usage Description URL.
+		MUDURL string
+	}
+
+	// LLDPManagementAddress is a management address of an LLDP neighbor.
+	LLDPManagementAddress struct {
+		// AddressSubtype is the IANA address family number of Address, 1 for IPv4 and 2 for IPv6.
+		AddressSubtype uint32
+
+		// Address is the management address.
+		Address []byte
+
+		// InterfaceNumberSubtype is the numbering method of InterfaceNumber.
+		InterfaceNumberSubtype uint32
+
+		// InterfaceNumber is the number of the interface of the management address.
+		InterfaceNumber uint32
+
+		// ObjectID is the OID of the hardware component or protocol entity of the management address.
+		ObjectID []byte
+	}
+
+	// LLDPPPVID is a port and protocol VLAN identifier of an LLDP neighbor.
+	LLDPPPVID struct {
+		PPVID uint32
+		Flags uint32
+	}
+
+	// LLDPVLAN is a VLAN of an LLDP neighbor.
+	LLDPVLAN struct {
+		VID  uint32
+		Name string
+	}
+
+	// LLDPMACPHYConf is the IEEE 802.3 MAC/PHY configuration and status of an LLDP neighbor.
+	LLDPMACPHYConf struct {
+		Autoneg            uint32
+		PMDAutonegCap      uint32
+		OperationalMAUType uint32
+	}
+
+	// LLDPPowerViaMDI is the IEEE 802.3 power via MDI of an LLDP neighbor.
+	LLDPPowerViaMDI struct {
+		MDIPowerSupport uint32
+		PSEPowerPair    uint32
+		PowerClass      uint32
+	}
+)
+
+// IP returns the management address as an IP, nil if it is not an IPv4 or IPv6 address.
+func (a LLDPManagementAddress) IP() net.IP {
+	switch {
+	case a.AddressSubtype == 1 && len(a.Address) == net.IPv4len, a.AddressSubtype == 2 && len(a.Address) == net.IPv6len:
+		return net.IP(a.Address)
+	}
+	return nil
+}
+
+// DecodeLLDPNeighbor returns the LLDPNeighbor corresponding to the D-Bus representation m.
+func DecodeLLDPNeighbor(m map[string]dbus.Variant) LLDPNeighbor {
+	var n LLDPNeighbor
+	n.Raw, _ = m["raw"].Value().([]byte)
+	n.Destination, _ = m["destination"].Value().(string)
+	chassisIDType, _ := m["chassis-id-type"].Value().(uint32)
+	n.ChassisIDType = LLDPChassisIDType(chassisIDType)
+	n.ChassisID, _ = m["chassis-id"].Value().(string)
+	portIDType, _ := m["port-id-type"].Value().(uint32)
+	n.PortIDType = LLDPPortIDType(portIDType)
+	n.PortID, _ = m["port-id"].Value().(string)
+	n.PortDescription, _ = m["port-description"].Value().(string)
+	n.SystemName, _ = m["system-name"].Value().(string)
+	n.SystemDescription, _ = m["system-description"].Value().(string)
+	systemCapabilities, _ := m["system-capabilities"].Value().(uint32)
+	n.SystemCapabilities = LLDPSystemCapabilities(systemCapabilities)
+
+	addresses, _ := m["management-addresses"].Value().([]map[string]dbus.Variant)
+	for _, a := range addresses {
+		var address LLDPManagementAddress
+		address.AddressSubtype, _ = a["address-subtype"].Value().(uint32)
+		address.Address, _ = a["address"].Value().([]byte)
+		address.InterfaceNumberSubtype, _ = a["interface-number-subtype"].Value().(uint32)
+		address.InterfaceNumber, _ = a["interface-number"].Value().(uint32)
+		address.ObjectID, _ = a["object-id"].Value().([]byte)
+		n.ManagementAddresses = append(n.ManagementAddresses, address)
+	}
+
+	n.PVID, _ = m["ieee-802-1-pvid"].Value().(uint32)
+
+	ppvids, _ := m["ieee-802-1-ppvids"].Value().([]map[string]dbus.Variant)
+	for _, p := range ppvids {
+		var ppvid LLDPPPVID
+		ppvid.PPVID, _ = p["ppvid"].Value().(uint32)
+		ppvid.Flags, _ = p["flags"].Value().(uint32)
+		n.PPVIDs = append(n.PPVIDs, ppvid)
+	}
+	if ppvids == nil {
+		// NetworkManager older than 1.10 only has the first PPVID
+		if ppvid, ok := m["ieee-802-1-ppvid"].Value().(uint32); ok {
+			flags, _ := m["ieee-802-1-ppvid-flags"].Value().(uint32)
+			n.PPVIDs = []LLDPPPVID{{ppvid, flags}}
+		}
+	}
+
+	vlans, _ := m["ieee-802-1-vlans"].Value().([]map[string]dbus.Variant)
+	for _, v := range vlans {
+		var vlan LLDPVLAN
+		vlan.VID, _ = v["vid"].Value().(uint32)
+		vlan.Name, _ = v["name"].Value().(string)
+		n.VLANs = append(n.VLANs, vlan)
+	}
+	if vlans == nil {
+		// NetworkManager older than 1.10 only has the first VLAN
+		if vid, ok := m["ieee-802-1-vid"].Value().(uint32); ok {
+			name, _ := m["ieee-802-1-vlan-name"].Value().(string)
+			n.VLANs = []LLDPVLAN{{vid, name}}
+		}
+	}
+
+	if conf, ok := m["ieee-802-3-mac-phy-conf"].Value().(map[string]dbus.Variant); ok {
+		n.MACPHYConf = &LLDPMACPHYConf{}
+		n.MACPHYConf.Autoneg, _ = conf["autoneg"].Value().(uint32)
+		n.MACPHYConf.PMDAutonegCap, _ = conf["pmd-autoneg-cap"].Value().(uint32)
+		n.MACPHYConf.OperationalMAUType, _ = conf["operational-mau-type"].Value().(uint32)
+	}
+
+	if power, ok := m["ieee-802-3-power-via-mdi"].Value().(map[string]dbus.Variant); ok {
+		n.PowerViaMDI = &LLDPPowerViaMDI{}
+		n.PowerViaMDI.MDIPowerSupport, _ = power["mdi-power-support"].Value().(uint32)
+		n.PowerViaMDI.PSEPowerPair, _ = power["pse-power-pair"].Value().(uint32)
+		n.PowerViaMDI.PowerClass, _ = power["power-class"].Value().(uint32)
+	}
+
+	n.MaxFrameSize, _ = m["ieee-802-3-max-frame-size"].Value().(uint32)
+	n.MUDURL, _ = m["mud-url"].Value().(string)
+
+	return n
+}
+
+func (d *device) LldpNeighbors() ([]LLDPNeighbor, error) {
+	p, err := d.GetProperty(DeviceIface + ".LldpNeighbors")
+	if err != nil {
+		return nil, err
+	}
+	aasv, _ := p.Value().([]map[string]dbus.Variant)
+	neighbors := make([]LLDPNeighbor, len(aasv))
+	for i, asv := range aasv {
+		neighbors[i] = DecodeLLDPNeighbor(asv)
+	}
+	return neighbors, nil
+}
+
+// DeviceLLDPNeighbors are the LLDP neighbors of a device.
+type DeviceLLDPNeighbors struct {
+	Device    Device
+	Interface string
+	Neighbors []LLDPNeighbor
+}
+
+func (nm *networkManager) LLDPNeighbors() ([]DeviceLLDPNeighbors, error) {
+	devices, err := nm.GetAllDevices()
+	if err != nil {
+		return nil, err
+	}
+
+	all := make([]DeviceLLDPNeighbors, 0, len(devices))
+	for _, d := range devices {
+		iface, err := d.Interface()
+		if isRemovedObject(err) {
+			// the device was removed after being listed
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		neighbors, err := d.LldpNeighbors()
+		if isRemovedObject(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, DeviceLLDPNeighbors{d, iface, neighbors})
+	}
+	return all, nil
+}
+
+// LLDPNeighbors returns the LLDP neighbors of all the devices, including the devices without neighbors.
+//
+// LLDP must be enabled in the connection settings of a device to discover its neighbors.
+// The devices removed while the neighbors are being read are skipped.
+func LLDPNeighbors() ([]DeviceLLDPNeighbors, error) {
+	nm, err := System()
+	if err != nil {
+		return nil, err
+	}
+	return nm.LLDPNeighbors()
+}
+
+// LLDPChassisIDType is the type of the chassis ID of an LLDP neighbor.
+type LLDPChassisIDType uint
+
+const (
+	// LLDPChassisIDTypeChassisComponent is the entPhysicalAlias of a chassis component.
+	LLDPChassisIDTypeChassisComponent LLDPChassisIDType = iota + 1
+
+	// LLDPChassisIDTypeInterfaceAlias is the ifAlias of an interface.
+	LLDPChassisIDTypeInterfaceAlias
+
+	// LLDPChassisIDTypePortComponent is the entPhysicalAlias of a port or backplane component.
+	LLDPChassisIDTypePortComponent
+
+	// LLDPChassisIDTypeMACAddress is a MAC address.
+	LLDPChassisIDTypeMACAddress
+
+	// LLDPChassisIDTypeNetworkAddress is a network address.
+	LLDPChassisIDTypeNetworkAddress
+
+	// LLDPChassisIDTypeInterfaceName is the ifName of an interface.
+	LLDPChassisIDTypeInterfaceName
+
+	// LLDPChassisIDTypeLocallyAssigned is a locally assigned identifier.
+	LLDPChassisIDTypeLocallyAssigned
+)
+
+var lldpChassisIDTypeNames = []enums.Name{
+	{Value: uint(LLDPChassisIDTypeChassisComponent), Name: "chassis-component"},
+	{Value: uint(LLDPChassisIDTypeInterfaceAlias), Name: "interface-alias"},
+	{Value: uint(LLDPChassisIDTypePortComponent), Name: "port-component"},
+	{Value: uint(LLDPChassisIDTypeMACAddress), Name: "mac-address"},
+	{Value: uint(LLDPChassisIDTypeNetworkAddress), Name: "network-address"},
+	{Value: uint(LLDPChassisIDTypeInterfaceName), Name: "interface-name"},
+	{Value: uint(LLDPChassisIDTypeLocallyAssigned), Name: "locally-assigned"},
+}
+
+func (t LLDPChassisIDType) String() string {
+	return enums.String(uint(t), lldpChassisIDTypeNames)
+}
+
+// ParseLLDPChassisIDType returns the LLDPChassisIDType named s, as returned by LLDPChassisIDType.String.
+func ParseLLDPChassisIDType(s string) (LLDPChassisIDType, error) {
+	v, err := enums.Parse("LLDPChassisIDType", s, lldpChassisIDTypeNames)
+	return LLDPChassisIDType(v), err
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (t LLDPChassisIDType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (t *LLDPChassisIDType) UnmarshalText(text []byte) error {
+	v, err := ParseLLDPChassisIDType(string(text))
+	if err != nil {
+		return err
+	}
+	*t = v
+	return nil
+}
+
+// LLDPPortIDType is the type of the port ID of an LLDP neighbor.
+type LLDPPortIDType uint
+
+const (
+	// LLDPPortIDTypeInterfaceAlias is the ifAlias of an interface.
+	LLDPPortIDTypeInterfaceAlias LLDPPortIDType = iota + 1
+
+	// LLDPPortIDTypePortComponent is the entPhysicalAlias of a port component.
+	LLDPPortIDTypePortComponent
+
+	// LLDPPortIDTypeMACAddress is a MAC address.
+	LLDPPortIDTypeMACAddress
+
+	// LLDPPortIDTypeNetworkAddress is a network address.
+	LLDPPortIDTypeNetworkAddress
+
+	// LLDPPortIDTypeInterfaceName is the ifName of an interface.
+	LLDPPortIDTypeInterfaceName
+
+	// LLDPPortIDTypeAgentCircuitID is an agent circuit ID.
+	LLDPPortIDTypeAgentCircuitID
+
+	// LLDPPortIDTypeLocallyAssigned is a locally assigned identifier.
+	LLDPPortIDTypeLocallyAssigned
+)
+
+var lldpPortIDTypeNames = []enums.Name{
+	{Value: uint(LLDPPortIDTypeInterfaceAlias), Name: "interface-alias"},
+	{Value: uint(LLDPPortIDTypePortComponent), Name: "port-component"},
+	{Value: uint(LLDPPortIDTypeMACAddress), Name: "mac-address"},
+	{Value: uint(LLDPPortIDTypeNetworkAddress), Name: "network-address"},
+	{Value: uint(LLDPPortIDTypeInterfaceName), Name: "interface-name"},
+	{Value: uint(LLDPPortIDTypeAgentCircuitID), Name: "agent-circuit-id"},
+	{Value: uint(LLDPPortIDTypeLocallyAssigned), Name: "locally-assigned"},
+}
+
+func (t LLDPPortIDType) String() string {
+	return enums.String(uint(t), lldpPortIDTypeNames)
+}
+
+// ParseLLDPPortIDType returns the LLDPPortIDType named s, as returned by LLDPPortIDType.String.
+func ParseLLDPPortIDType(s string) (LLDPPortIDType, error) {
+	v, err := enums.Parse("LLDPPortIDType", s, lldpPortIDTypeNames)
+	return LLDPPortIDType(v), err
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (t LLDPPortIDType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (t *LLDPPortIDType) UnmarshalText(text []byte) error {
+	v, err := ParseLLDPPortIDType(string(text))
+	if err != nil {
+		return err
+	}
+	*t = v
+	return nil
+}
+
+// LLDPSystemCapabilities are the system capabilities of an LLDP neighbor.
+type LLDPSystemCapabilities uint
+
+const (
+	// LLDPSystemCapabilityNone means no capabilities.
+	LLDPSystemCapabilityNone LLDPSystemCapabilities = 0
+
+	// LLDPSystemCapabilityOther means other capabilities.
+	LLDPSystemCapabilityOther LLDPSystemCapabilities = 1 << (iota - 1)
+
+	// LLDPSystemCapabilityRepeater means the neighbor is a repeater.
+	LLDPSystemCapabilityRepeater
+
+	// LLDPSystemCapabilityBridge means the neighbor is a MAC bridge.
+	LLDPSystemCapabilityBridge
+
+	// LLDPSystemCapabilityWLANAccessPoint means the neighbor is a WLAN access point.
+	LLDPSystemCapabilityWLANAccessPoint
+
+	// LLDPSystemCapabilityRouter means the neighbor is a router.
+	LLDPSystemCapabilityRouter
+
+	// LLDPSystemCapabilityTelephone means the neighbor is a telephone.
+	LLDPSystemCapabilityTelephone
+
+	// LLDPSystemCapabilityDOCSISCableDevice means the neighbor is a DOCSIS cable device.
+	LLDPSystemCapabilityDOCSISCableDevice
+
+	// LLDPSystemCapabilityStationOnly means the neighbor is a station only.
+	LLDPSystemCapabilityStationOnly
+
+	// LLDPSystemCapabilityCVLAN means the neighbor is a C-VLAN component.
+	LLDPSystemCapabilityCVLAN
+
+	// LLDPSystemCapabilitySVLAN means the neighbor is an S-VLAN component.
+	LLDPSystemCapabilitySVLAN
+
+	// LLDPSystemCapabilityTPMR means the neighbor is a two-port MAC relay.
+	LLDPSystemCapabilityTPMR
+)
+
+var lldpSystemCapabilitiesNames = []enums.Name{
+	{Value: uint(LLDPSystemCapabilityOther), Name: "other"},
+	{Value: uint(LLDPSystemCapabilityRepeater), Name: "repeater"},
+	{Value: uint(LLDPSystemCapabilityBridge), Name: "bridge"},
+	{Value: uint(LLDPSystemCapabilityWLANAccessPoint), Name: "wlan-access-point"},
+	{Value: uint(LLDPSystemCapabilityRouter), Name: "router"},
+	{Value: uint(LLDPSystemCapabilityTelephone), Name: "telephone"},
+	{Value: uint(LLDPSystemCapabilityDOCSISCableDevice), Name: "docsis-cable-device"},
+	{Value: uint(LLDPSystemCapabilityStationOnly), Name: "station-only"},
+	{Value: uint(LLDPSystemCapabilityCVLAN), Name: "c-vlan"},
+	{Value: uint(LLDPSystemCapabilitySVLAN), Name: "s-vlan"},
+	{Value: uint(LLDPSystemCapabilityTPMR), Name: "tpmr"},
+}
+
+func (c LLDPSystemCapabilities) String() string {
+	return enums.FlagsString(uint(c), "none", lldpSystemCapabilitiesNames)
+}
+
+// ParseLLDPSystemCapabilities returns the LLDPSystemCapabilities corresponding to s, as returned by LLDPSystemCapabilities.String.
+func ParseLLDPSystemCapabilities(s string) (LLDPSystemCapabilities, error) {
+	v, err := enums.ParseFlags("LLDPSystemCapabilities", s, "none", lldpSystemCapabilitiesNames)
+	return LLDPSystemCapabilities(v), err
+}
+
+// Bits decomposes c into its single flags.
+func (c LLDPSystemCapabilities) Bits() []LLDPSystemCapabilities {
+	bits := enums.Bits(uint(c))
+	capabilities := make([]LLDPSystemCapabilities, len(bits))
+	for i, bit := range bits {
+		capabilities[i] = LLDPSystemCapabilities(bit)
+	}
+	return capabilities
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (c LLDPSystemCapabilities) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (c *LLDPSystemCapabilities) UnmarshalText(text []byte) error {
+	v, err := ParseLLDPSystemCapabilities(string(text))
+	if err != nil {
+		return err
+	}
+	*c = v
+	return nil
+}
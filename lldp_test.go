@@ -0,0 +1,122 @@
+package netmgr
+
+import (
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/godbus/dbus/v5/prop"
+
+	"github.com/nlepage/go-netmgr/internal/dbusext"
+)
+
+func TestLldpNeighbors(t *testing.T) {
+	conn := privateBus(t)
+
+	const devicePath = dbus.ObjectPath(NetworkManagerPath + "/Devices/1")
+
+	export(t, conn, BusName, devicePath, map[string]map[string]*prop.Prop{
+		DeviceIface: {
+			"DeviceType": {Value: uint32(DeviceTypeGeneric)},
+			"LldpNeighbors": {Value: []map[string]dbus.Variant{{
+				"chassis-id-type":     dbus.MakeVariant(uint32(LLDPChassisIDTypeMACAddress)),
+				"chassis-id":          dbus.MakeVariant("00:11:22:33:44:55"),
+				"port-id-type":        dbus.MakeVariant(uint32(LLDPPortIDTypeInterfaceName)),
+				"port-id":             dbus.MakeVariant("Gi1/0/12"),
+				"system-name":         dbus.MakeVariant("switch1"),
+				"system-capabilities": dbus.MakeVariant(uint32(LLDPSystemCapabilityBridge | LLDPSystemCapabilityRouter)),
+				"management-addresses": dbus.MakeVariant([]map[string]dbus.Variant{{
+					"address-subtype": dbus.MakeVariant(uint32(1)),
+					"address":         dbus.MakeVariant([]byte{192, 168, 1, 2}),
+				}}),
+				"ieee-802-1-pvid": dbus.MakeVariant(uint32(10)),
+				"ieee-802-1-vlans": dbus.MakeVariant([]map[string]dbus.Variant{{
+					"vid":  dbus.MakeVariant(uint32(10)),
+					"name": dbus.MakeVariant("users"),
+				}}),
+				"ieee-802-3-mac-phy-conf": dbus.MakeVariant(map[string]dbus.Variant{
+					"autoneg":              dbus.MakeVariant(uint32(3)),
+					"operational-mau-type": dbus.MakeVariant(uint32(30)),
+				}),
+			}}},
+		},
+	})
+
+	d := NewDevice(conn, devicePath)
+
+	neighbors, err := d.LldpNeighbors()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(neighbors) != 1 {
+		t.Fatalf("expected 1 neighbor, got %d", len(neighbors))
+	}
+	n := neighbors[0]
+
+	if n.ChassisIDType != LLDPChassisIDTypeMACAddress || n.ChassisID != "00:11:22:33:44:55" {
+		t.Errorf("unexpected chassis id %s %q", n.ChassisIDType, n.ChassisID)
+	}
+	if n.PortIDType != LLDPPortIDTypeInterfaceName || n.PortID != "Gi1/0/12" {
+		t.Errorf("unexpected port id %s %q", n.PortIDType, n.PortID)
+	}
+	if s := n.SystemCapabilities.String(); s != "bridge | router" {
+		t.Errorf("SystemCapabilities = %q", s)
+	}
+	if len(n.ManagementAddresses) != 1 || !n.ManagementAddresses[0].IP().Equal(net.IPv4(192, 168, 1, 2)) {
+		t.Errorf("unexpected management addresses %v", n.ManagementAddresses)
+	}
+	if n.PVID != 10 || !reflect.DeepEqual(n.VLANs, []LLDPVLAN{{VID: 10, Name: "users"}}) {
+		t.Errorf("unexpected VLANs %d %v", n.PVID, n.VLANs)
+	}
+	if n.MACPHYConf == nil || *n.MACPHYConf != (LLDPMACPHYConf{Autoneg: 3, OperationalMAUType: 30}) {
+		t.Errorf("unexpected MAC/PHY configuration %v", n.MACPHYConf)
+	}
+	if n.PowerViaMDI != nil {
+		t.Errorf("expected no power via MDI, got %v", n.PowerViaMDI)
+	}
+}
+
+// removedObject replies to properties calls as NetworkManager does for a removed object.
+type removedObject struct{}
+
+func (removedObject) Get(iface, property string) (dbus.Variant, *dbus.Error) {
+	return dbus.Variant{}, errUnknownObject
+}
+
+// fakeDevices implements the GetAllDevices method of NetworkManager.
+type fakeDevices []dbus.ObjectPath
+
+func (f fakeDevices) GetAllDevices() ([]dbus.ObjectPath, *dbus.Error) {
+	return f, nil
+}
+
+func TestLLDPNeighborsRemovedDevice(t *testing.T) {
+	conn := privateBus(t)
+
+	const devicePath = dbus.ObjectPath(NetworkManagerPath + "/Devices/1")
+
+	export(t, conn, BusName, devicePath, map[string]map[string]*prop.Prop{
+		DeviceIface: {
+			"DeviceType":    {Value: uint32(DeviceTypeGeneric)},
+			"Interface":     {Value: "eth0"},
+			"LldpNeighbors": {Value: []map[string]dbus.Variant{}},
+		},
+	})
+	// the second device is removed after being listed
+	const removedPath = dbus.ObjectPath(NetworkManagerPath + "/Devices/2")
+	if err := conn.Export(removedObject{}, removedPath, dbusext.PropertiesIface); err != nil {
+		t.Fatal(err)
+	}
+	if err := conn.Export(fakeDevices{devicePath, removedPath}, NetworkManagerPath, NetworkManagerInterface); err != nil {
+		t.Fatal(err)
+	}
+
+	all, err := New(conn).LLDPNeighbors()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 1 || all[0].Interface != "eth0" {
+		t.Errorf("expected only the neighbors of eth0, got %+v", all)
+	}
+}
@@ -0,0 +1,204 @@
+package netmgr
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// LogLevel is a logging level of NetworkManager.
+//
+// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.html#gdbus-method-org-freedesktop-NetworkManager.SetLogging for more information.
+type LogLevel string
+
+const (
+	// LogLevelErr logs only errors.
+	LogLevelErr LogLevel = "ERR"
+
+	// LogLevelWarn logs warnings and errors.
+	LogLevelWarn LogLevel = "WARN"
+
+	// LogLevelInfo logs informational messages, warnings and errors.
+	LogLevelInfo LogLevel = "INFO"
+
+	// LogLevelDebug logs debug messages, and everything logged by LogLevelInfo.
+	LogLevelDebug LogLevel = "DEBUG"
+
+	// LogLevelTrace logs everything.
+	LogLevelTrace LogLevel = "TRACE"
+
+	// LogLevelOff disables logging.
+	LogLevelOff LogLevel = "OFF"
+
+	// LogLevelKeep keeps the current global level, only valid with SetLogging.
+	LogLevelKeep LogLevel = "KEEP"
+)
+
+// ParseLogLevel returns the LogLevel named s, case insensitively.
+func ParseLogLevel(s string) (LogLevel, error) {
+	switch level := LogLevel(strings.ToUpper(s)); level {
+	case LogLevelErr, LogLevelWarn, LogLevelInfo, LogLevelDebug, LogLevelTrace, LogLevelOff, LogLevelKeep:
+		return level, nil
+	}
+	return "", fmt.Errorf("invalid LogLevel: %q", s)
+}
+
+// LogDomain is a logging domain of NetworkManager.
+type LogDomain string
+
+// Logging domains of NetworkManager.
+const (
+	LogDomainPlatform   LogDomain = "PLATFORM"
+	LogDomainRfkill     LogDomain = "RFKILL"
+	LogDomainEther      LogDomain = "ETHER"
+	LogDomainWifi       LogDomain = "WIFI"
+	LogDomainBt         LogDomain = "BT"
+	LogDomainMb         LogDomain = "MB"
+	LogDomainDHCP4      LogDomain = "DHCP4"
+	LogDomainDHCP6      LogDomain = "DHCP6"
+	LogDomainPPP        LogDomain = "PPP"
+	LogDomainWifiScan   LogDomain = "WIFI_SCAN"
+	LogDomainIP4        LogDomain = "IP4"
+	LogDomainIP6        LogDomain = "IP6"
+	LogDomainAutoIP4    LogDomain = "AUTOIP4"
+	LogDomainDNS        LogDomain = "DNS"
+	LogDomainVPN        LogDomain = "VPN"
+	LogDomainSharing    LogDomain = "SHARING"
+	LogDomainSupplicant LogDomain = "SUPPLICANT"
+	LogDomainAgents     LogDomain = "AGENTS"
+	LogDomainSettings   LogDomain = "SETTINGS"
+	LogDomainSuspend    LogDomain = "SUSPEND"
+	LogDomainCore       LogDomain = "CORE"
+	LogDomainDevice     LogDomain = "DEVICE"
+	LogDomainOLPC       LogDomain = "OLPC"
+	LogDomainInfiniband LogDomain = "INFINIBAND"
+	LogDomainFirewall   LogDomain = "FIREWALL"
+	LogDomainADSL       LogDomain = "ADSL"
+	LogDomainBond       LogDomain = "BOND"
+	LogDomainVLAN       LogDomain = "VLAN"
+	LogDomainBridge     LogDomain = "BRIDGE"
+	LogDomainDBusProps  LogDomain = "DBUS_PROPS"
+	LogDomainTeam       LogDomain = "TEAM"
+	LogDomainConcheck   LogDomain = "CONCHECK"
+	LogDomainDCB        LogDomain = "DCB"
+	LogDomainDispatch   LogDomain = "DISPATCH"
+	LogDomainAudit      LogDomain = "AUDIT"
+	LogDomainSystemd    LogDomain = "SYSTEMD"
+	LogDomainVPNPlugin  LogDomain = "VPN_PLUGIN"
+	LogDomainProxy      LogDomain = "PROXY"
+)
+
+const (
+	// LogDomainAll means all the domains.
+	LogDomainAll LogDomain = "ALL"
+
+	// LogDomainDefault means the domains logged by default.
+	LogDomainDefault LogDomain = "DEFAULT"
+
+	// LogDomainDHCP means LogDomainDHCP4 and LogDomainDHCP6.
+	LogDomainDHCP LogDomain = "DHCP"
+
+	// LogDomainIP means LogDomainIP4 and LogDomainIP6.
+	LogDomainIP LogDomain = "IP"
+)
+
+// LogDomains are logging domains, with their level, empty to use the global level.
+type LogDomains map[LogDomain]LogLevel
+
+// ParseLogDomains parses logging domains as given to SetLogging or returned by GetLogging, such as "WIFI:DEBUG,DNS".
+//
+// Domains unknown to this package are accepted, as they may have been added by a newer NetworkManager.
+func ParseLogDomains(s string) (LogDomains, error) {
+	domains := LogDomains{}
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		var level LogLevel
+		if i := strings.IndexByte(part, ':'); i != -1 {
+			var err error
+			if level, err = ParseLogLevel(part[i+1:]); err != nil {
+				return nil, err
+			}
+			part = part[:i]
+		}
+		domains[LogDomain(strings.ToUpper(part))] = level
+	}
+	return domains, nil
+}
+
+// String returns the domains in the format of SetLogging, sorted by name.
+func (d LogDomains) String() string {
+	parts := make([]string, 0, len(d))
+	for domain, level := range d {
+		if level == "" {
+			parts = append(parts, string(domain))
+		} else {
+			parts = append(parts, string(domain)+":"+string(level))
+		}
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ",")
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (d LogDomains) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (d *LogDomains) UnmarshalText(text []byte) error {
+	domains, err := ParseLogDomains(string(text))
+	if err != nil {
+		return err
+	}
+	*d = domains
+	return nil
+}
+
+func (nm *networkManager) WithLogging(ctx context.Context, domains LogDomains, fn func(ctx context.Context) error) (err error) {
+	level, previous, err := nm.GetLogging()
+	if err != nil {
+		return err
+	}
+
+	// the domains given to SetLogging replace the enabled ones, so they are merged with the current ones
+	raised := make(LogDomains, len(previous)+len(domains))
+	for domain, domainLevel := range previous {
+		raised[domain] = domainLevel
+	}
+	for domain, domainLevel := range domains {
+		raised[domain] = domainLevel
+	}
+
+	if err := nm.SetLogging(LogLevelKeep, raised); err != nil {
+		return err
+	}
+
+	// restored even if fn panics, so that the daemon is not left logging verbosely
+	defer func() {
+		if restoreErr := nm.SetLogging(level, previous); restoreErr != nil {
+			if err != nil {
+				err = fmt.Errorf("%w (restoring logging failed: %v)", err, restoreErr)
+			} else {
+				err = fmt.Errorf("restoring logging failed: %w", restoreErr)
+			}
+		}
+	}()
+
+	return fn(ctx)
+}
+
+// WithLogging sets the level of the logging domains, keeping the global level and the other enabled domains, and calls fn.
+//
+// Once fn returns, the previous logging level and domains are put back.
+// For a troubleshooting window, fn may simply wait until ctx is done.
+func WithLogging(ctx context.Context, domains LogDomains, fn func(ctx context.Context) error) error {
+	nm, err := System()
+	if err != nil {
+		return err
+	}
+	return nm.WithLogging(ctx, domains, fn)
+}
@@ -0,0 +1,55 @@
+package netmgr
+
+import (
+	"context"
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// fakeLogging implements the GetLogging and SetLogging methods of NetworkManager.
+type fakeLogging struct {
+	level, domains string
+}
+
+func (f *fakeLogging) GetLogging() (string, string, *dbus.Error) {
+	return f.level, f.domains, nil
+}
+
+func (f *fakeLogging) SetLogging(level, domains string) *dbus.Error {
+	if level != string(LogLevelKeep) {
+		f.level = level
+	}
+	f.domains = domains
+	return nil
+}
+
+func TestWithLoggingPanic(t *testing.T) {
+	conn := privateBus(t)
+
+	if reply, err := conn.RequestName(BusName, dbus.NameFlagDoNotQueue); err != nil || reply != dbus.RequestNameReplyPrimaryOwner {
+		t.Fatalf("could not own %s: %v", BusName, err)
+	}
+	fake := &fakeLogging{level: "INFO", domains: "WIFI:INFO"}
+	if err := conn.Export(fake, NetworkManagerPath, NetworkManagerInterface); err != nil {
+		t.Fatal(err)
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected WithLogging to panic")
+			}
+		}()
+		New(conn).WithLogging(context.Background(), LogDomains{"DNS": LogLevelDebug}, func(ctx context.Context) error {
+			if fake.domains != "DNS:DEBUG,WIFI:INFO" {
+				t.Errorf("expected raised domains, got %q", fake.domains)
+			}
+			panic("fn failed")
+		})
+	}()
+
+	if fake.level != "INFO" || fake.domains != "WIFI:INFO" {
+		t.Errorf("expected logging INFO WIFI:INFO to be restored, got %s %s", fake.level, fake.domains)
+	}
+}
@@ -0,0 +1,141 @@
+package netmgr
+
+// MacsecDeviceIface is the Macsec Device interface.
+const MacsecDeviceIface = "org.freedesktop.NetworkManager.Device.Macsec"
+
+type (
+	// MacsecDevice represents a MACsec device.
+	//
+	// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Macsec.html for more information.
+	MacsecDevice interface {
+		Device
+		isMacsecDevice()
+
+		// Properties
+
+		// Parent is the parent device of the MACsec device, nil if none.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Macsec.html#gdbus-property-org-freedesktop-NetworkManager-Device-Macsec.Parent for more information.
+		Parent() (Device, error)
+
+		// Sci is the Secure Channel Identifier in use.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Macsec.html#gdbus-property-org-freedesktop-NetworkManager-Device-Macsec.Sci for more information.
+		Sci() (uint64, error)
+
+		// IcvLength is the length of the ICV (Integrity Check Value).
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Macsec.html#gdbus-property-org-freedesktop-NetworkManager-Device-Macsec.IcvLength for more information.
+		IcvLength() (byte, error)
+
+		// CipherSuite is the set of cryptographic algorithms in use.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Macsec.html#gdbus-property-org-freedesktop-NetworkManager-Device-Macsec.CipherSuite for more information.
+		CipherSuite() (uint64, error)
+
+		// Window is the size of the replay window.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Macsec.html#gdbus-property-org-freedesktop-NetworkManager-Device-Macsec.Window for more information.
+		Window() (uint32, error)
+
+		// EncodingSa is the value of the Association Number (0..3) for the Security Association in use.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Macsec.html#gdbus-property-org-freedesktop-NetworkManager-Device-Macsec.EncodingSa for more information.
+		EncodingSa() (byte, error)
+
+		// Validation is the validation mode for incoming packets, one of "strict", "check" or "disabled".
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Macsec.html#gdbus-property-org-freedesktop-NetworkManager-Device-Macsec.Validation for more information.
+		Validation() (string, error)
+
+		// Encrypt tells if encryption of the packets is enabled.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Macsec.html#gdbus-property-org-freedesktop-NetworkManager-Device-Macsec.Encrypt for more information.
+		Encrypt() (bool, error)
+
+		// Protect tells if protection of the packets is enabled.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Macsec.html#gdbus-property-org-freedesktop-NetworkManager-Device-Macsec.Protect for more information.
+		Protect() (bool, error)
+
+		// IncludeSci tells if the SCI is always included in the SecTAG.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Macsec.html#gdbus-property-org-freedesktop-NetworkManager-Device-Macsec.IncludeSci for more information.
+		IncludeSci() (bool, error)
+
+		// Es tells if the ES (End station) bit is enabled in the SecTAG.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Macsec.html#gdbus-property-org-freedesktop-NetworkManager-Device-Macsec.Es for more information.
+		Es() (bool, error)
+
+		// Scb tells if the SCB (Single Copy Broadcast) bit is enabled in the SecTAG.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Macsec.html#gdbus-property-org-freedesktop-NetworkManager-Device-Macsec.Scb for more information.
+		Scb() (bool, error)
+
+		// ReplayProtect tells if replay protection is enabled.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Macsec.html#gdbus-property-org-freedesktop-NetworkManager-Device-Macsec.ReplayProtect for more information.
+		ReplayProtect() (bool, error)
+	}
+
+	macsecDevice struct {
+		device
+	}
+)
+
+var _ MacsecDevice = (*macsecDevice)(nil)
+
+func (*macsecDevice) isMacsecDevice() {}
+
+func (d *macsecDevice) Parent() (Device, error) {
+	return d.parent(MacsecDeviceIface)
+}
+
+func (d *macsecDevice) Sci() (uint64, error) {
+	return d.GetTProperty(MacsecDeviceIface + ".Sci")
+}
+
+func (d *macsecDevice) IcvLength() (byte, error) {
+	return d.GetYProperty(MacsecDeviceIface + ".IcvLength")
+}
+
+func (d *macsecDevice) CipherSuite() (uint64, error) {
+	return d.GetTProperty(MacsecDeviceIface + ".CipherSuite")
+}
+
+func (d *macsecDevice) Window() (uint32, error) {
+	return d.GetUProperty(MacsecDeviceIface + ".Window")
+}
+
+func (d *macsecDevice) EncodingSa() (byte, error) {
+	return d.GetYProperty(MacsecDeviceIface + ".EncodingSa")
+}
+
+func (d *macsecDevice) Validation() (string, error) {
+	return d.GetSProperty(MacsecDeviceIface + ".Validation")
+}
+
+func (d *macsecDevice) Encrypt() (bool, error) {
+	return d.GetBProperty(MacsecDeviceIface + ".Encrypt")
+}
+
+func (d *macsecDevice) Protect() (bool, error) {
+	return d.GetBProperty(MacsecDeviceIface + ".Protect")
+}
+
+func (d *macsecDevice) IncludeSci() (bool, error) {
+	return d.GetBProperty(MacsecDeviceIface + ".IncludeSci")
+}
+
+func (d *macsecDevice) Es() (bool, error) {
+	return d.GetBProperty(MacsecDeviceIface + ".Es")
+}
+
+func (d *macsecDevice) Scb() (bool, error) {
+	return d.GetBProperty(MacsecDeviceIface + ".Scb")
+}
+
+func (d *macsecDevice) ReplayProtect() (bool, error) {
+	return d.GetBProperty(MacsecDeviceIface + ".ReplayProtect")
+}
@@ -0,0 +1,91 @@
+package netmgr
+
+// MacvlanDeviceIface is the Macvlan Device interface.
+const MacvlanDeviceIface = "org.freedesktop.NetworkManager.Device.Macvlan"
+
+type (
+	// MacvlanDevice represents a MACVLAN device.
+	//
+	// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Macvlan.html for more information.
+	MacvlanDevice interface {
+		Device
+		isMacvlanDevice()
+
+		// Properties
+
+		// HwAddress is the hardware address of the device.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Macvlan.html#gdbus-property-org-freedesktop-NetworkManager-Device-Macvlan.HwAddress for more information.
+		HwAddress() (string, error)
+
+		// Parent is the parent device of the MACVLAN, nil if none.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Macvlan.html#gdbus-property-org-freedesktop-NetworkManager-Device-Macvlan.Parent for more information.
+		Parent() (Device, error)
+
+		// Mode is the MACVLAN mode.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Macvlan.html#gdbus-property-org-freedesktop-NetworkManager-Device-Macvlan.Mode for more information.
+		Mode() (MacvlanMode, error)
+
+		// NoPromisc tells if the device is blocked from going into promiscuous mode.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Macvlan.html#gdbus-property-org-freedesktop-NetworkManager-Device-Macvlan.NoPromisc for more information.
+		NoPromisc() (bool, error)
+
+		// Tap tells if the device is a MACVTAP.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Macvlan.html#gdbus-property-org-freedesktop-NetworkManager-Device-Macvlan.Tab for more information.
+		Tap() (bool, error)
+	}
+
+	macvlanDevice struct {
+		device
+	}
+)
+
+var _ MacvlanDevice = (*macvlanDevice)(nil)
+
+func (*macvlanDevice) isMacvlanDevice() {}
+
+func (d *macvlanDevice) HwAddress() (string, error) {
+	return d.GetSProperty(MacvlanDeviceIface + ".HwAddress")
+}
+
+func (d *macvlanDevice) Parent() (Device, error) {
+	return d.parent(MacvlanDeviceIface)
+}
+
+func (d *macvlanDevice) Mode() (MacvlanMode, error) {
+	mode, err := d.GetSProperty(MacvlanDeviceIface + ".Mode")
+	return MacvlanMode(mode), err
+}
+
+func (d *macvlanDevice) NoPromisc() (bool, error) {
+	return d.GetBProperty(MacvlanDeviceIface + ".NoPromisc")
+}
+
+func (d *macvlanDevice) Tap() (bool, error) {
+	// the property is misspelled in NetworkManager's D-Bus API
+	return d.GetBProperty(MacvlanDeviceIface + ".Tab")
+}
+
+// MacvlanMode is the mode of a MACVLAN device.
+type MacvlanMode string
+
+const (
+	// MacvlanModeVEPA sends all the traffic to the external switch, even between MACVLANs of the same parent.
+	MacvlanModeVEPA MacvlanMode = "vepa"
+
+	// MacvlanModeBridge bridges the traffic between MACVLANs of the same parent.
+	MacvlanModeBridge MacvlanMode = "bridge"
+
+	// MacvlanModePrivate forbids the traffic between MACVLANs of the same parent.
+	MacvlanModePrivate MacvlanMode = "private"
+
+	// MacvlanModePassthru gives the single MACVLAN of the parent full control of it.
+	MacvlanModePassthru MacvlanMode = "passthru"
+
+	// MacvlanModeSource only accepts traffic from allowed source MAC addresses.
+	MacvlanModeSource MacvlanMode = "source"
+)
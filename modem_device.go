@@ -0,0 +1,172 @@
+package netmgr
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/godbus/dbus/v5"
+
+	"github.com/nlepage/go-netmgr/internal/enums"
+)
+
+// ModemDeviceIface is the Modem Device interface.
+const ModemDeviceIface = "org.freedesktop.NetworkManager.Device.Modem"
+
+// ErrNoModemManagerModem is returned when a modem device is not handled by ModemManager.
+var ErrNoModemManagerModem = errors.New("modem is not a ModemManager modem")
+
+type (
+	// ModemDevice represents a modem device.
+	//
+	// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Modem.html for more information.
+	ModemDevice interface {
+		Device
+		isModemDevice()
+
+		// Properties
+
+		// ModemCapabilities are the generic family of access technologies the modem supports.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Modem.html#gdbus-property-org-freedesktop-NetworkManager-Device-Modem.ModemCapabilities for more information.
+		ModemCapabilities() (DeviceModemCapabilities, error)
+
+		// CurrentCapabilities are the generic family of access technologies the modem currently supports without a firmware reload or reinitialization.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Modem.html#gdbus-property-org-freedesktop-NetworkManager-Device-Modem.CurrentCapabilities for more information.
+		CurrentCapabilities() (DeviceModemCapabilities, error)
+
+		// DeviceID is an identifier used by the modem backend (ModemManager) that aims to uniquely identify a device.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Modem.html#gdbus-property-org-freedesktop-NetworkManager-Device-Modem.DeviceId for more information.
+		DeviceID() (string, error)
+
+		// OperatorCode is the MCC and MNC (concatenated) of the network the modem is connected to, empty if not connected or not a 3GPP device.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Modem.html#gdbus-property-org-freedesktop-NetworkManager-Device-Modem.OperatorCode for more information.
+		OperatorCode() (string, error)
+
+		// Apn is the access point name the modem is connected to, empty if not connected.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Modem.html#gdbus-property-org-freedesktop-NetworkManager-Device-Modem.Apn for more information.
+		Apn() (string, error)
+
+		// Helpers
+
+		// ModemManagerModem returns the ModemManager object of the modem, which is its Udi.
+		//
+		// ErrNoModemManagerModem is returned if the modem is not handled by ModemManager.
+		ModemManagerModem() (ModemManagerModem, error)
+	}
+
+	modemDevice struct {
+		device
+	}
+)
+
+var _ ModemDevice = (*modemDevice)(nil)
+
+func (*modemDevice) isModemDevice() {}
+
+func (d *modemDevice) ModemCapabilities() (DeviceModemCapabilities, error) {
+	capabilities, err := d.GetUProperty(ModemDeviceIface + ".ModemCapabilities")
+	return DeviceModemCapabilities(capabilities), err
+}
+
+func (d *modemDevice) CurrentCapabilities() (DeviceModemCapabilities, error) {
+	capabilities, err := d.GetUProperty(ModemDeviceIface + ".CurrentCapabilities")
+	return DeviceModemCapabilities(capabilities), err
+}
+
+func (d *modemDevice) DeviceID() (string, error) {
+	return d.GetSProperty(ModemDeviceIface + ".DeviceId")
+}
+
+func (d *modemDevice) OperatorCode() (string, error) {
+	return d.GetSProperty(ModemDeviceIface + ".OperatorCode")
+}
+
+func (d *modemDevice) Apn() (string, error) {
+	return d.GetSProperty(ModemDeviceIface + ".Apn")
+}
+
+func (d *modemDevice) ModemManagerModem() (ModemManagerModem, error) {
+	udi, err := d.Udi()
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(udi, ModemManagerModemPathPrefix) || !dbus.ObjectPath(udi).IsValid() {
+		return nil, ErrNoModemManagerModem
+	}
+	return NewModemManagerModem(d.Conn, dbus.ObjectPath(udi)), nil
+}
+
+// DeviceModemCapabilities are the generic families of access technologies of a modem.
+//
+// See https://developer.gnome.org/NetworkManager/stable/nm-dbus-types.html#NMDeviceModemCapabilities for more information.
+type DeviceModemCapabilities uint
+
+const (
+	// DeviceModemCapabilityNone means the modem has no capabilities.
+	DeviceModemCapabilityNone DeviceModemCapabilities = 0
+
+	// DeviceModemCapabilityPOTS means the modem supports analog telephone line modem protocols.
+	DeviceModemCapabilityPOTS DeviceModemCapabilities = 1 << (iota - 1)
+
+	// DeviceModemCapabilityCDMAEVDO means the modem supports CDMA/EVDO protocols.
+	DeviceModemCapabilityCDMAEVDO
+
+	// DeviceModemCapabilityGSMUMTS means the modem supports GSM/UMTS protocols.
+	DeviceModemCapabilityGSMUMTS
+
+	// DeviceModemCapabilityLTE means the modem supports LTE protocols.
+	DeviceModemCapabilityLTE
+
+	_
+	_
+
+	// DeviceModemCapability5GNR means the modem supports 5GNR protocols.
+	DeviceModemCapability5GNR
+)
+
+var deviceModemCapabilitiesNames = []enums.Name{
+	{Value: uint(DeviceModemCapabilityPOTS), Name: "NM_DEVICE_MODEM_CAPABILITY_POTS"},
+	{Value: uint(DeviceModemCapabilityCDMAEVDO), Name: "NM_DEVICE_MODEM_CAPABILITY_CDMA_EVDO"},
+	{Value: uint(DeviceModemCapabilityGSMUMTS), Name: "NM_DEVICE_MODEM_CAPABILITY_GSM_UMTS"},
+	{Value: uint(DeviceModemCapabilityLTE), Name: "NM_DEVICE_MODEM_CAPABILITY_LTE"},
+	{Value: uint(DeviceModemCapability5GNR), Name: "NM_DEVICE_MODEM_CAPABILITY_5GNR"},
+}
+
+func (c DeviceModemCapabilities) String() string {
+	return enums.FlagsString(uint(c), "NM_DEVICE_MODEM_CAPABILITY_NONE", deviceModemCapabilitiesNames)
+}
+
+// ParseDeviceModemCapabilities returns the DeviceModemCapabilities corresponding to s, as returned by DeviceModemCapabilities.String.
+func ParseDeviceModemCapabilities(s string) (DeviceModemCapabilities, error) {
+	v, err := enums.ParseFlags("DeviceModemCapabilities", s, "NM_DEVICE_MODEM_CAPABILITY_NONE", deviceModemCapabilitiesNames)
+	return DeviceModemCapabilities(v), err
+}
+
+// Bits decomposes c into its single flags.
+func (c DeviceModemCapabilities) Bits() []DeviceModemCapabilities {
+	bits := enums.Bits(uint(c))
+	capabilities := make([]DeviceModemCapabilities, len(bits))
+	for i, bit := range bits {
+		capabilities[i] = DeviceModemCapabilities(bit)
+	}
+	return capabilities
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (c DeviceModemCapabilities) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (c *DeviceModemCapabilities) UnmarshalText(text []byte) error {
+	v, err := ParseDeviceModemCapabilities(string(text))
+	if err != nil {
+		return err
+	}
+	*c = v
+	return nil
+}
@@ -0,0 +1,75 @@
+package netmgr
+
+import (
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/godbus/dbus/v5/prop"
+)
+
+func TestModemDeviceModemManagerModem(t *testing.T) {
+	conn := privateBus(t)
+
+	const (
+		devicePath = dbus.ObjectPath(NetworkManagerPath + "/Devices/1")
+		modemPath  = dbus.ObjectPath(ModemManagerModemPathPrefix + "0")
+	)
+
+	export(t, conn, BusName, devicePath, map[string]map[string]*prop.Prop{
+		DeviceIface: {
+			"Udi":        {Value: string(modemPath)},
+			"DeviceType": {Value: uint32(DeviceTypeModem)},
+		},
+		ModemDeviceIface: {
+			"ModemCapabilities":   {Value: uint32(DeviceModemCapabilityGSMUMTS | DeviceModemCapabilityLTE)},
+			"CurrentCapabilities": {Value: uint32(DeviceModemCapabilityLTE)},
+			"DeviceId":            {Value: "0123456789"},
+			"OperatorCode":        {Value: "20801"},
+			"Apn":                 {Value: "internet"},
+		},
+	})
+
+	export(t, conn, ModemManagerBusName, modemPath, map[string]map[string]*prop.Prop{
+		ModemManagerModemIface: {
+			"State": {Value: int32(ModemManagerModemStateConnected)},
+			"SignalQuality": {Value: struct {
+				Quality uint32
+				Recent  bool
+			}{75, true}},
+		},
+		ModemManagerModem3gppIface: {
+			"RegistrationState": {Value: uint32(ModemManager3gppRegistrationStateRoaming)},
+			"OperatorName":      {Value: "Operator"},
+		},
+	})
+
+	d := NewDevice(conn, devicePath)
+	md, ok := d.(ModemDevice)
+	if !ok {
+		t.Fatalf("NewDevice returned %T, expected a ModemDevice", d)
+	}
+
+	if capabilities, err := md.ModemCapabilities(); err != nil || capabilities != DeviceModemCapabilityGSMUMTS|DeviceModemCapabilityLTE {
+		t.Errorf("ModemCapabilities() = %s, %v", capabilities, err)
+	}
+	if operatorCode, err := md.OperatorCode(); err != nil || operatorCode != "20801" {
+		t.Errorf("OperatorCode() = %q, %v", operatorCode, err)
+	}
+
+	m, err := md.ModemManagerModem()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Path() != modemPath {
+		t.Errorf("ModemManagerModem().Path() = %s, expected %s", m.Path(), modemPath)
+	}
+	if state, err := m.State(); err != nil || state != ModemManagerModemStateConnected {
+		t.Errorf("State() = %s, %v", state, err)
+	}
+	if quality, recent, err := m.SignalQuality(); err != nil || quality != 75 || !recent {
+		t.Errorf("SignalQuality() = %d, %v, %v", quality, recent, err)
+	}
+	if state, err := m.RegistrationState(); err != nil || state != ModemManager3gppRegistrationStateRoaming {
+		t.Errorf("RegistrationState() = %s, %v", state, err)
+	}
+}
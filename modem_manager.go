@@ -0,0 +1,286 @@
+package netmgr
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/godbus/dbus/v5"
+
+	"github.com/nlepage/go-netmgr/internal/dbusext"
+	"github.com/nlepage/go-netmgr/internal/enums"
+)
+
+const (
+	// ModemManagerBusName is the bus name of ModemManager.
+	ModemManagerBusName = "org.freedesktop.ModemManager1"
+
+	// ModemManagerModemPathPrefix is the prefix of the paths of ModemManager modems.
+	ModemManagerModemPathPrefix = "/org/freedesktop/ModemManager1/Modem/"
+
+	// ModemManagerModemIface is the ModemManager Modem interface.
+	ModemManagerModemIface = "org.freedesktop.ModemManager1.Modem"
+
+	// ModemManagerModem3gppIface is the ModemManager Modem 3GPP interface.
+	ModemManagerModem3gppIface = "org.freedesktop.ModemManager1.Modem.Modem3gpp"
+)
+
+type (
+	// ModemManagerModem represents a modem of ModemManager, only a few properties are exposed.
+	//
+	// See https://www.freedesktop.org/software/ModemManager/api/latest/gdbus-org.freedesktop.ModemManager1.Modem.html for more information.
+	ModemManagerModem interface {
+		dbus.BusObject
+
+		// Properties
+
+		// State is the overall state of the modem.
+		//
+		// See https://www.freedesktop.org/software/ModemManager/api/latest/gdbus-org.freedesktop.ModemManager1.Modem.html#gdbus-property-org-freedesktop-ModemManager1-Modem.State for more information.
+		State() (ModemManagerModemState, error)
+
+		// SignalQuality is the signal quality in percent, and whether it was recently taken.
+		//
+		// See https://www.freedesktop.org/software/ModemManager/api/latest/gdbus-org.freedesktop.ModemManager1.Modem.html#gdbus-property-org-freedesktop-ModemManager1-Modem.SignalQuality for more information.
+		SignalQuality() (quality uint32, recent bool, err error)
+
+		// RegistrationState is the registration state of the modem in a 3GPP network.
+		//
+		// See https://www.freedesktop.org/software/ModemManager/api/latest/gdbus-org.freedesktop.ModemManager1.Modem.Modem3gpp.html#gdbus-property-org-freedesktop-ModemManager1-Modem-Modem3gpp.RegistrationState for more information.
+		RegistrationState() (ModemManager3gppRegistrationState, error)
+
+		// OperatorName is the name of the 3GPP network operator the modem is registered with.
+		//
+		// See https://www.freedesktop.org/software/ModemManager/api/latest/gdbus-org.freedesktop.ModemManager1.Modem.Modem3gpp.html#gdbus-property-org-freedesktop-ModemManager1-Modem-Modem3gpp.OperatorName for more information.
+		OperatorName() (string, error)
+	}
+
+	modemManagerModem struct {
+		dbusext.BusObject
+	}
+)
+
+var _ ModemManagerModem = (*modemManagerModem)(nil)
+
+// NewModemManagerModem returns the ModemManagerModem from conn corresponding to path.
+func NewModemManagerModem(conn *dbus.Conn, path dbus.ObjectPath) ModemManagerModem {
+	return &modemManagerModem{dbusext.NewBusObject(conn, ModemManagerBusName, path)}
+}
+
+func (m *modemManagerModem) State() (ModemManagerModemState, error) {
+	p, err := m.GetProperty(ModemManagerModemIface + ".State")
+	if err != nil {
+		return ModemManagerModemStateUnknown, err
+	}
+	state, ok := p.Value().(int32)
+	if !ok {
+		return ModemManagerModemStateUnknown, fmt.Errorf("unexpected State type %s", p.Signature())
+	}
+	return ModemManagerModemState(state), nil
+}
+
+func (m *modemManagerModem) SignalQuality() (uint32, bool, error) {
+	p, err := m.GetProperty(ModemManagerModemIface + ".SignalQuality")
+	if err != nil {
+		return 0, false, err
+	}
+	v, ok := p.Value().([]interface{})
+	if !ok {
+		return 0, false, fmt.Errorf("unexpected SignalQuality type %s", p.Signature())
+	}
+	var quality uint32
+	var recent bool
+	if err := dbus.Store(v, &quality, &recent); err != nil {
+		return 0, false, err
+	}
+	return quality, recent, nil
+}
+
+func (m *modemManagerModem) RegistrationState() (ModemManager3gppRegistrationState, error) {
+	state, err := m.GetUProperty(ModemManagerModem3gppIface + ".RegistrationState")
+	return ModemManager3gppRegistrationState(state), err
+}
+
+func (m *modemManagerModem) OperatorName() (string, error) {
+	return m.GetSProperty(ModemManagerModem3gppIface + ".OperatorName")
+}
+
+// ModemManagerModemState is the overall state of a ModemManager modem.
+//
+// See https://www.freedesktop.org/software/ModemManager/api/latest/ModemManager-Flags-and-Enumerations.html#MMModemState for more information.
+type ModemManagerModemState int32
+
+const (
+	// ModemManagerModemStateFailed means the modem is unusable.
+	ModemManagerModemStateFailed ModemManagerModemState = iota - 1
+
+	// ModemManagerModemStateUnknown means the state is unknown or not reportable.
+	ModemManagerModemStateUnknown
+
+	// ModemManagerModemStateInitializing means the modem is currently being initialized.
+	ModemManagerModemStateInitializing
+
+	// ModemManagerModemStateLocked means the modem needs to be unlocked.
+	ModemManagerModemStateLocked
+
+	// ModemManagerModemStateDisabled means the modem is not enabled and is powered down.
+	ModemManagerModemStateDisabled
+
+	// ModemManagerModemStateDisabling means the modem is currently transitioning to the disabled state.
+	ModemManagerModemStateDisabling
+
+	// ModemManagerModemStateEnabling means the modem is currently transitioning to the enabled state.
+	ModemManagerModemStateEnabling
+
+	// ModemManagerModemStateEnabled means the modem is enabled and powered on but not registered with a network provider.
+	ModemManagerModemStateEnabled
+
+	// ModemManagerModemStateSearching means the modem is searching for a network provider to register with.
+	ModemManagerModemStateSearching
+
+	// ModemManagerModemStateRegistered means the modem is registered with a network provider.
+	ModemManagerModemStateRegistered
+
+	// ModemManagerModemStateDisconnecting means the modem is disconnecting and deactivating the last active packet data bearer.
+	ModemManagerModemStateDisconnecting
+
+	// ModemManagerModemStateConnecting means the modem is activating and connecting the first packet data bearer.
+	ModemManagerModemStateConnecting
+
+	// ModemManagerModemStateConnected means one or more packet data bearers is active and connected.
+	ModemManagerModemStateConnected
+)
+
+// modemManagerModemStateNames is a map, as enums.Name does not support negative values.
+var modemManagerModemStateNames = map[ModemManagerModemState]string{
+	ModemManagerModemStateFailed:        "MM_MODEM_STATE_FAILED",
+	ModemManagerModemStateUnknown:       "MM_MODEM_STATE_UNKNOWN",
+	ModemManagerModemStateInitializing:  "MM_MODEM_STATE_INITIALIZING",
+	ModemManagerModemStateLocked:        "MM_MODEM_STATE_LOCKED",
+	ModemManagerModemStateDisabled:      "MM_MODEM_STATE_DISABLED",
+	ModemManagerModemStateDisabling:     "MM_MODEM_STATE_DISABLING",
+	ModemManagerModemStateEnabling:      "MM_MODEM_STATE_ENABLING",
+	ModemManagerModemStateEnabled:       "MM_MODEM_STATE_ENABLED",
+	ModemManagerModemStateSearching:     "MM_MODEM_STATE_SEARCHING",
+	ModemManagerModemStateRegistered:    "MM_MODEM_STATE_REGISTERED",
+	ModemManagerModemStateDisconnecting: "MM_MODEM_STATE_DISCONNECTING",
+	ModemManagerModemStateConnecting:    "MM_MODEM_STATE_CONNECTING",
+	ModemManagerModemStateConnected:     "MM_MODEM_STATE_CONNECTED",
+}
+
+func (s ModemManagerModemState) String() string {
+	if name, ok := modemManagerModemStateNames[s]; ok {
+		return name
+	}
+	return strconv.Itoa(int(s))
+}
+
+// ParseModemManagerModemState returns the ModemManagerModemState named s, as returned by ModemManagerModemState.String.
+func ParseModemManagerModemState(s string) (ModemManagerModemState, error) {
+	for state, name := range modemManagerModemStateNames {
+		if name == s {
+			return state, nil
+		}
+	}
+	if v, err := strconv.ParseInt(s, 0, 32); err == nil {
+		return ModemManagerModemState(v), nil
+	}
+	return ModemManagerModemStateUnknown, fmt.Errorf("invalid ModemManagerModemState: %q", s)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s ModemManagerModemState) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *ModemManagerModemState) UnmarshalText(text []byte) error {
+	v, err := ParseModemManagerModemState(string(text))
+	if err != nil {
+		return err
+	}
+	*s = v
+	return nil
+}
+
+// ModemManager3gppRegistrationState is the registration state of a ModemManager modem in a 3GPP network.
+//
+// See https://www.freedesktop.org/software/ModemManager/api/latest/ModemManager-Flags-and-Enumerations.html#MMModem3gppRegistrationState for more information.
+type ModemManager3gppRegistrationState uint
+
+const (
+	// ModemManager3gppRegistrationStateIdle means not registered, not searching for a new operator to register.
+	ModemManager3gppRegistrationStateIdle ModemManager3gppRegistrationState = iota
+
+	// ModemManager3gppRegistrationStateHome means registered on the home network.
+	ModemManager3gppRegistrationStateHome
+
+	// ModemManager3gppRegistrationStateSearching means not registered, searching for a new operator to register.
+	ModemManager3gppRegistrationStateSearching
+
+	// ModemManager3gppRegistrationStateDenied means registration was denied.
+	ModemManager3gppRegistrationStateDenied
+
+	// ModemManager3gppRegistrationStateUnknown means the registration state is unknown.
+	ModemManager3gppRegistrationStateUnknown
+
+	// ModemManager3gppRegistrationStateRoaming means registered on a roaming network.
+	ModemManager3gppRegistrationStateRoaming
+
+	// ModemManager3gppRegistrationStateHomeSMSOnly means registered for SMS only on the home network.
+	ModemManager3gppRegistrationStateHomeSMSOnly
+
+	// ModemManager3gppRegistrationStateRoamingSMSOnly means registered for SMS only on a roaming network.
+	ModemManager3gppRegistrationStateRoamingSMSOnly
+
+	// ModemManager3gppRegistrationStateEmergencyOnly means emergency services only.
+	ModemManager3gppRegistrationStateEmergencyOnly
+
+	// ModemManager3gppRegistrationStateHomeCSFBNotPreferred means registered on the home network, CSFB not preferred.
+	ModemManager3gppRegistrationStateHomeCSFBNotPreferred
+
+	// ModemManager3gppRegistrationStateRoamingCSFBNotPreferred means registered on a roaming network, CSFB not preferred.
+	ModemManager3gppRegistrationStateRoamingCSFBNotPreferred
+
+	// ModemManager3gppRegistrationStateAttachedRLOS means attached for access to Restricted Local Operator Services.
+	ModemManager3gppRegistrationStateAttachedRLOS
+)
+
+var modemManager3gppRegistrationStateNames = []enums.Name{
+	{Value: uint(ModemManager3gppRegistrationStateIdle), Name: "MM_MODEM_3GPP_REGISTRATION_STATE_IDLE"},
+	{Value: uint(ModemManager3gppRegistrationStateHome), Name: "MM_MODEM_3GPP_REGISTRATION_STATE_HOME"},
+	{Value: uint(ModemManager3gppRegistrationStateSearching), Name: "MM_MODEM_3GPP_REGISTRATION_STATE_SEARCHING"},
+	{Value: uint(ModemManager3gppRegistrationStateDenied), Name: "MM_MODEM_3GPP_REGISTRATION_STATE_DENIED"},
+	{Value: uint(ModemManager3gppRegistrationStateUnknown), Name: "MM_MODEM_3GPP_REGISTRATION_STATE_UNKNOWN"},
+	{Value: uint(ModemManager3gppRegistrationStateRoaming), Name: "MM_MODEM_3GPP_REGISTRATION_STATE_ROAMING"},
+	{Value: uint(ModemManager3gppRegistrationStateHomeSMSOnly), Name: "MM_MODEM_3GPP_REGISTRATION_STATE_HOME_SMS_ONLY"},
+	{Value: uint(ModemManager3gppRegistrationStateRoamingSMSOnly), Name: "MM_MODEM_3GPP_REGISTRATION_STATE_ROAMING_SMS_ONLY"},
+	{Value: uint(ModemManager3gppRegistrationStateEmergencyOnly), Name: "MM_MODEM_3GPP_REGISTRATION_STATE_EMERGENCY_ONLY"},
+	{Value: uint(ModemManager3gppRegistrationStateHomeCSFBNotPreferred), Name: "MM_MODEM_3GPP_REGISTRATION_STATE_HOME_CSFB_NOT_PREFERRED"},
+	{Value: uint(ModemManager3gppRegistrationStateRoamingCSFBNotPreferred), Name: "MM_MODEM_3GPP_REGISTRATION_STATE_ROAMING_CSFB_NOT_PREFERRED"},
+	{Value: uint(ModemManager3gppRegistrationStateAttachedRLOS), Name: "MM_MODEM_3GPP_REGISTRATION_STATE_ATTACHED_RLOS"},
+}
+
+func (s ModemManager3gppRegistrationState) String() string {
+	return enums.String(uint(s), modemManager3gppRegistrationStateNames)
+}
+
+// ParseModemManager3gppRegistrationState returns the ModemManager3gppRegistrationState named s, as returned by ModemManager3gppRegistrationState.String.
+func ParseModemManager3gppRegistrationState(s string) (ModemManager3gppRegistrationState, error) {
+	v, err := enums.Parse("ModemManager3gppRegistrationState", s, modemManager3gppRegistrationStateNames)
+	return ModemManager3gppRegistrationState(v), err
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s ModemManager3gppRegistrationState) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *ModemManager3gppRegistrationState) UnmarshalText(text []byte) error {
+	v, err := ParseModemManager3gppRegistrationState(string(text))
+	if err != nil {
+		return err
+	}
+	*s = v
+	return nil
+}
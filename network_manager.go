@@ -1,6 +1,8 @@
 package netmgr
 
 import (
+	"context"
+
 	"github.com/godbus/dbus/v5"
 
 	"github.com/nlepage/go-netmgr/internal/dbusext"
@@ -25,7 +27,7 @@ type (
 
 		// Methods
 
-		Reload(flags uint) error
+		Reload(flags ReloadFlags) error
 		GetDevices() ([]Device, error)
 		GetAllDevices() ([]Device, error)
 		GetDeviceByIPIface(iface string) (Device, error)
@@ -35,18 +37,23 @@ type (
 		DeactivateConnection(activeConnection interface{}) error
 		Sleep(sleep bool) error
 		Enable(enable bool) error
-		GetPermissions() (map[string]string, error)
-		SetLogging(level string, domains string) error
-		GetLogging() (string, string, error)
+		GetPermissions() (Permissions, error)
+		SetLogging(level LogLevel, domains LogDomains) error
+		GetLogging() (LogLevel, LogDomains, error)
 		CheckConnectivity() (ConnectivityState, error)
 		GetState() (StateEnum, error)
 		CheckpointCreate(devices []interface{}, rollbackTimeout uint, flags CheckpointCreateFlags) (Checkpoint, error)
 		CheckpointDestroy(checkpoint interface{}) error
-		CheckpointRollback(checkpoint interface{}) (map[dbus.ObjectPath]RollbackResult, error)
+		CheckpointRollback(checkpoint interface{}) (RollbackResults, error)
 		CheckpointAdjustRollbackTimeout(checkpoint interface{}, rollbackTimeout uint) error
 
 		// FIXME Signals
 		StateChanged(ch chan<- StateEnum) error
+		CheckPermissions(ch chan<- struct{}) error
+
+		// Properties changes
+
+		CheckpointsChanged(ch chan<- []Checkpoint) error
 
 		// Properties
 
@@ -74,8 +81,22 @@ type (
 		ConnectivityCheckEnabled() (bool, error)
 		SetConnectivityCheckEnabled(bool) error
 		ConnectivityCheckURI() (string, error)
-		GlobalDNSConfiguration() (map[string]interface{}, error)
-		SetGlobalDNSConfiguration(map[string]interface{}) error
+		GlobalDNSConfiguration() (GlobalDNSConfig, error)
+		SetGlobalDNSConfiguration(GlobalDNSConfig) error
+
+		// Helpers
+
+		ActivateConnectionAndWait(ctx context.Context, connection interface{}, device interface{}, specificObject interface{}) (ConnectionActive, error)
+		WaitCheckpointRemoved(ctx context.Context, checkpoint interface{}) error
+		WithLogging(ctx context.Context, domains LogDomains, fn func(ctx context.Context) error) error
+		WatchPermissions(ctx context.Context) (*PermissionsView, error)
+		ConnectWifi(ctx context.Context, ssid string, options WifiConnectOptions) (SettingsConnection, ConnectionActive, error)
+		StartHotspot(ctx context.Context, ssid, passphrase string, options HotspotOptions) (*Hotspot, error)
+		ConnectWifiP2P(ctx context.Context, device WifiP2PDevice, peer WifiP2PPeer) (SettingsConnection, ConnectionActive, error)
+		HasCapability(capability Capability) (bool, error)
+		LLDPNeighbors() ([]DeviceLLDPNeighbors, error)
+		AddOVSBridge(config OVSBridgeConfig, options map[string]interface{}) ([]SettingsConnection, error)
+		CheckpointTransaction(ctx context.Context, devices []interface{}, options CheckpointTransactionOptions, fn func(ctx context.Context) error) (RollbackResults, error)
 	}
 
 	networkManager struct {
@@ -1,6 +1,10 @@
 package netmgr
 
-import "strconv"
+import (
+	"errors"
+
+	"github.com/nlepage/go-netmgr/internal/enums"
+)
 
 // Capability names the numbers in the Capabilities property.
 //
@@ -15,7 +19,35 @@ const (
 	CapabilityOVS
 )
 
-// FIXME Capability.String()
+var capabilityNames = []enums.Name{
+	{Value: uint(CapabilityTeam), Name: "NM_CAPABILITY_TEAM"},
+	{Value: uint(CapabilityOVS), Name: "NM_CAPABILITY_OVS"},
+}
+
+func (c Capability) String() string {
+	return enums.String(uint(c), capabilityNames)
+}
+
+// ParseCapability returns the Capability named s, as returned by Capability.String.
+func ParseCapability(s string) (Capability, error) {
+	v, err := enums.Parse("Capability", s, capabilityNames)
+	return Capability(v), err
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (c Capability) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (c *Capability) UnmarshalText(text []byte) error {
+	v, err := ParseCapability(string(text))
+	if err != nil {
+		return err
+	}
+	*c = v
+	return nil
+}
 
 // StateEnum values indicate the current overall networking state.
 //
@@ -48,7 +80,41 @@ const (
 	StateConnectedGlobal
 )
 
-// FIXME StateEnum.String()
+var stateEnumNames = []enums.Name{
+	{Value: uint(StateUnknown), Name: "NM_STATE_UNKNOWN"},
+	{Value: uint(StateAsleep), Name: "NM_STATE_ASLEEP"},
+	{Value: uint(StateDisconnected), Name: "NM_STATE_DISCONNECTED"},
+	{Value: uint(StateDisconnecting), Name: "NM_STATE_DISCONNECTING"},
+	{Value: uint(StateConnecting), Name: "NM_STATE_CONNECTING"},
+	{Value: uint(StateConnectedLocal), Name: "NM_STATE_CONNECTED_LOCAL"},
+	{Value: uint(StateConnectedSite), Name: "NM_STATE_CONNECTED_SITE"},
+	{Value: uint(StateConnectedGlobal), Name: "NM_STATE_CONNECTED_GLOBAL"},
+}
+
+func (s StateEnum) String() string {
+	return enums.String(uint(s), stateEnumNames)
+}
+
+// ParseStateEnum returns the StateEnum named s, as returned by StateEnum.String.
+func ParseStateEnum(s string) (StateEnum, error) {
+	v, err := enums.Parse("StateEnum", s, stateEnumNames)
+	return StateEnum(v), err
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s StateEnum) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *StateEnum) UnmarshalText(text []byte) error {
+	v, err := ParseStateEnum(string(text))
+	if err != nil {
+		return err
+	}
+	*s = v
+	return nil
+}
 
 // ConnectivityState values indicate the connectivity state.
 //
@@ -72,20 +138,37 @@ const (
 	ConnectivityFull
 )
 
+var connectivityStateNames = []enums.Name{
+	{Value: uint(ConnectivityUnknown), Name: "NM_CONNECTIVITY_UNKNOWN"},
+	{Value: uint(ConnectivityNone), Name: "NM_CONNECTIVITY_NONE"},
+	{Value: uint(ConnectivityPortal), Name: "NM_CONNECTIVITY_PORTAL"},
+	{Value: uint(ConnectivityLimited), Name: "NM_CONNECTIVITY_LIMITED"},
+	{Value: uint(ConnectivityFull), Name: "NM_CONNECTIVITY_FULL"},
+}
+
 func (cs ConnectivityState) String() string {
-	switch cs {
-	case ConnectivityUnknown:
-		return "NM_CONNECTIVITY_UNKNOWN"
-	case ConnectivityNone:
-		return "NM_CONNECTIVITY_NONE"
-	case ConnectivityPortal:
-		return "NM_CONNECTIVITY_PORTAL"
-	case ConnectivityLimited:
-		return "NM_CONNECTIVITY_LIMITED"
-	case ConnectivityFull:
-		return "NM_CONNECTIVITY_FULL"
+	return enums.String(uint(cs), connectivityStateNames)
+}
+
+// ParseConnectivityState returns the ConnectivityState named s, as returned by ConnectivityState.String.
+func ParseConnectivityState(s string) (ConnectivityState, error) {
+	v, err := enums.Parse("ConnectivityState", s, connectivityStateNames)
+	return ConnectivityState(v), err
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (cs ConnectivityState) MarshalText() ([]byte, error) {
+	return []byte(cs.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (cs *ConnectivityState) UnmarshalText(text []byte) error {
+	v, err := ParseConnectivityState(string(text))
+	if err != nil {
+		return err
 	}
-	return strconv.Itoa(int(cs))
+	*cs = v
+	return nil
 }
 
 // CheckpointCreateFlags are the flags for CheckpointCreate call.
@@ -98,7 +181,7 @@ const (
 	CheckpointCreateFlagNone CheckpointCreateFlags = 0
 
 	// CheckpointCreateFlagDestroyAll means when creating a new checkpoint, destroy all existing ones.
-	CheckpointCreateFlagDestroyAll = 1 << (iota - 1)
+	CheckpointCreateFlagDestroyAll CheckpointCreateFlags = 1 << (iota - 1)
 
 	// CheckpointCreateFlagDeleteNewConnections means upon rollback, delete any new connection added after the checkpoint.
 	CheckpointCreateFlagDeleteNewConnections
@@ -110,7 +193,47 @@ const (
 	CheckpointCreateFlagAllowOverlapping
 )
 
-// FIXME CheckpointCreateFlags.String()
+var checkpointCreateFlagsNames = []enums.Name{
+	{Value: uint(CheckpointCreateFlagDestroyAll), Name: "NM_CHECKPOINT_CREATE_FLAG_DESTROY_ALL"},
+	{Value: uint(CheckpointCreateFlagDeleteNewConnections), Name: "NM_CHECKPOINT_CREATE_FLAG_DELETE_NEW_CONNECTIONS"},
+	{Value: uint(CheckpointCreateFlagDisconnectNewDevices), Name: "NM_CHECKPOINT_CREATE_FLAG_DISCONNECT_NEW_DEVICES"},
+	{Value: uint(CheckpointCreateFlagAllowOverlapping), Name: "NM_CHECKPOINT_CREATE_FLAG_ALLOW_OVERLAPPING"},
+}
+
+func (f CheckpointCreateFlags) String() string {
+	return enums.FlagsString(uint(f), "NM_CHECKPOINT_CREATE_FLAG_NONE", checkpointCreateFlagsNames)
+}
+
+// ParseCheckpointCreateFlags returns the CheckpointCreateFlags corresponding to s, as returned by CheckpointCreateFlags.String.
+func ParseCheckpointCreateFlags(s string) (CheckpointCreateFlags, error) {
+	v, err := enums.ParseFlags("CheckpointCreateFlags", s, "NM_CHECKPOINT_CREATE_FLAG_NONE", checkpointCreateFlagsNames)
+	return CheckpointCreateFlags(v), err
+}
+
+// Bits decomposes f into its single flags.
+func (f CheckpointCreateFlags) Bits() []CheckpointCreateFlags {
+	bits := enums.Bits(uint(f))
+	flags := make([]CheckpointCreateFlags, len(bits))
+	for i, bit := range bits {
+		flags[i] = CheckpointCreateFlags(bit)
+	}
+	return flags
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (f CheckpointCreateFlags) MarshalText() ([]byte, error) {
+	return []byte(f.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (f *CheckpointCreateFlags) UnmarshalText(text []byte) error {
+	v, err := ParseCheckpointCreateFlags(string(text))
+	if err != nil {
+		return err
+	}
+	*f = v
+	return nil
+}
 
 // RollbackResult is the result of a checkpoint Rollback() operation for a specific device.
 //
@@ -131,4 +254,131 @@ const (
 	RollbackResultErrFailed
 )
 
-// FIXME RollbackResult.String()
+var rollbackResultNames = []enums.Name{
+	{Value: uint(RollbackResultOK), Name: "NM_ROLLBACK_RESULT_OK"},
+	{Value: uint(RollbackResultErrNoDevice), Name: "NM_ROLLBACK_RESULT_ERR_NO_DEVICE"},
+	{Value: uint(RollbackResultErrDeviceUnmanaged), Name: "NM_ROLLBACK_RESULT_ERR_DEVICE_UNMANAGED"},
+	{Value: uint(RollbackResultErrFailed), Name: "NM_ROLLBACK_RESULT_ERR_FAILED"},
+}
+
+func (r RollbackResult) String() string {
+	return enums.String(uint(r), rollbackResultNames)
+}
+
+// ParseRollbackResult returns the RollbackResult named s, as returned by RollbackResult.String.
+func ParseRollbackResult(s string) (RollbackResult, error) {
+	v, err := enums.Parse("RollbackResult", s, rollbackResultNames)
+	return RollbackResult(v), err
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (r RollbackResult) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (r *RollbackResult) UnmarshalText(text []byte) error {
+	v, err := ParseRollbackResult(string(text))
+	if err != nil {
+		return err
+	}
+	*r = v
+	return nil
+}
+
+// ReloadFlags are the flags for Reload call, telling what to reload.
+//
+// See https://developer.gnome.org/NetworkManager/stable/nm-dbus-types.html#NMManagerReloadFlags for more information.
+type ReloadFlags uint
+
+const (
+	// ReloadFlagNone reloads everything, as if all the flags were given.
+	ReloadFlagNone ReloadFlags = 0
+
+	// ReloadFlagConf reloads the NetworkManager.conf configuration from disk.
+	ReloadFlagConf ReloadFlags = 1 << (iota - 1)
+
+	// ReloadFlagDNSRc updates the DNS configuration, which mostly results in writing /etc/resolv.conf anew.
+	ReloadFlagDNSRc
+
+	// ReloadFlagDNSFull means to restart the DNS plugin, flushing its caches.
+	ReloadFlagDNSFull
+)
+
+// ReloadFlagsAll are all the flags known to this package.
+const ReloadFlagsAll = ReloadFlagConf | ReloadFlagDNSRc | ReloadFlagDNSFull
+
+// ErrNothingToReload is returned by ReloadFlags.Without when all the flags are unset.
+var ErrNothingToReload = errors.New("no reload flags left, ReloadFlagNone would reload everything")
+
+var reloadFlagsNames = []enums.Name{
+	{Value: uint(ReloadFlagConf), Name: "NM_MANAGER_RELOAD_FLAG_CONF"},
+	{Value: uint(ReloadFlagDNSRc), Name: "NM_MANAGER_RELOAD_FLAG_DNS_RC"},
+	{Value: uint(ReloadFlagDNSFull), Name: "NM_MANAGER_RELOAD_FLAG_DNS_FULL"},
+}
+
+func (f ReloadFlags) String() string {
+	return enums.FlagsString(uint(f), "NM_MANAGER_RELOAD_FLAG_NONE", reloadFlagsNames)
+}
+
+// ParseReloadFlags returns the ReloadFlags corresponding to s, as returned by ReloadFlags.String.
+func ParseReloadFlags(s string) (ReloadFlags, error) {
+	v, err := enums.ParseFlags("ReloadFlags", s, "NM_MANAGER_RELOAD_FLAG_NONE", reloadFlagsNames)
+	return ReloadFlags(v), err
+}
+
+// Bits decomposes f into its single flags.
+func (f ReloadFlags) Bits() []ReloadFlags {
+	bits := enums.Bits(uint(f))
+	flags := make([]ReloadFlags, len(bits))
+	for i, bit := range bits {
+		flags[i] = ReloadFlags(bit)
+	}
+	return flags
+}
+
+// Has tells if all of flags are set in f.
+//
+// As ReloadFlagNone reloads everything, f.Has(flags) is always true if f is ReloadFlagNone.
+func (f ReloadFlags) Has(flags ReloadFlags) bool {
+	return f == ReloadFlagNone || f&flags == flags
+}
+
+// With returns f with flags set.
+//
+// If f is ReloadFlagNone, it already reloads everything, so it is returned unchanged.
+func (f ReloadFlags) With(flags ReloadFlags) ReloadFlags {
+	if f == ReloadFlagNone {
+		return f
+	}
+	return f | flags
+}
+
+// Without returns f with flags unset.
+//
+// If f is ReloadFlagNone, it is taken as ReloadFlagsAll, so that the result still reloads everything but flags.
+// ErrNothingToReload is returned if no flag is left, as the result would be ReloadFlagNone, which reloads everything.
+func (f ReloadFlags) Without(flags ReloadFlags) (ReloadFlags, error) {
+	if f == ReloadFlagNone {
+		f = ReloadFlagsAll
+	}
+	if f &^= flags; f == ReloadFlagNone {
+		return ReloadFlagNone, ErrNothingToReload
+	}
+	return f, nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (f ReloadFlags) MarshalText() ([]byte, error) {
+	return []byte(f.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (f *ReloadFlags) UnmarshalText(text []byte) error {
+	v, err := ParseReloadFlags(string(text))
+	if err != nil {
+		return err
+	}
+	*f = v
+	return nil
+}
@@ -1,18 +1,28 @@
 package netmgr
 
 import (
+	"context"
+
 	"github.com/godbus/dbus/v5"
 	"github.com/nlepage/go-netmgr/internal/dbusext"
 )
 
-func (nm *networkManager) Reload(flags uint) error {
-	return nm.CallAndStore(NetworkManagerInterface+".Reload", dbusext.Args{flags}, nil)
+func (nm *networkManager) Reload(flags ReloadFlags) error {
+	// Reload and its flags were added in NetworkManager 1.22, see the "Since" notes of Reload and NMManagerReloadFlags
+	// at https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.html#gdbus-method-org-freedesktop-NetworkManager.Reload
+	if err := nm.requireVersion("Reload", "1.22"); err != nil {
+		return err
+	}
+	return nm.CallAndStore(NetworkManagerInterface+".Reload", dbusext.Args{uint32(flags)}, nil)
 }
 
 // Reload NetworkManager's configuration and perform certain updates, like flushing a cache or rewriting external state to disk.
 //
+// ReloadFlagNone reloads everything, for example ReloadFlagDNSRc only rewrites the DNS configuration.
+// A *VersionError is returned if the running NetworkManager does not support Reload.
+//
 // See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.html#gdbus-method-org-freedesktop-NetworkManager.Reload for more information.
-func Reload(flags uint) error {
+func Reload(flags ReloadFlags) error {
 	nm, err := System()
 	if err != nil {
 		return err
@@ -110,6 +120,29 @@ func ActivateConnection(connection interface{}, device interface{}, specificObje
 	return nm.ActivateConnection(connection, device, specificObject)
 }
 
+func (nm *networkManager) ActivateConnectionAndWait(ctx context.Context, connection interface{}, device interface{}, specificObject interface{}) (ConnectionActive, error) {
+	connectionActive, err := nm.ActivateConnection(connection, device, specificObject)
+	if err != nil {
+		return nil, err
+	}
+	if err := connectionActive.WaitActivated(ctx); err != nil {
+		return connectionActive, err
+	}
+	return connectionActive, nil
+}
+
+// ActivateConnectionAndWait activates a connection using the supplied device, and waits until the activation finishes.
+//
+// If the activation fails, an *ActivationError is returned with the reasons of the failure.
+// If ctx is done before the activation finishes, ctx.Err() is returned and the activation is left in progress.
+func ActivateConnectionAndWait(ctx context.Context, connection interface{}, device interface{}, specificObject interface{}) (ConnectionActive, error) {
+	nm, err := System()
+	if err != nil {
+		return nil, err
+	}
+	return nm.ActivateConnectionAndWait(ctx, connection, device, specificObject)
+}
+
 func (nm *networkManager) AddAndActivateConnection(connection SettingsConnectionInput, device interface{}, specificObject interface{}) (SettingsConnection, ConnectionActive, error) {
 	devicePath, err := dbusext.ObjectPath(device)
 	if err != nil {
@@ -150,6 +183,20 @@ func AddAndActivateConnection(connection SettingsConnectionInput, device interfa
 	return nm.AddAndActivateConnection(connection, device, specificObject)
 }
 
+// Persist is the value of the "persist" option of AddAndActivateConnection2.
+type Persist string
+
+const (
+	// PersistDisk means the profile is saved to disk.
+	PersistDisk Persist = "disk"
+
+	// PersistMemory means the profile is only kept in memory.
+	PersistMemory Persist = "memory"
+
+	// PersistVolatile means the profile is only kept in memory, and deleted when it is deactivated.
+	PersistVolatile Persist = "volatile"
+)
+
 func (nm *networkManager) AddAndActivateConnection2(connection SettingsConnectionInput, device interface{}, specificObject interface{}, options map[string]interface{}) (SettingsConnection, ConnectionActive, error) {
 	devicePath, err := dbusext.ObjectPath(device)
 	if err != nil {
@@ -244,8 +291,8 @@ func Enable(enable bool) error {
 	return nm.Enable(enable)
 }
 
-func (nm *networkManager) GetPermissions() (map[string]string, error) {
-	var permissions = make(map[string]string)
+func (nm *networkManager) GetPermissions() (Permissions, error) {
+	var permissions = make(Permissions)
 	if err := nm.CallAndStore(NetworkManagerInterface+".GetPermissions", nil, dbusext.Args{permissions}); err != nil {
 		return nil, err
 	}
@@ -255,7 +302,7 @@ func (nm *networkManager) GetPermissions() (map[string]string, error) {
 // GetPermissions returns the permissions a caller has for various authenticated operations that NetworkManager provides, like Enable/Disable networking, changing Wi-Fi, WWAN, and WiMAX state, etc.
 //
 // See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.html#gdbus-method-org-freedesktop-NetworkManager.GetPermissions for more information.
-func GetPermissions() (map[string]string, error) {
+func GetPermissions() (Permissions, error) {
 	nm, err := System()
 	if err != nil {
 		return nil, err
@@ -263,14 +310,16 @@ func GetPermissions() (map[string]string, error) {
 	return nm.GetPermissions()
 }
 
-func (nm *networkManager) SetLogging(level string, domains string) error {
-	return nm.CallAndStore(NetworkManagerInterface+".SetLogging", dbusext.Args{level, domains}, nil)
+func (nm *networkManager) SetLogging(level LogLevel, domains LogDomains) error {
+	return nm.CallAndStore(NetworkManagerInterface+".SetLogging", dbusext.Args{string(level), domains.String()}, nil)
 }
 
 // SetLogging sets logging verbosity and which operations are logged.
 //
+// If domains is empty, only the level is changed, otherwise only domains are logged.
+//
 // See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.html#gdbus-method-org-freedesktop-NetworkManager.SetLogging for more information.
-func SetLogging(level string, domains string) error {
+func SetLogging(level LogLevel, domains LogDomains) error {
 	nm, err := System()
 	if err != nil {
 		return err
@@ -278,22 +327,30 @@ func SetLogging(level string, domains string) error {
 	return nm.SetLogging(level, domains)
 }
 
-func (nm *networkManager) GetLogging() (string, string, error) {
+func (nm *networkManager) GetLogging() (LogLevel, LogDomains, error) {
 	var level string
 	var domains string
 	if err := nm.CallAndStore(NetworkManagerInterface+".GetLogging", nil, dbusext.Args{&level, &domains}); err != nil {
-		return "", "", err
+		return "", nil, err
+	}
+	logLevel, err := ParseLogLevel(level)
+	if err != nil {
+		return "", nil, err
 	}
-	return level, domains, nil
+	logDomains, err := ParseLogDomains(domains)
+	if err != nil {
+		return "", nil, err
+	}
+	return logLevel, logDomains, nil
 }
 
 // GetLogging gets current logging verbosity level and operations domains.
 //
 // See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.html#gdbus-method-org-freedesktop-NetworkManager.GetLogging for more information.
-func GetLogging() (string, string, error) {
+func GetLogging() (LogLevel, LogDomains, error) {
 	nm, err := System()
 	if err != nil {
-		return "", "", err
+		return "", nil, err
 	}
 	return nm.GetLogging()
 }
@@ -384,7 +441,7 @@ func CheckpointDestroy(checkpoint interface{}) error {
 	return nm.CheckpointDestroy(checkpoint)
 }
 
-func (nm *networkManager) CheckpointRollback(checkpoint interface{}) (map[dbus.ObjectPath]RollbackResult, error) {
+func (nm *networkManager) CheckpointRollback(checkpoint interface{}) (RollbackResults, error) {
 	checkpointPath, err := dbusext.ObjectPath(checkpoint)
 	if err != nil {
 		return nil, err
@@ -393,13 +450,15 @@ func (nm *networkManager) CheckpointRollback(checkpoint interface{}) (map[dbus.O
 	if err := nm.CallAndStore(NetworkManagerInterface+".CheckpointRollback", dbusext.Args{checkpointPath}, dbusext.Args{result}); err != nil {
 		return nil, err
 	}
-	return result, nil
+	return newRollbackResults(nm.Conn, result), nil
 }
 
 // CheckpointRollback rollback a checkpoint before the timeout is reached.
 //
+// The results are keyed by device object path, see RollbackResults.
+//
 // See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.html#gdbus-method-org-freedesktop-NetworkManager.CheckpointRollback for more information.
-func CheckpointRollback(checkpoint interface{}) (map[dbus.ObjectPath]RollbackResult, error) {
+func CheckpointRollback(checkpoint interface{}) (RollbackResults, error) {
 	nm, err := System()
 	if err != nil {
 		return nil, err
@@ -303,6 +303,28 @@ func Capabilities() ([]Capability, error) {
 	return nm.Capabilities()
 }
 
+func (nm *networkManager) HasCapability(capability Capability) (bool, error) {
+	capabilities, err := nm.Capabilities()
+	if err != nil {
+		return false, err
+	}
+	for _, c := range capabilities {
+		if c == capability {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// HasCapability tells if capability is in the current set of capabilities.
+func HasCapability(capability Capability) (bool, error) {
+	nm, err := System()
+	if err != nil {
+		return false, err
+	}
+	return nm.HasCapability(capability)
+}
+
 func (nm *networkManager) State() (StateEnum, error) {
 	state, err := nm.GetUProperty(NetworkManagerInterface + ".State")
 	return StateEnum(state), err
@@ -395,29 +417,37 @@ func ConnectivityCheckURI() (string, error) {
 	return nm.ConnectivityCheckURI()
 }
 
-func (nm *networkManager) GlobalDNSConfiguration() (map[string]interface{}, error) {
-	return nm.GetASVProperty(NetworkManagerInterface + ".GlobalDnsConfiguration")
+func (nm *networkManager) GlobalDNSConfiguration() (GlobalDNSConfig, error) {
+	p, err := nm.GetProperty(NetworkManagerInterface + ".GlobalDnsConfiguration")
+	if err != nil {
+		return GlobalDNSConfig{}, err
+	}
+	m, _ := p.Value().(map[string]dbus.Variant)
+	return DecodeGlobalDNSConfig(m), nil
 }
 
-// GlobalDNSConfiguration is the dictionary of global DNS settings where the key is one of "searches", "options" and "domains".
+// GlobalDNSConfiguration is the global DNS configuration, made of search domains, options and per-domain name servers.
 //
 // See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.html#gdbus-property-org-freedesktop-NetworkManager.GlobalDnsConfiguration for more information.
-func GlobalDNSConfiguration() (map[string]interface{}, error) {
+func GlobalDNSConfiguration() (GlobalDNSConfig, error) {
 	nm, err := System()
 	if err != nil {
-		return nil, err
+		return GlobalDNSConfig{}, err
 	}
 	return nm.GlobalDNSConfiguration()
 }
 
-func (nm *networkManager) SetGlobalDNSConfiguration(value map[string]interface{}) error {
-	return nm.SetProperty(NetworkManagerInterface+".GlobalDnsConfiguration", dbus.MakeVariant(value))
+func (nm *networkManager) SetGlobalDNSConfiguration(value GlobalDNSConfig) error {
+	if err := value.Validate(); err != nil {
+		return err
+	}
+	return nm.SetProperty(NetworkManagerInterface+".GlobalDnsConfiguration", dbus.MakeVariant(value.Encode()))
 }
 
-// SetGlobalDNSConfiguration sets the dictionary of global DNS settings where the key is one of "searches", "options" and "domains".
+// SetGlobalDNSConfiguration sets the global DNS configuration, after validating it.
 //
 // See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.html#gdbus-property-org-freedesktop-NetworkManager.GlobalDnsConfiguration for more information.
-func SetGlobalDNSConfiguration(value map[string]interface{}) error {
+func SetGlobalDNSConfiguration(value GlobalDNSConfig) error {
 	nm, err := System()
 	if err != nil {
 		return err
@@ -1,5 +1,14 @@
 package netmgr
 
+import (
+	"context"
+	"reflect"
+
+	"github.com/godbus/dbus/v5"
+
+	"github.com/nlepage/go-netmgr/internal/dbusext"
+)
+
 func (nm *networkManager) StateChanged(state chan<- StateEnum) error {
 	return nm.USignal(NetworkManagerInterface, "StateChanged", state, nil)
 }
@@ -11,3 +20,83 @@ func StateChanged(state chan<- StateEnum) error {
 	}
 	return nm.StateChanged(state)
 }
+
+func (nm *networkManager) CheckPermissions(ch chan<- struct{}) error {
+	return nm.BodySignal(NetworkManagerInterface, "CheckPermissions", ch, func([]interface{}) struct{} {
+		return struct{}{}
+	})
+}
+
+// CheckPermissions is emitted when system authorization details change, indicating that clients may wish to recheck permissions with GetPermissions.
+//
+// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.html#gdbus-signal-org-freedesktop-NetworkManager.CheckPermissions for more information.
+func CheckPermissions(ch chan<- struct{}) error {
+	nm, err := System()
+	if err != nil {
+		return err
+	}
+	return nm.CheckPermissions(ch)
+}
+
+func (nm *networkManager) CheckpointsChanged(ch chan<- []Checkpoint) error {
+	return nm.PropertyChanged(NetworkManagerInterface, "Checkpoints", reflect.TypeOf([]dbus.ObjectPath(nil)), ch, func(paths []dbus.ObjectPath) []Checkpoint {
+		return NewCheckpoints(nm.Conn, paths)
+	})
+}
+
+// CheckpointsChanged sends the list of active checkpoints to ch each time it changes.
+//
+// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.html#gdbus-property-org-freedesktop-NetworkManager.Checkpoints for more information.
+func CheckpointsChanged(ch chan<- []Checkpoint) error {
+	nm, err := System()
+	if err != nil {
+		return err
+	}
+	return nm.CheckpointsChanged(ch)
+}
+
+func (nm *networkManager) WaitCheckpointRemoved(ctx context.Context, checkpoint interface{}) error {
+	checkpointPath, err := dbusext.ObjectPath(checkpoint)
+	if err != nil {
+		return err
+	}
+
+	changes := make(chan []Checkpoint)
+	if err := nm.CheckpointsChanged(changes); err != nil {
+		return err
+	}
+	defer nm.RemovePropertyChanged(changes)
+
+	checkpoints, err := nm.Checkpoints()
+	for err == nil {
+		if !containsCheckpoint(checkpoints, checkpointPath) {
+			return nil
+		}
+		select {
+		case checkpoints = <-changes:
+		case <-ctx.Done():
+			err = ctx.Err()
+		}
+	}
+	return err
+}
+
+// WaitCheckpointRemoved waits until checkpoint is removed from the list of active checkpoints.
+//
+// This happens when the checkpoint is destroyed, rolled back, or expires after its rollback timeout.
+func WaitCheckpointRemoved(ctx context.Context, checkpoint interface{}) error {
+	nm, err := System()
+	if err != nil {
+		return err
+	}
+	return nm.WaitCheckpointRemoved(ctx, checkpoint)
+}
+
+func containsCheckpoint(checkpoints []Checkpoint, path dbus.ObjectPath) bool {
+	for _, checkpoint := range checkpoints {
+		if checkpoint.Path() == path {
+			return true
+		}
+	}
+	return false
+}
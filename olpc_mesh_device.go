@@ -0,0 +1,51 @@
+package netmgr
+
+// OlpcMeshDeviceIface is the OlpcMesh Device interface.
+const OlpcMeshDeviceIface = "org.freedesktop.NetworkManager.Device.OlpcMesh"
+
+type (
+	// OlpcMeshDevice represents an OLPC Mesh device.
+	//
+	// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.OlpcMesh.html for more information.
+	OlpcMeshDevice interface {
+		Device
+		isOlpcMeshDevice()
+
+		// Properties
+
+		// HwAddress is the hardware address of the device.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.OlpcMesh.html#gdbus-property-org-freedesktop-NetworkManager-Device-OlpcMesh.HwAddress for more information.
+		HwAddress() (string, error)
+
+		// Companion is the Wi-Fi device the mesh device is paired with, nil if none.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.OlpcMesh.html#gdbus-property-org-freedesktop-NetworkManager-Device-OlpcMesh.Companion for more information.
+		Companion() (Device, error)
+
+		// ActiveChannel is the currently active channel.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.OlpcMesh.html#gdbus-property-org-freedesktop-NetworkManager-Device-OlpcMesh.ActiveChannel for more information.
+		ActiveChannel() (uint32, error)
+	}
+
+	olpcMeshDevice struct {
+		device
+	}
+)
+
+var _ OlpcMeshDevice = (*olpcMeshDevice)(nil)
+
+func (*olpcMeshDevice) isOlpcMeshDevice() {}
+
+func (d *olpcMeshDevice) HwAddress() (string, error) {
+	return d.GetSProperty(OlpcMeshDeviceIface + ".HwAddress")
+}
+
+func (d *olpcMeshDevice) Companion() (Device, error) {
+	return d.deviceProperty(OlpcMeshDeviceIface + ".Companion")
+}
+
+func (d *olpcMeshDevice) ActiveChannel() (uint32, error) {
+	return d.GetUProperty(OlpcMeshDeviceIface + ".ActiveChannel")
+}
@@ -0,0 +1,194 @@
+package netmgr
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrOVSUnavailable is returned when the Open vSwitch device plugin of NetworkManager is not loaded.
+var ErrOVSUnavailable = errors.New("Open vSwitch is not available, the NetworkManager OVS plugin is not loaded")
+
+// OVSInterfaceType is the type of an Open vSwitch interface, as in the type property of the ovs-interface setting.
+//
+// See https://developer.gnome.org/NetworkManager/stable/settings-ovs-interface.html for more information.
+type OVSInterfaceType string
+
+const (
+	// OVSInterfaceTypeInternal is an interface created by Open vSwitch, such as the local interface of a bridge.
+	OVSInterfaceTypeInternal OVSInterfaceType = "internal"
+
+	// OVSInterfaceTypeSystem is an existing Ethernet interface attached to the port.
+	OVSInterfaceTypeSystem OVSInterfaceType = "system"
+
+	// OVSInterfaceTypePatch is a patch interface, connecting two bridges.
+	OVSInterfaceTypePatch OVSInterfaceType = "patch"
+
+	// OVSInterfaceTypeDPDK is a DPDK interface.
+	OVSInterfaceTypeDPDK OVSInterfaceType = "dpdk"
+)
+
+type (
+	// OVSBridgeConfig is the configuration of an Open vSwitch bridge with its ports.
+	OVSBridgeConfig struct {
+		// Name is the name of the bridge.
+		Name string
+
+		// Ports are the ports of the bridge.
+		Ports []OVSPortConfig
+	}
+
+	// OVSPortConfig is the configuration of an Open vSwitch port with its interfaces.
+	OVSPortConfig struct {
+		// Name is the name of the port.
+		Name string
+
+		// Interfaces are the interfaces of the port, at least one is required.
+		Interfaces []OVSInterfaceConfig
+	}
+
+	// OVSInterfaceConfig is the configuration of an Open vSwitch interface.
+	OVSInterfaceConfig struct {
+		// Name is the name of the interface.
+		Name string
+
+		// Type is the type of the interface.
+		Type OVSInterfaceType
+
+		// Peer is the name of the peer interface of a patch interface.
+		Peer string
+
+		// IPv4 and IPv6 are the ipv4 and ipv6 settings of the interface, nil for the defaults of NetworkManager.
+		IPv4, IPv6 map[string]interface{}
+	}
+)
+
+// OVSBridgeConnectionSettings returns the settings of the profiles of the bridge, its ports and their interfaces, in activation order.
+func OVSBridgeConnectionSettings(config OVSBridgeConfig) ([]ConnectionSettings, error) {
+	if config.Name == "" {
+		return nil, errors.New("a bridge name is required")
+	}
+
+	settings := []ConnectionSettings{{
+		"connection": {
+			"id":             "ovs-bridge-" + config.Name,
+			"type":           "ovs-bridge",
+			"interface-name": config.Name,
+		},
+		"ovs-bridge": {},
+	}}
+
+	for _, port := range config.Ports {
+		if port.Name == "" {
+			return nil, fmt.Errorf("a port name is required in bridge %s", config.Name)
+		}
+		if len(port.Interfaces) == 0 {
+			return nil, fmt.Errorf("port %s requires at least one interface", port.Name)
+		}
+
+		settings = append(settings, ConnectionSettings{
+			"connection": {
+				"id":             "ovs-port-" + port.Name,
+				"type":           "ovs-port",
+				"interface-name": port.Name,
+				"master":         config.Name,
+				"slave-type":     "ovs-bridge",
+			},
+			"ovs-port": {},
+		})
+
+		for _, iface := range port.Interfaces {
+			s, err := iface.connectionSettings(port.Name)
+			if err != nil {
+				return nil, err
+			}
+			settings = append(settings, s)
+		}
+	}
+
+	return settings, nil
+}
+
+func (c OVSInterfaceConfig) connectionSettings(port string) (ConnectionSettings, error) {
+	if c.Name == "" {
+		return nil, fmt.Errorf("an interface name is required in port %s", port)
+	}
+
+	connection := map[string]interface{}{
+		"id":             "ovs-interface-" + c.Name,
+		"type":           "ovs-interface",
+		"interface-name": c.Name,
+		"master":         port,
+		"slave-type":     "ovs-port",
+	}
+	settings := ConnectionSettings{"connection": connection}
+
+	switch c.Type {
+	case OVSInterfaceTypeSystem:
+		// an existing interface is attached with a profile of its own type
+		connection["id"] = "ovs-slave-" + c.Name
+		connection["type"] = "802-3-ethernet"
+		settings["802-3-ethernet"] = map[string]interface{}{}
+	case OVSInterfaceTypePatch:
+		if c.Peer == "" {
+			return nil, fmt.Errorf("patch interface %s requires a peer", c.Name)
+		}
+		settings["ovs-interface"] = map[string]interface{}{"type": string(c.Type)}
+		settings["ovs-patch"] = map[string]interface{}{"peer": c.Peer}
+	case OVSInterfaceTypeInternal, OVSInterfaceTypeDPDK:
+		settings["ovs-interface"] = map[string]interface{}{"type": string(c.Type)}
+	default:
+		return nil, fmt.Errorf("invalid type %q of interface %s", c.Type, c.Name)
+	}
+
+	if c.IPv4 != nil {
+		settings["ipv4"] = c.IPv4
+	}
+	if c.IPv6 != nil {
+		settings["ipv6"] = c.IPv6
+	}
+
+	return settings, nil
+}
+
+func (nm *networkManager) AddOVSBridge(config OVSBridgeConfig, options map[string]interface{}) ([]SettingsConnection, error) {
+	ovs, err := nm.HasCapability(CapabilityOVS)
+	if err != nil {
+		return nil, err
+	}
+	if !ovs {
+		return nil, ErrOVSUnavailable
+	}
+
+	settings, err := OVSBridgeConnectionSettings(config)
+	if err != nil {
+		return nil, err
+	}
+
+	if options == nil {
+		options = map[string]interface{}{}
+	}
+
+	settingsConnections := make([]SettingsConnection, 0, len(settings))
+	for _, s := range settings {
+		settingsConnection, _, err := nm.AddAndActivateConnection2(s, nil, nil, options)
+		if err != nil {
+			return settingsConnections, err
+		}
+		settingsConnections = append(settingsConnections, settingsConnection)
+	}
+
+	return settingsConnections, nil
+}
+
+// AddOVSBridge adds and activates the profiles of an Open vSwitch bridge, its ports and their interfaces.
+//
+// options are given to AddAndActivateConnection2 for each profile.
+// ErrOVSUnavailable is returned if NetworkManager does not have CapabilityOVS.
+// If adding a profile fails, the profiles already added are returned along with the error.
+func AddOVSBridge(config OVSBridgeConfig, options map[string]interface{}) ([]SettingsConnection, error) {
+	nm, err := System()
+	if err != nil {
+		return nil, err
+	}
+	return nm.AddOVSBridge(config, options)
+}
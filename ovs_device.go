@@ -0,0 +1,106 @@
+package netmgr
+
+const (
+	// OvsBridgeDeviceIface is the OvsBridge Device interface.
+	OvsBridgeDeviceIface = "org.freedesktop.NetworkManager.Device.OvsBridge"
+
+	// OvsPortDeviceIface is the OvsPort Device interface.
+	OvsPortDeviceIface = "org.freedesktop.NetworkManager.Device.OvsPort"
+
+	// OvsInterfaceDeviceIface is the OvsInterface Device interface.
+	OvsInterfaceDeviceIface = "org.freedesktop.NetworkManager.Device.OvsInterface"
+)
+
+type (
+	// OvsBridgeDevice represents an Open vSwitch bridge, its ports are OvsPortDevice.
+	//
+	// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.OvsBridge.html for more information.
+	OvsBridgeDevice interface {
+		Device
+		isOvsBridgeDevice()
+
+		// Properties
+
+		// Slaves are the ports of the bridge.
+		//
+		// Deprecated: Use Ports, Slaves is deprecated since NetworkManager 1.34.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.OvsBridge.html#gdbus-property-org-freedesktop-NetworkManager-Device-OvsBridge.Slaves for more information.
+		Slaves() ([]Device, error)
+
+		// Ports are the ports of the bridge, read from Slaves for NetworkManager older than 1.34.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.html#gdbus-property-org-freedesktop-NetworkManager-Device.Ports for more information.
+		Ports() ([]Device, error)
+	}
+
+	ovsBridgeDevice struct {
+		device
+	}
+
+	// OvsPortDevice represents an Open vSwitch port, its ports are OvsInterfaceDevice or other devices attached to the port.
+	//
+	// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.OvsPort.html for more information.
+	OvsPortDevice interface {
+		Device
+		isOvsPortDevice()
+
+		// Properties
+
+		// Slaves are the interfaces of the port.
+		//
+		// Deprecated: Use Ports, Slaves is deprecated since NetworkManager 1.34.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.OvsPort.html#gdbus-property-org-freedesktop-NetworkManager-Device-OvsPort.Slaves for more information.
+		Slaves() ([]Device, error)
+
+		// Ports are the interfaces of the port, read from Slaves for NetworkManager older than 1.34.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.html#gdbus-property-org-freedesktop-NetworkManager-Device.Ports for more information.
+		Ports() ([]Device, error)
+	}
+
+	ovsPortDevice struct {
+		device
+	}
+
+	// OvsInterfaceDevice represents an Open vSwitch interface, it has no specific properties.
+	//
+	// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.OvsInterface.html for more information.
+	OvsInterfaceDevice interface {
+		Device
+		isOvsInterfaceDevice()
+	}
+
+	ovsInterfaceDevice struct {
+		device
+	}
+)
+
+var (
+	_ OvsBridgeDevice    = (*ovsBridgeDevice)(nil)
+	_ OvsPortDevice      = (*ovsPortDevice)(nil)
+	_ OvsInterfaceDevice = (*ovsInterfaceDevice)(nil)
+)
+
+func (*ovsBridgeDevice) isOvsBridgeDevice() {}
+
+func (*ovsPortDevice) isOvsPortDevice() {}
+
+func (*ovsInterfaceDevice) isOvsInterfaceDevice() {}
+
+func (d *ovsBridgeDevice) Slaves() ([]Device, error) {
+	return d.slaves(OvsBridgeDeviceIface)
+}
+
+func (d *ovsBridgeDevice) Ports() ([]Device, error) {
+	return d.ports(OvsBridgeDeviceIface)
+}
+
+func (d *ovsPortDevice) Slaves() ([]Device, error) {
+	return d.slaves(OvsPortDeviceIface)
+}
+
+func (d *ovsPortDevice) Ports() ([]Device, error) {
+	return d.ports(OvsPortDeviceIface)
+}
@@ -0,0 +1,37 @@
+package netmgr
+
+import "testing"
+
+func TestOVSBridgeConnectionSettings(t *testing.T) {
+	settings, err := OVSBridgeConnectionSettings(OVSBridgeConfig{
+		Name: "br0",
+		Ports: []OVSPortConfig{
+			{Name: "port0", Interfaces: []OVSInterfaceConfig{{Name: "iface0", Type: OVSInterfaceTypeInternal}}},
+			{Name: "port1", Interfaces: []OVSInterfaceConfig{{Name: "eth0", Type: OVSInterfaceTypeSystem}}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []struct{ id, typ, master string }{
+		{"ovs-bridge-br0", "ovs-bridge", ""},
+		{"ovs-port-port0", "ovs-port", "br0"},
+		{"ovs-interface-iface0", "ovs-interface", "port0"},
+		{"ovs-port-port1", "ovs-port", "br0"},
+		{"ovs-slave-eth0", "802-3-ethernet", "port1"},
+	}
+	if len(settings) != len(expected) {
+		t.Fatalf("expected %d profiles, got %d", len(expected), len(settings))
+	}
+	for i, e := range expected {
+		connection := settings[i]["connection"]
+		if connection["id"] != e.id || connection["type"] != e.typ || (e.master != "" && connection["master"] != e.master) {
+			t.Errorf("profile %d: unexpected connection setting %v", i, connection)
+		}
+	}
+
+	if _, err := OVSBridgeConnectionSettings(OVSBridgeConfig{Name: "br0", Ports: []OVSPortConfig{{Name: "port0"}}}); err == nil {
+		t.Errorf("expected an error for a port without interface")
+	}
+}
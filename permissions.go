@@ -0,0 +1,173 @@
+package netmgr
+
+import (
+	"context"
+	"sync"
+)
+
+// Permission is a polkit action of NetworkManager, as returned by GetPermissions.
+//
+// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.html#gdbus-method-org-freedesktop-NetworkManager.GetPermissions for more information.
+type Permission string
+
+const (
+	// PermissionEnableDisableNetwork allows enabling or disabling system networking.
+	PermissionEnableDisableNetwork Permission = "org.freedesktop.NetworkManager.enable-disable-network"
+
+	// PermissionEnableDisableWifi allows enabling or disabling Wi-Fi devices.
+	PermissionEnableDisableWifi Permission = "org.freedesktop.NetworkManager.enable-disable-wifi"
+
+	// PermissionEnableDisableWwan allows enabling or disabling mobile broadband devices.
+	PermissionEnableDisableWwan Permission = "org.freedesktop.NetworkManager.enable-disable-wwan"
+
+	// PermissionEnableDisableWimax allows enabling or disabling WiMAX mobile broadband devices.
+	PermissionEnableDisableWimax Permission = "org.freedesktop.NetworkManager.enable-disable-wimax"
+
+	// PermissionSleepWake allows putting NetworkManager to sleep or waking it up.
+	PermissionSleepWake Permission = "org.freedesktop.NetworkManager.sleep-wake"
+
+	// PermissionNetworkControl allows controlling network connections.
+	PermissionNetworkControl Permission = "org.freedesktop.NetworkManager.network-control"
+
+	// PermissionWifiShareProtected allows connection sharing via a protected Wi-Fi network.
+	PermissionWifiShareProtected Permission = "org.freedesktop.NetworkManager.wifi.share.protected"
+
+	// PermissionWifiShareOpen allows connection sharing via an open Wi-Fi network.
+	PermissionWifiShareOpen Permission = "org.freedesktop.NetworkManager.wifi.share.open"
+
+	// PermissionSettingsModifySystem allows modifying network connections for all users.
+	PermissionSettingsModifySystem Permission = "org.freedesktop.NetworkManager.settings.modify.system"
+
+	// PermissionSettingsModifyOwn allows modifying personal network connections.
+	PermissionSettingsModifyOwn Permission = "org.freedesktop.NetworkManager.settings.modify.own"
+
+	// PermissionSettingsModifyHostname allows modifying the persistent system hostname.
+	PermissionSettingsModifyHostname Permission = "org.freedesktop.NetworkManager.settings.modify.hostname"
+
+	// PermissionSettingsModifyGlobalDNS allows modifying the global DNS configuration.
+	PermissionSettingsModifyGlobalDNS Permission = "org.freedesktop.NetworkManager.settings.modify.global-dns"
+
+	// PermissionReload allows reloading NetworkManager configuration.
+	PermissionReload Permission = "org.freedesktop.NetworkManager.reload"
+
+	// PermissionCheckpointRollback allows creating checkpoints and rolling them back.
+	PermissionCheckpointRollback Permission = "org.freedesktop.NetworkManager.checkpoint-rollback"
+
+	// PermissionEnableDisableStatistics allows enabling or disabling device statistics.
+	PermissionEnableDisableStatistics Permission = "org.freedesktop.NetworkManager.enable-disable-statistics"
+
+	// PermissionEnableDisableConnectivityCheck allows enabling or disabling connectivity checking.
+	PermissionEnableDisableConnectivityCheck Permission = "org.freedesktop.NetworkManager.enable-disable-connectivity-check"
+
+	// PermissionWifiScan allows requesting Wi-Fi scans.
+	PermissionWifiScan Permission = "org.freedesktop.NetworkManager.wifi.scan"
+)
+
+// PermissionResult is the result of a permission check.
+type PermissionResult string
+
+const (
+	// PermissionYes means the caller is allowed to perform the action.
+	PermissionYes PermissionResult = "yes"
+
+	// PermissionNo means the caller is not allowed to perform the action.
+	PermissionNo PermissionResult = "no"
+
+	// PermissionAuth means the caller is allowed to perform the action after authenticating.
+	PermissionAuth PermissionResult = "auth"
+)
+
+// Permissions are the results of permission checks, keyed by permission.
+type Permissions map[Permission]PermissionResult
+
+// Allowed tells if the caller may perform the action of p, possibly after authenticating.
+func (p Permissions) Allowed(permission Permission) bool {
+	result := p[permission]
+	return result == PermissionYes || result == PermissionAuth
+}
+
+// PermissionsView is a cached view of the permissions of the caller, refreshed each time NetworkManager emits CheckPermissions.
+type PermissionsView struct {
+	lck         sync.RWMutex
+	permissions Permissions
+	err         error
+	changed     chan struct{}
+}
+
+func (nm *networkManager) WatchPermissions(ctx context.Context) (*PermissionsView, error) {
+	checks := make(chan struct{})
+	if err := nm.CheckPermissions(checks); err != nil {
+		return nil, err
+	}
+
+	permissions, err := nm.GetPermissions()
+	if err != nil {
+		nm.RemoveSignal(NetworkManagerInterface, "CheckPermissions", checks)
+		return nil, err
+	}
+
+	v := &PermissionsView{
+		permissions: permissions,
+		changed:     make(chan struct{}),
+	}
+
+	go func() {
+		defer nm.RemoveSignal(NetworkManagerInterface, "CheckPermissions", checks)
+		for {
+			select {
+			case <-checks:
+				permissions, err := nm.GetPermissions()
+				v.update(permissions, err)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return v, nil
+}
+
+// WatchPermissions returns a view of the permissions of the caller, which is kept up to date until ctx is done.
+func WatchPermissions(ctx context.Context) (*PermissionsView, error) {
+	nm, err := System()
+	if err != nil {
+		return nil, err
+	}
+	return nm.WatchPermissions(ctx)
+}
+
+func (v *PermissionsView) update(permissions Permissions, err error) {
+	v.lck.Lock()
+	defer v.lck.Unlock()
+	if err == nil {
+		v.permissions = permissions
+	}
+	v.err = err
+	close(v.changed)
+	v.changed = make(chan struct{})
+}
+
+// Permissions returns the last known permissions, and the error of the last refresh, if any.
+func (v *PermissionsView) Permissions() (Permissions, error) {
+	v.lck.RLock()
+	defer v.lck.RUnlock()
+	permissions := make(Permissions, len(v.permissions))
+	for permission, result := range v.permissions {
+		permissions[permission] = result
+	}
+	return permissions, v.err
+}
+
+// Allowed tells if the caller may perform the action of permission, according to the last known permissions.
+func (v *PermissionsView) Allowed(permission Permission) bool {
+	v.lck.RLock()
+	defer v.lck.RUnlock()
+	return v.permissions.Allowed(permission)
+}
+
+// Changed returns a channel which is closed on the next refresh of the permissions.
+func (v *PermissionsView) Changed() <-chan struct{} {
+	v.lck.RLock()
+	defer v.lck.RUnlock()
+	return v.changed
+}
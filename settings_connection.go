@@ -1,24 +1,73 @@
 package netmgr
 
 import (
+	"context"
+
 	"github.com/godbus/dbus/v5"
 
 	"github.com/nlepage/go-netmgr/internal/dbusext"
 )
 
+// SettingsConnectionIface is the Settings Connection interface.
+const SettingsConnectionIface = "org.freedesktop.NetworkManager.Settings.Connection"
+
 type (
 	// SettingsConnection represents a single network connection configuration.
+	//
+	// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Settings.Connection.html for more information.
 	SettingsConnection interface {
 		dbus.BusObject
+
+		// Methods
+
+		// Delete deletes the connection.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Settings.Connection.html#gdbus-method-org-freedesktop-NetworkManager-Settings-Connection.Delete for more information.
+		Delete() error
+
+		// GetSettings gets the settings of the connection, without secrets.
+		//
+		// The deprecated addresses and routes of the ipv4 and ipv6 settings are dropped when address-data and route-data are present,
+		// so that the settings can be given back to Update2.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Settings.Connection.html#gdbus-method-org-freedesktop-NetworkManager-Settings-Connection.GetSettings for more information.
+		GetSettings() (ConnectionSettings, error)
+
+		// Update2 updates the connection with settings, nil only applies flags, and returns the result of the update.
+		//
+		// args may contain "version-id" to fail with ErrSettingsVersionIDMismatch if the connection changed since reading VersionID.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Settings.Connection.html#gdbus-method-org-freedesktop-NetworkManager-Settings-Connection.Update2 for more information.
+		Update2(settings ConnectionSettings, flags SettingsUpdate2Flags, args map[string]interface{}) (map[string]interface{}, error)
+
+		// Properties
+
+		// VersionID is the version of the connection, incremented each time the connection changes, since NetworkManager 1.44.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Settings.Connection.html#gdbus-property-org-freedesktop-NetworkManager-Settings-Connection.VersionId for more information.
+		VersionID() (uint64, error)
+
+		// Helpers
+
+		// UpdateSettings calls edit with the settings of the connection, and updates the connection with the edited settings using Update2.
+		//
+		// If the connection changed in the meantime, it is read and edited again, up to UpdateSettingsAttempts times,
+		// then a *ConflictError is returned.
+		// It requires NetworkManager 1.44 or later, a *VersionError is returned otherwise.
+		UpdateSettings(ctx context.Context, flags SettingsUpdate2Flags, edit func(ConnectionSettings) error) error
 	}
 
 	settingsConnection struct {
 		dbusext.BusObject
 	}
 
+	// ConnectionSettings are the settings of a connection profile, keyed by setting name then by property name.
+	//
+	// See https://developer.gnome.org/NetworkManager/stable/ch01.html for more information.
+	ConnectionSettings map[string]map[string]interface{}
+
 	// SettingsConnectionInput represents connection settings and properties.
-	SettingsConnectionInput struct {
-	}
+	SettingsConnectionInput = ConnectionSettings
 )
 
 var _ SettingsConnection = (*settingsConnection)(nil)
@@ -27,3 +76,33 @@ var _ SettingsConnection = (*settingsConnection)(nil)
 func NewSettingsConnection(conn *dbus.Conn, path dbus.ObjectPath) SettingsConnection {
 	return &settingsConnection{dbusext.NewBusObject(conn, BusName, path)}
 }
+
+func (sc *settingsConnection) Delete() error {
+	return sc.CallAndStore(SettingsConnectionIface+".Delete", nil, nil)
+}
+
+func (sc *settingsConnection) GetSettings() (ConnectionSettings, error) {
+	var settings map[string]map[string]dbus.Variant
+	if err := sc.CallAndStore(SettingsConnectionIface+".GetSettings", nil, dbusext.Args{&settings}); err != nil {
+		return nil, err
+	}
+	return decodeConnectionSettings(settings), nil
+}
+
+func (sc *settingsConnection) Update2(settings ConnectionSettings, flags SettingsUpdate2Flags, args map[string]interface{}) (map[string]interface{}, error) {
+	if settings == nil {
+		settings = ConnectionSettings{}
+	}
+	if args == nil {
+		args = map[string]interface{}{}
+	}
+	var result map[string]dbus.Variant
+	if err := sc.CallAndStore(SettingsConnectionIface+".Update2", dbusext.Args{settings, uint32(flags), args}, dbusext.Args{&result}); err != nil {
+		return nil, err
+	}
+	return dbusext.ASV2ASI(result), nil
+}
+
+func (sc *settingsConnection) VersionID() (uint64, error) {
+	return sc.GetTProperty(SettingsConnectionIface + ".VersionId")
+}
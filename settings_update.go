@@ -0,0 +1,127 @@
+package netmgr
+
+import (
+	"context"
+
+	"github.com/godbus/dbus/v5"
+
+	"github.com/nlepage/go-netmgr/internal/dbusext"
+	"github.com/nlepage/go-netmgr/internal/enums"
+)
+
+// UpdateSettingsAttempts is the maximum number of attempts of SettingsConnection.UpdateSettings.
+const UpdateSettingsAttempts = 5
+
+// ErrSettingsVersionIDMismatch is returned by SettingsConnection.Update2 when the connection changed since the given version-id.
+var ErrSettingsVersionIDMismatch = dbus.NewError("org.freedesktop.NetworkManager.Settings.VersionIdMismatch", []interface{}{"version id mismatch"})
+
+func (sc *settingsConnection) UpdateSettings(ctx context.Context, flags SettingsUpdate2Flags, edit func(ConnectionSettings) error) error {
+	// the VersionId property and the version-id argument of Update2 were added in NetworkManager 1.44
+	nm := &networkManager{dbusext.NewBusObject(sc.Conn, BusName, NetworkManagerPath)}
+	if err := nm.requireVersion("UpdateSettings", "1.44"); err != nil {
+		return err
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < UpdateSettingsAttempts; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		// the version is read first, so that a change while reading the settings is detected by Update2
+		versionID, err := sc.VersionID()
+		if err != nil {
+			return err
+		}
+		settings, err := sc.GetSettings()
+		if err != nil {
+			return err
+		}
+		if err := edit(settings); err != nil {
+			return err
+		}
+
+		_, err = sc.Update2(settings, flags, map[string]interface{}{"version-id": versionID})
+		if !isDBusError(err, ErrSettingsVersionIDMismatch) {
+			return err
+		}
+		lastErr = err
+	}
+	return &ConflictError{sc.Path(), UpdateSettingsAttempts, lastErr}
+}
+
+// SettingsUpdate2Flags are the flags of SettingsConnection.Update2.
+//
+// See https://developer.gnome.org/NetworkManager/stable/nm-dbus-types.html#NMSettingsUpdate2Flags for more information.
+type SettingsUpdate2Flags uint
+
+const (
+	// SettingsUpdate2FlagNone means no flag, the connection is persisted as before the update.
+	SettingsUpdate2FlagNone SettingsUpdate2Flags = 0
+
+	// SettingsUpdate2FlagToDisk persists the connection to disk.
+	SettingsUpdate2FlagToDisk SettingsUpdate2Flags = 1 << (iota - 1)
+
+	// SettingsUpdate2FlagInMemory keeps the connection in memory only, the profile on disk is not changed.
+	SettingsUpdate2FlagInMemory
+
+	// SettingsUpdate2FlagInMemoryDetached keeps the connection in memory only, and detaches it from the profile on disk.
+	SettingsUpdate2FlagInMemoryDetached
+
+	// SettingsUpdate2FlagInMemoryOnly keeps the connection in memory only, and deletes the profile on disk.
+	SettingsUpdate2FlagInMemoryOnly
+
+	// SettingsUpdate2FlagVolatile deletes the connection when it is deactivated, it requires an in-memory flag.
+	SettingsUpdate2FlagVolatile
+
+	// SettingsUpdate2FlagBlockAutoconnect blocks autoconnect of the connection until it is manually activated.
+	SettingsUpdate2FlagBlockAutoconnect
+
+	// SettingsUpdate2FlagNoReapply does not reapply the changes to devices where the connection is active.
+	SettingsUpdate2FlagNoReapply
+)
+
+var settingsUpdate2FlagsNames = []enums.Name{
+	{Value: uint(SettingsUpdate2FlagToDisk), Name: "NM_SETTINGS_UPDATE2_FLAG_TO_DISK"},
+	{Value: uint(SettingsUpdate2FlagInMemory), Name: "NM_SETTINGS_UPDATE2_FLAG_IN_MEMORY"},
+	{Value: uint(SettingsUpdate2FlagInMemoryDetached), Name: "NM_SETTINGS_UPDATE2_FLAG_IN_MEMORY_DETACHED"},
+	{Value: uint(SettingsUpdate2FlagInMemoryOnly), Name: "NM_SETTINGS_UPDATE2_FLAG_IN_MEMORY_ONLY"},
+	{Value: uint(SettingsUpdate2FlagVolatile), Name: "NM_SETTINGS_UPDATE2_FLAG_VOLATILE"},
+	{Value: uint(SettingsUpdate2FlagBlockAutoconnect), Name: "NM_SETTINGS_UPDATE2_FLAG_BLOCK_AUTOCONNECT"},
+	{Value: uint(SettingsUpdate2FlagNoReapply), Name: "NM_SETTINGS_UPDATE2_FLAG_NO_REAPPLY"},
+}
+
+func (f SettingsUpdate2Flags) String() string {
+	return enums.FlagsString(uint(f), "NM_SETTINGS_UPDATE2_FLAG_NONE", settingsUpdate2FlagsNames)
+}
+
+// ParseSettingsUpdate2Flags returns the SettingsUpdate2Flags corresponding to s, as returned by SettingsUpdate2Flags.String.
+func ParseSettingsUpdate2Flags(s string) (SettingsUpdate2Flags, error) {
+	v, err := enums.ParseFlags("SettingsUpdate2Flags", s, "NM_SETTINGS_UPDATE2_FLAG_NONE", settingsUpdate2FlagsNames)
+	return SettingsUpdate2Flags(v), err
+}
+
+// Bits decomposes f into its single flags.
+func (f SettingsUpdate2Flags) Bits() []SettingsUpdate2Flags {
+	bits := enums.Bits(uint(f))
+	flags := make([]SettingsUpdate2Flags, len(bits))
+	for i, bit := range bits {
+		flags[i] = SettingsUpdate2Flags(bit)
+	}
+	return flags
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (f SettingsUpdate2Flags) MarshalText() ([]byte, error) {
+	return []byte(f.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (f *SettingsUpdate2Flags) UnmarshalText(text []byte) error {
+	v, err := ParseSettingsUpdate2Flags(string(text))
+	if err != nil {
+		return err
+	}
+	*f = v
+	return nil
+}
@@ -0,0 +1,122 @@
+package netmgr
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/godbus/dbus/v5/prop"
+)
+
+// ipv6Route is an element of the deprecated routes property of the ipv6 setting, of signature (ayuayu).
+type ipv6Route struct {
+	Dest    []byte
+	Prefix  uint32
+	NextHop []byte
+	Metric  uint32
+}
+
+// fakeSettingsConnection implements the GetSettings and Update2 methods of a settings connection,
+// the connection is changed concurrently by the first conflicts updates.
+type fakeSettingsConnection struct {
+	props     *prop.Properties
+	id        string
+	ipv6      map[string]dbus.Variant
+	conflicts int
+	updates   []uint32
+}
+
+func (f *fakeSettingsConnection) GetSettings() (map[string]map[string]dbus.Variant, *dbus.Error) {
+	return map[string]map[string]dbus.Variant{
+		"connection": {"id": dbus.MakeVariant(f.id)},
+		"ipv6": {
+			"routes":     dbus.MakeVariant([]ipv6Route{{Dest: make([]byte, 16), Prefix: 0, NextHop: make([]byte, 16), Metric: 100}}),
+			"route-data": dbus.MakeVariant([]map[string]dbus.Variant{{"dest": dbus.MakeVariant("::"), "prefix": dbus.MakeVariant(uint32(0))}}),
+			// NetworkManager always sends address-data and route-data along with the deprecated properties
+			"addresses":    ipv6Addresses,
+			"address-data": dbus.MakeVariant([]map[string]dbus.Variant{}),
+		},
+	}, nil
+}
+
+func (f *fakeSettingsConnection) Update2(settings map[string]map[string]dbus.Variant, flags uint32, args map[string]dbus.Variant) (map[string]dbus.Variant, *dbus.Error) {
+	f.updates = append(f.updates, flags)
+	versionID := f.props.GetMust(SettingsConnectionIface, "VersionId").(uint64)
+	if len(f.updates) <= f.conflicts {
+		versionID++
+		f.props.SetMust(SettingsConnectionIface, "VersionId", versionID)
+	}
+	if args["version-id"].Value().(uint64) != versionID {
+		return nil, ErrSettingsVersionIDMismatch
+	}
+	f.id = settings["connection"]["id"].Value().(string)
+	f.ipv6 = settings["ipv6"]
+	f.props.SetMust(SettingsConnectionIface, "VersionId", versionID+1)
+	return map[string]dbus.Variant{}, nil
+}
+
+func TestUpdateSettings(t *testing.T) {
+	conn := privateBus(t)
+
+	const path = dbus.ObjectPath(NetworkManagerPath + "/Settings/1")
+
+	props := export(t, conn, BusName, path, map[string]map[string]*prop.Prop{
+		SettingsConnectionIface: {"VersionId": {Value: uint64(1)}},
+	})
+	fake := &fakeSettingsConnection{props: props, id: "eth0", conflicts: 1}
+	if err := conn.Export(fake, path, SettingsConnectionIface); err != nil {
+		t.Fatal(err)
+	}
+
+	nmProps := export(t, conn, BusName, NetworkManagerPath, map[string]map[string]*prop.Prop{
+		NetworkManagerInterface: {"Version": {Value: "1.44.2"}},
+	})
+
+	sc := NewSettingsConnection(conn, path)
+	rename := func(settings ConnectionSettings) error {
+		settings["connection"]["id"] = settings["connection"]["id"].(string) + "-renamed"
+		return nil
+	}
+
+	flags := SettingsUpdate2FlagInMemory | SettingsUpdate2FlagNoReapply
+	if err := sc.UpdateSettings(context.Background(), flags, rename); err != nil {
+		t.Fatal(err)
+	}
+	if fake.id != "eth0-renamed" {
+		t.Errorf("expected id eth0-renamed, got %q", fake.id)
+	}
+	if len(fake.updates) != 2 || fake.updates[1] != uint32(flags) {
+		t.Errorf("expected 2 updates with flags %s, got %v", flags, fake.updates)
+	}
+	// the deprecated addresses and routes would be sent back as aav instead of a(ayuay) and a(ayuayu)
+	for _, deprecated := range []string{"addresses", "routes"} {
+		if v, ok := fake.ipv6[deprecated]; ok {
+			t.Errorf("expected ipv6 %s to be dropped, got %s", deprecated, v.Signature())
+		}
+	}
+	if _, ok := fake.ipv6["route-data"]; !ok {
+		t.Error("expected ipv6 route-data to be updated")
+	}
+
+	fake.conflicts = len(fake.updates) + UpdateSettingsAttempts
+	err := sc.UpdateSettings(context.Background(), flags, rename)
+	var conflictErr *ConflictError
+	if !errors.As(err, &conflictErr) || conflictErr.Path != path || conflictErr.Attempts != UpdateSettingsAttempts || conflictErr.Err == nil {
+		t.Errorf("expected a *ConflictError, got %v", err)
+	}
+	if fake.id != "eth0-renamed" {
+		t.Errorf("expected id unchanged after conflict, got %q", fake.id)
+	}
+
+	nmProps.SetMust(NetworkManagerInterface, "Version", "1.42.8")
+	updates := len(fake.updates)
+	err = sc.UpdateSettings(context.Background(), flags, rename)
+	var versionErr *VersionError
+	if !errors.As(err, &versionErr) || versionErr.Required != "1.44" {
+		t.Errorf("expected a *VersionError, got %v", err)
+	}
+	if len(fake.updates) != updates {
+		t.Errorf("expected no update with NetworkManager 1.42, got %d", len(fake.updates)-updates)
+	}
+}
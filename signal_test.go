@@ -0,0 +1,90 @@
+package netmgr
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/godbus/dbus/v5/prop"
+
+	"github.com/nlepage/go-netmgr/internal/dbusext"
+)
+
+func TestPropertyChangedSameChannel(t *testing.T) {
+	conn := privateBus(t)
+
+	const path = dbus.ObjectPath(NetworkManagerPath + "/Devices/1")
+
+	props := export(t, conn, BusName, path, map[string]map[string]*prop.Prop{
+		DeviceIface: {
+			"Driver":        {Value: "e1000", Emit: prop.EmitTrue},
+			"DriverVersion": {Value: "1.0", Emit: prop.EmitTrue},
+		},
+	})
+
+	d := &device{dbusext.NewBusObject(conn, BusName, path)}
+	ch := make(chan string, 2)
+	for _, property := range []string{"Driver", "DriverVersion"} {
+		if err := d.PropertyChanged(DeviceIface, property, reflect.TypeOf(""), ch, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	defer d.RemovePropertyChanged(ch)
+
+	props.SetMust(DeviceIface, "Driver", "igb")
+	props.SetMust(DeviceIface, "DriverVersion", "2.0")
+
+	for _, expected := range []string{"igb", "2.0"} {
+		select {
+		case v := <-ch:
+			if v != expected {
+				t.Errorf("expected %q, got %q", expected, v)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("%q was not received", expected)
+		}
+	}
+}
+
+func TestRemoveSignalWhileSubscribing(t *testing.T) {
+	conn := privateBus(t)
+
+	const path = dbus.ObjectPath(NetworkManagerPath + "/Devices/1")
+
+	props := export(t, conn, BusName, path, map[string]map[string]*prop.Prop{
+		DeviceIface: {"Driver": {Value: "e1000", Emit: prop.EmitTrue}},
+	})
+
+	d := &device{dbusext.NewBusObject(conn, BusName, path)}
+
+	// blocked is not read, so the dispatcher blocks sending the change to it
+	blocked := make(chan string)
+	if err := d.PropertyChanged(DeviceIface, "Driver", reflect.TypeOf(""), blocked, nil); err != nil {
+		t.Fatal(err)
+	}
+	props.SetMust(DeviceIface, "Driver", "igb")
+	time.Sleep(100 * time.Millisecond)
+
+	subscribed := make(chan error, 1)
+	go func() {
+		subscribed <- d.PropertyChanged(DeviceIface, "DriverVersion", reflect.TypeOf(""), make(chan string), nil)
+	}()
+	// lets subscribe wait for the dispatcher before removing the blocked channel
+	time.Sleep(100 * time.Millisecond)
+	removed := make(chan error, 1)
+	go func() {
+		removed <- d.RemovePropertyChanged(blocked)
+	}()
+
+	for _, done := range []chan error{subscribed, removed} {
+		select {
+		case err := <-done:
+			if err != nil {
+				t.Fatal(err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("subscribing and removing a blocked channel deadlocked")
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package netmgr
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"sort"
+)
+
+// TeamDeviceIface is the Team Device interface.
+const TeamDeviceIface = "org.freedesktop.NetworkManager.Device.Team"
+
+type (
+	// TeamDevice represents a team controller device.
+	//
+	// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Team.html for more information.
+	TeamDevice interface {
+		Device
+		isTeamDevice()
+
+		// Properties
+
+		// HwAddress is the hardware address of the device.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Team.html#gdbus-property-org-freedesktop-NetworkManager-Device-Team.HwAddress for more information.
+		HwAddress() (string, error)
+
+		// Carrier tells if the device has a carrier.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Team.html#gdbus-property-org-freedesktop-NetworkManager-Device-Team.Carrier for more information.
+		Carrier() (bool, error)
+
+		// Slaves are the devices enslaved to the team device.
+		//
+		// Deprecated: Use Ports, Slaves is deprecated since NetworkManager 1.34.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Team.html#gdbus-property-org-freedesktop-NetworkManager-Device-Team.Slaves for more information.
+		Slaves() ([]Device, error)
+
+		// Ports are the devices enslaved to the team device, read from Slaves for NetworkManager older than 1.34.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.html#gdbus-property-org-freedesktop-NetworkManager-Device.Ports for more information.
+		Ports() ([]Device, error)
+
+		// Config is the JSON configuration currently applied on the device.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Team.html#gdbus-property-org-freedesktop-NetworkManager-Device-Team.Config for more information.
+		Config() (string, error)
+
+		// Properties changes
+
+		// CarrierChanged sends the new Carrier value to ch each time it changes.
+		CarrierChanged(ch chan<- bool) error
+
+		// Helpers
+
+		// WatchCarrier returns a channel receiving the current Carrier value, then each change, until ctx is done.
+		WatchCarrier(ctx context.Context) (<-chan bool, error)
+
+		// TeamConfig is Config decoded, zero if the device has no configuration.
+		TeamConfig() (TeamConfig, error)
+	}
+
+	teamDevice struct {
+		device
+	}
+)
+
+var _ TeamDevice = (*teamDevice)(nil)
+
+func (*teamDevice) isTeamDevice() {}
+
+func (d *teamDevice) HwAddress() (string, error) {
+	return d.GetSProperty(TeamDeviceIface + ".HwAddress")
+}
+
+func (d *teamDevice) Carrier() (bool, error) {
+	return d.GetBProperty(TeamDeviceIface + ".Carrier")
+}
+
+func (d *teamDevice) Slaves() ([]Device, error) {
+	return d.slaves(TeamDeviceIface)
+}
+
+func (d *teamDevice) Ports() ([]Device, error) {
+	return d.ports(TeamDeviceIface)
+}
+
+func (d *teamDevice) Config() (string, error) {
+	return d.GetSProperty(TeamDeviceIface + ".Config")
+}
+
+func (d *teamDevice) TeamConfig() (TeamConfig, error) {
+	config, err := d.Config()
+	if err != nil {
+		return TeamConfig{}, err
+	}
+	return ParseTeamConfig(config)
+}
+
+func (d *teamDevice) CarrierChanged(ch chan<- bool) error {
+	return d.PropertyChanged(TeamDeviceIface, "Carrier", reflect.TypeOf(false), ch, nil)
+}
+
+func (d *teamDevice) WatchCarrier(ctx context.Context) (<-chan bool, error) {
+	return watchCarrier(ctx, &d.device, d.Carrier, d.CarrierChanged)
+}
+
+type (
+	// TeamConfig is the configuration of a team device, as used by teamd.
+	//
+	// See teamd.conf(5) for more information.
+	TeamConfig struct {
+		// Device is the name of the team device.
+		Device string `json:"device,omitempty"`
+
+		// Runner is the configuration of the runner, deciding which ports transmit.
+		Runner TeamRunnerConfig `json:"runner"`
+
+		// LinkWatch is the configuration of the link watcher(s), a single object or an array of objects.
+		LinkWatch json.RawMessage `json:"link_watch,omitempty"`
+
+		// Ports are the configurations of the ports, keyed by interface name.
+		Ports map[string]TeamPortConfig `json:"ports,omitempty"`
+	}
+
+	// TeamRunnerConfig is the configuration of a team runner.
+	TeamRunnerConfig struct {
+		// Name is the name of the runner, such as "activebackup", "loadbalance" or "lacp".
+		Name string `json:"name,omitempty"`
+
+		// HwaddrPolicy is the hardware address policy of the activebackup runner.
+		HwaddrPolicy string `json:"hwaddr_policy,omitempty"`
+
+		// TxHash are the fields used for the transmit hash of the loadbalance and lacp runners.
+		TxHash []string `json:"tx_hash,omitempty"`
+
+		// Active tells if the lacp runner sends LACPDU frames periodically.
+		Active *bool `json:"active,omitempty"`
+
+		// FastRate tells if the lacp runner asks its partner to send LACPDU frames fast.
+		FastRate *bool `json:"fast_rate,omitempty"`
+	}
+
+	// TeamPortConfig is the configuration of a port of a team.
+	TeamPortConfig struct {
+		// QueueID is the queue ID of the port.
+		QueueID *int `json:"queue_id,omitempty"`
+
+		// Prio is the priority of the port for the activebackup runner.
+		Prio int `json:"prio,omitempty"`
+
+		// Sticky tells if the activebackup runner keeps the port active once selected.
+		Sticky bool `json:"sticky,omitempty"`
+
+		// LacpPrio is the priority of the port for the lacp runner.
+		LacpPrio int `json:"lacp_prio,omitempty"`
+
+		// LacpKey is the key of the port for the lacp runner.
+		LacpKey int `json:"lacp_key,omitempty"`
+	}
+)
+
+// ParseTeamConfig parses the JSON configuration of a team device, an empty config gives a zero TeamConfig.
+func ParseTeamConfig(config string) (TeamConfig, error) {
+	var c TeamConfig
+	if config == "" {
+		return c, nil
+	}
+	err := json.Unmarshal([]byte(config), &c)
+	return c, err
+}
+
+// PortNames returns the sorted interface names of the ports of c.
+func (c TeamConfig) PortNames() []string {
+	names := make([]string, 0, len(c.Ports))
+	for name := range c.Ports {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,26 @@
+package netmgr
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseTeamConfig(t *testing.T) {
+	c, err := ParseTeamConfig(`{"device": "team0", "runner": {"name": "lacp", "tx_hash": ["eth", "ipv4"], "active": true}, "link_watch": {"name": "ethtool"}, "ports": {"eth1": {"lacp_prio": 10}, "eth0": {}}}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Device != "team0" || c.Runner.Name != "lacp" || !reflect.DeepEqual(c.Runner.TxHash, []string{"eth", "ipv4"}) || c.Runner.Active == nil || !*c.Runner.Active {
+		t.Errorf("unexpected TeamConfig %#v", c)
+	}
+	if c.Ports["eth1"].LacpPrio != 10 {
+		t.Errorf("unexpected eth1 port config %#v", c.Ports["eth1"])
+	}
+	if names := c.PortNames(); !reflect.DeepEqual(names, []string{"eth0", "eth1"}) {
+		t.Errorf("PortNames() = %v", names)
+	}
+
+	if c, err := ParseTeamConfig(""); err != nil || !reflect.DeepEqual(c, TeamConfig{}) {
+		t.Errorf("ParseTeamConfig(\"\") = %#v, %v", c, err)
+	}
+}
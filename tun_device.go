@@ -0,0 +1,99 @@
+package netmgr
+
+// TunDeviceIface is the Tun Device interface.
+const TunDeviceIface = "org.freedesktop.NetworkManager.Device.Tun"
+
+type (
+	// TunDevice represents a TUN or TAP device.
+	//
+	// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Tun.html for more information.
+	TunDevice interface {
+		Device
+		isTunDevice()
+
+		// Properties
+
+		// Owner is the uid of the tunnel owner, or -1 if it has no owner.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Tun.html#gdbus-property-org-freedesktop-NetworkManager-Device-Tun.Owner for more information.
+		Owner() (int64, error)
+
+		// Group is the gid of the tunnel group, or -1 if it has no group.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Tun.html#gdbus-property-org-freedesktop-NetworkManager-Device-Tun.Group for more information.
+		Group() (int64, error)
+
+		// Mode is the tunnel mode.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Tun.html#gdbus-property-org-freedesktop-NetworkManager-Device-Tun.Mode for more information.
+		Mode() (TunMode, error)
+
+		// NoPi tells if the IFF_NO_PI flag is set, meaning packets do not include packet information.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Tun.html#gdbus-property-org-freedesktop-NetworkManager-Device-Tun.NoPi for more information.
+		NoPi() (bool, error)
+
+		// VnetHdr tells if the IFF_VNET_HDR flag is set, meaning packets include a virtio network header.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Tun.html#gdbus-property-org-freedesktop-NetworkManager-Device-Tun.VnetHdr for more information.
+		VnetHdr() (bool, error)
+
+		// MultiQueue tells if the IFF_MULTI_QUEUE flag is set, meaning multiple queues can be used to send and receive packets.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Tun.html#gdbus-property-org-freedesktop-NetworkManager-Device-Tun.MultiQueue for more information.
+		MultiQueue() (bool, error)
+
+		// HwAddress is the hardware address of the device.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Tun.html#gdbus-property-org-freedesktop-NetworkManager-Device-Tun.HwAddress for more information.
+		HwAddress() (string, error)
+	}
+
+	tunDevice struct {
+		device
+	}
+)
+
+var _ TunDevice = (*tunDevice)(nil)
+
+func (*tunDevice) isTunDevice() {}
+
+func (d *tunDevice) Owner() (int64, error) {
+	return d.GetXProperty(TunDeviceIface + ".Owner")
+}
+
+func (d *tunDevice) Group() (int64, error) {
+	return d.GetXProperty(TunDeviceIface + ".Group")
+}
+
+func (d *tunDevice) Mode() (TunMode, error) {
+	mode, err := d.GetSProperty(TunDeviceIface + ".Mode")
+	return TunMode(mode), err
+}
+
+func (d *tunDevice) NoPi() (bool, error) {
+	return d.GetBProperty(TunDeviceIface + ".NoPi")
+}
+
+func (d *tunDevice) VnetHdr() (bool, error) {
+	return d.GetBProperty(TunDeviceIface + ".VnetHdr")
+}
+
+func (d *tunDevice) MultiQueue() (bool, error) {
+	return d.GetBProperty(TunDeviceIface + ".MultiQueue")
+}
+
+func (d *tunDevice) HwAddress() (string, error) {
+	return d.GetSProperty(TunDeviceIface + ".HwAddress")
+}
+
+// TunMode is the mode of a TUN or TAP device.
+type TunMode string
+
+const (
+	// TunModeTun is a TUN device, exchanging IP packets.
+	TunModeTun TunMode = "tun"
+
+	// TunModeTap is a TAP device, exchanging Ethernet frames.
+	TunModeTap TunMode = "tap"
+)
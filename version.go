@@ -0,0 +1,70 @@
+package netmgr
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// VersionError is returned when a feature is not supported by the version of the running NetworkManager.
+type VersionError struct {
+	// Feature is the unsupported feature.
+	Feature string
+
+	// Required is the minimum version supporting Feature.
+	Required string
+
+	// Version is the version of the running NetworkManager.
+	Version string
+}
+
+func (e *VersionError) Error() string {
+	return fmt.Sprintf("%s requires NetworkManager %s or later, running %s", e.Feature, e.Required, e.Version)
+}
+
+// versionAtLeast tells if version, such as "1.22.10" or "1.31.2-dev", is required or later.
+func versionAtLeast(version, required string) bool {
+	v, r := versionNumbers(version), versionNumbers(required)
+	for i := range r {
+		if i >= len(v) {
+			return false
+		}
+		if v[i] != r[i] {
+			return v[i] > r[i]
+		}
+	}
+	return true
+}
+
+func versionNumbers(version string) []int {
+	parts := strings.Split(version, ".")
+	numbers := make([]int, 0, len(parts))
+	for _, part := range parts {
+		// stop at the first non digit, ignoring suffixes such as "-dev"
+		end := 0
+		for end < len(part) && part[end] >= '0' && part[end] <= '9' {
+			end++
+		}
+		n, err := strconv.Atoi(part[:end])
+		if err != nil {
+			break
+		}
+		numbers = append(numbers, n)
+		if end != len(part) {
+			break
+		}
+	}
+	return numbers
+}
+
+// requireVersion returns a *VersionError if the running NetworkManager is older than required.
+func (nm *networkManager) requireVersion(feature, required string) error {
+	version, err := nm.Version()
+	if err != nil {
+		return err
+	}
+	if !versionAtLeast(version, required) {
+		return &VersionError{feature, required, version}
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package netmgr
+
+import "testing"
+
+func TestVersionAtLeast(t *testing.T) {
+	tests := []struct {
+		version, required string
+		expected          bool
+	}{
+		{"1.22.10", "1.22", true},
+		{"1.22", "1.22", true},
+		{"1.6.0", "1.22", false},
+		{"1.30.0", "1.6", true},
+		{"2.0", "1.99.99", true},
+		{"1.31.2-dev", "1.31.2", true},
+		{"1", "1.2", false},
+		{"", "1.2", false},
+	}
+
+	for _, test := range tests {
+		if got := versionAtLeast(test.version, test.required); got != test.expected {
+			t.Errorf("versionAtLeast(%q, %q) = %v, expected %v", test.version, test.required, got, test.expected)
+		}
+	}
+}
+
+func TestReloadFlags(t *testing.T) {
+	if !ReloadFlagNone.Has(ReloadFlagDNSFull) {
+		t.Errorf("ReloadFlagNone should reload everything")
+	}
+	if f := ReloadFlagNone.With(ReloadFlagDNSRc); f != ReloadFlagNone {
+		t.Errorf("ReloadFlagNone.With(ReloadFlagDNSRc) = %s, should still reload everything", f)
+	}
+	if f := ReloadFlagConf.With(ReloadFlagDNSRc); f != ReloadFlagConf|ReloadFlagDNSRc {
+		t.Errorf("ReloadFlagConf.With(ReloadFlagDNSRc) = %s", f)
+	}
+	if f, err := ReloadFlagNone.Without(ReloadFlagConf); err != nil || f != ReloadFlagDNSRc|ReloadFlagDNSFull {
+		t.Errorf("ReloadFlagNone.Without(ReloadFlagConf) = %s, %v", f, err)
+	}
+	if f, err := ReloadFlagDNSRc.Without(ReloadFlagDNSRc); err != ErrNothingToReload {
+		t.Errorf("ReloadFlagDNSRc.Without(ReloadFlagDNSRc) = %s, %v, expected ErrNothingToReload", f, err)
+	}
+	if s := (ReloadFlagDNSRc | ReloadFlagDNSFull).String(); s != "NM_MANAGER_RELOAD_FLAG_DNS_RC | NM_MANAGER_RELOAD_FLAG_DNS_FULL" {
+		t.Errorf("unexpected String() %q", s)
+	}
+}
@@ -0,0 +1,33 @@
+package netmgr
+
+// VethDeviceIface is the Veth Device interface.
+const VethDeviceIface = "org.freedesktop.NetworkManager.Device.Veth"
+
+type (
+	// VethDevice represents a virtual Ethernet device.
+	//
+	// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Veth.html for more information.
+	VethDevice interface {
+		Device
+		isVethDevice()
+
+		// Properties
+
+		// Peer is the device at the other end of the veth pair, nil if none.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Veth.html#gdbus-property-org-freedesktop-NetworkManager-Device-Veth.Peer for more information.
+		Peer() (Device, error)
+	}
+
+	vethDevice struct {
+		device
+	}
+)
+
+var _ VethDevice = (*vethDevice)(nil)
+
+func (*vethDevice) isVethDevice() {}
+
+func (d *vethDevice) Peer() (Device, error) {
+	return d.deviceProperty(VethDeviceIface + ".Peer")
+}
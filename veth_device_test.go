@@ -0,0 +1,44 @@
+package netmgr
+
+import (
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/godbus/dbus/v5/prop"
+)
+
+func TestVethDevicePeer(t *testing.T) {
+	conn := privateBus(t)
+
+	const (
+		vethPath = dbus.ObjectPath(NetworkManagerPath + "/Devices/1")
+		peerPath = dbus.ObjectPath(NetworkManagerPath + "/Devices/2")
+	)
+
+	export(t, conn, BusName, vethPath, map[string]map[string]*prop.Prop{
+		DeviceIface:     {"DeviceType": {Value: uint32(DeviceTypeVeth)}},
+		VethDeviceIface: {"Peer": {Value: peerPath}},
+	})
+	export(t, conn, BusName, peerPath, map[string]map[string]*prop.Prop{
+		DeviceIface:    {"DeviceType": {Value: uint32(DeviceTypeTun)}},
+		TunDeviceIface: {"Mode": {Value: string(TunModeTap)}},
+	})
+
+	d := NewDevice(conn, vethPath)
+	veth, ok := d.(VethDevice)
+	if !ok {
+		t.Fatalf("NewDevice returned %T, expected a VethDevice", d)
+	}
+
+	peer, err := veth.Peer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tun, ok := peer.(TunDevice)
+	if !ok {
+		t.Fatalf("Peer() returned %T, expected a TunDevice", peer)
+	}
+	if mode, err := tun.Mode(); err != nil || mode != TunModeTap {
+		t.Errorf("Mode() = %q, %v", mode, err)
+	}
+}
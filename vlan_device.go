@@ -0,0 +1,60 @@
+package netmgr
+
+// VlanDeviceIface is the Vlan Device interface.
+const VlanDeviceIface = "org.freedesktop.NetworkManager.Device.Vlan"
+
+type (
+	// VlanDevice represents a VLAN device.
+	//
+	// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Vlan.html for more information.
+	VlanDevice interface {
+		Device
+		isVlanDevice()
+
+		// Properties
+
+		// HwAddress is the hardware address of the device.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Vlan.html#gdbus-property-org-freedesktop-NetworkManager-Device-Vlan.HwAddress for more information.
+		HwAddress() (string, error)
+
+		// Carrier tells if the device has a carrier.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Vlan.html#gdbus-property-org-freedesktop-NetworkManager-Device-Vlan.Carrier for more information.
+		Carrier() (bool, error)
+
+		// Parent is the parent device of the VLAN, nil if none.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Vlan.html#gdbus-property-org-freedesktop-NetworkManager-Device-Vlan.Parent for more information.
+		Parent() (Device, error)
+
+		// VlanID is the VLAN ID of this VLAN interface.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Vlan.html#gdbus-property-org-freedesktop-NetworkManager-Device-Vlan.VlanId for more information.
+		VlanID() (uint32, error)
+	}
+
+	vlanDevice struct {
+		device
+	}
+)
+
+var _ VlanDevice = (*vlanDevice)(nil)
+
+func (*vlanDevice) isVlanDevice() {}
+
+func (d *vlanDevice) HwAddress() (string, error) {
+	return d.GetSProperty(VlanDeviceIface + ".HwAddress")
+}
+
+func (d *vlanDevice) Carrier() (bool, error) {
+	return d.GetBProperty(VlanDeviceIface + ".Carrier")
+}
+
+func (d *vlanDevice) Parent() (Device, error) {
+	return d.parent(VlanDeviceIface)
+}
+
+func (d *vlanDevice) VlanID() (uint32, error) {
+	return d.GetUProperty(VlanDeviceIface + ".VlanId")
+}
@@ -0,0 +1,396 @@
+package vpnplugin
+
+import (
+	"encoding/binary"
+	"net"
+	"unsafe"
+
+	"github.com/godbus/dbus/v5"
+)
+
+type (
+	// Config is the generic configuration of a VPN connection, sent to NetworkManager by SetConfig.
+	//
+	// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.VPN.Plugin.html#gdbus-method-org-freedesktop-NetworkManager-VPN-Plugin.SetConfig for more information.
+	Config struct {
+		// Gateway is the external IP address of the VPN gateway.
+		Gateway net.IP
+
+		// TunDev is the name of the tunnel device.
+		TunDev string
+
+		// Banner is the login banner of the VPN server.
+		Banner string
+
+		// MTU is the MTU of the tunnel device, 0 if unset.
+		MTU uint32
+
+		// HasIP4 indicates an IPv4 configuration will be sent by SetIP4Config.
+		HasIP4 bool
+
+		// HasIP6 indicates an IPv6 configuration will be sent by SetIP6Config.
+		HasIP6 bool
+
+		// CanPersist indicates the plugin is able to reconnect by itself when the connection is lost.
+		CanPersist bool
+	}
+
+	// IP4Config is the IPv4 configuration of a VPN connection, sent to NetworkManager by SetIP4Config.
+	//
+	// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.VPN.Plugin.html#gdbus-method-org-freedesktop-NetworkManager-VPN-Plugin.SetIp4Config for more information.
+	IP4Config struct {
+		// InternalGateway is the IP address of the gateway inside the VPN.
+		InternalGateway net.IP
+
+		// Address is the IP address of the tunnel device.
+		Address net.IP
+
+		// PTP is the IP address of the remote end of a point-to-point tunnel.
+		PTP net.IP
+
+		// Prefix is the prefix length of Address.
+		Prefix uint32
+
+		// DNS are the IP addresses of the DNS servers.
+		DNS []net.IP
+
+		// NBNS are the IP addresses of the NetBIOS name servers.
+		NBNS []net.IP
+
+		// MSS is the maximum segment size, 0 if unset.
+		MSS uint32
+
+		// Domain is the DNS domain of the VPN.
+		Domain string
+
+		// Domains are the DNS search domains of the VPN.
+		Domains []string
+
+		// Routes are the routes pushed by the VPN server.
+		Routes []IP4Route
+
+		// NeverDefault prevents the VPN from being used as the default route.
+		NeverDefault bool
+
+		// PreserveRoutes keeps the routes of the connection profile along with Routes.
+		PreserveRoutes bool
+	}
+
+	// IP4Route is an IPv4 route of a VPN connection.
+	IP4Route struct {
+		Dest    net.IP
+		Prefix  uint32
+		NextHop net.IP
+		Metric  uint32
+	}
+
+	// IP6Config is the IPv6 configuration of a VPN connection, sent to NetworkManager by SetIP6Config.
+	//
+	// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.VPN.Plugin.html#gdbus-method-org-freedesktop-NetworkManager-VPN-Plugin.SetIp6Config for more information.
+	IP6Config struct {
+		// InternalGateway is the IP address of the gateway inside the VPN.
+		InternalGateway net.IP
+
+		// Address is the IP address of the tunnel device.
+		Address net.IP
+
+		// PTP is the IP address of the remote end of a point-to-point tunnel.
+		PTP net.IP
+
+		// Prefix is the prefix length of Address.
+		Prefix uint32
+
+		// DNS are the IP addresses of the DNS servers.
+		DNS []net.IP
+
+		// MSS is the maximum segment size, 0 if unset.
+		MSS uint32
+
+		// Domain is the DNS domain of the VPN.
+		Domain string
+
+		// Domains are the DNS search domains of the VPN.
+		Domains []string
+
+		// Routes are the routes pushed by the VPN server.
+		Routes []IP6Route
+
+		// NeverDefault prevents the VPN from being used as the default route.
+		NeverDefault bool
+
+		// PreserveRoutes keeps the routes of the connection profile along with Routes.
+		PreserveRoutes bool
+	}
+
+	// IP6Route is an IPv6 route of a VPN connection.
+	IP6Route struct {
+		Dest    net.IP
+		Prefix  uint32
+		NextHop net.IP
+		Metric  uint32
+	}
+
+	// ip6Route is the D-Bus representation of IP6Route, (ayuayu).
+	ip6Route struct {
+		Dest    []byte
+		Prefix  uint32
+		NextHop []byte
+		Metric  uint32
+	}
+)
+
+// nativeEndian is the byte order of the host.
+//
+// NetworkManager sends IPv4 addresses as the uint32 holding the address in network byte order in memory,
+// so the address bytes are read with the byte order of the host.
+var nativeEndian binary.ByteOrder = func() binary.ByteOrder {
+	x := uint16(1)
+	if *(*byte)(unsafe.Pointer(&x)) == 1 {
+		return binary.LittleEndian
+	}
+	return binary.BigEndian
+}()
+
+func ip4ToUint32(ip net.IP) uint32 {
+	ip4 := ip.To4()
+	if ip4 == nil {
+		return 0
+	}
+	return nativeEndian.Uint32(ip4)
+}
+
+func uint32ToIP4(u uint32) net.IP {
+	ip := make(net.IP, net.IPv4len)
+	nativeEndian.PutUint32(ip, u)
+	return ip
+}
+
+func ip6Bytes(ip net.IP) []byte {
+	return []byte(ip.To16())
+}
+
+// Encode returns the D-Bus representation of c.
+func (c Config) Encode() map[string]dbus.Variant {
+	m := map[string]dbus.Variant{}
+	if ip4 := c.Gateway.To4(); ip4 != nil {
+		m["gateway"] = dbus.MakeVariant(ip4ToUint32(ip4))
+	} else if c.Gateway != nil {
+		m["gateway"] = dbus.MakeVariant(ip6Bytes(c.Gateway))
+	}
+	if c.TunDev != "" {
+		m["tundev"] = dbus.MakeVariant(c.TunDev)
+	}
+	if c.Banner != "" {
+		m["banner"] = dbus.MakeVariant(c.Banner)
+	}
+	if c.MTU != 0 {
+		m["mtu"] = dbus.MakeVariant(c.MTU)
+	}
+	m["has-ip4"] = dbus.MakeVariant(c.HasIP4)
+	m["has-ip6"] = dbus.MakeVariant(c.HasIP6)
+	if c.CanPersist {
+		m["can-persist"] = dbus.MakeVariant(true)
+	}
+	return m
+}
+
+// DecodeConfig returns the Config corresponding to the D-Bus representation m.
+//
+// As NetworkManager does, HasIP4 is set if neither has-ip4 nor has-ip6 is given.
+func DecodeConfig(m map[string]dbus.Variant) Config {
+	var c Config
+	switch gw := m["gateway"].Value().(type) {
+	case uint32:
+		c.Gateway = uint32ToIP4(gw)
+	case []byte:
+		c.Gateway = net.IP(gw)
+	}
+	c.TunDev, _ = m["tundev"].Value().(string)
+	c.Banner, _ = m["banner"].Value().(string)
+	c.MTU, _ = m["mtu"].Value().(uint32)
+	c.HasIP4, _ = m["has-ip4"].Value().(bool)
+	c.HasIP6, _ = m["has-ip6"].Value().(bool)
+	c.CanPersist, _ = m["can-persist"].Value().(bool)
+	if _, ok := m["has-ip4"]; !ok {
+		if _, ok := m["has-ip6"]; !ok {
+			c.HasIP4 = true
+		}
+	}
+	return c
+}
+
+// Encode returns the D-Bus representation of c.
+func (c IP4Config) Encode() map[string]dbus.Variant {
+	m := map[string]dbus.Variant{}
+	if c.InternalGateway != nil {
+		m["internal-gateway"] = dbus.MakeVariant(ip4ToUint32(c.InternalGateway))
+	}
+	if c.Address != nil {
+		m["address"] = dbus.MakeVariant(ip4ToUint32(c.Address))
+	}
+	if c.PTP != nil {
+		m["ptp"] = dbus.MakeVariant(ip4ToUint32(c.PTP))
+	}
+	if c.Prefix != 0 {
+		m["prefix"] = dbus.MakeVariant(c.Prefix)
+	}
+	if len(c.DNS) != 0 {
+		m["dns"] = dbus.MakeVariant(ip4sToUint32s(c.DNS))
+	}
+	if len(c.NBNS) != 0 {
+		m["nbns"] = dbus.MakeVariant(ip4sToUint32s(c.NBNS))
+	}
+	if c.MSS != 0 {
+		m["mss"] = dbus.MakeVariant(c.MSS)
+	}
+	if c.Domain != "" {
+		m["domain"] = dbus.MakeVariant(c.Domain)
+	}
+	if len(c.Domains) != 0 {
+		m["domains"] = dbus.MakeVariant(c.Domains)
+	}
+	if len(c.Routes) != 0 {
+		routes := make([][]uint32, len(c.Routes))
+		for i, r := range c.Routes {
+			routes[i] = []uint32{ip4ToUint32(r.Dest), r.Prefix, ip4ToUint32(r.NextHop), r.Metric}
+		}
+		m["routes"] = dbus.MakeVariant(routes)
+	}
+	if c.NeverDefault {
+		m["never-default"] = dbus.MakeVariant(true)
+	}
+	if c.PreserveRoutes {
+		m["preserve-routes"] = dbus.MakeVariant(true)
+	}
+	return m
+}
+
+// DecodeIP4Config returns the IP4Config corresponding to the D-Bus representation m.
+func DecodeIP4Config(m map[string]dbus.Variant) IP4Config {
+	var c IP4Config
+	if u, ok := m["internal-gateway"].Value().(uint32); ok {
+		c.InternalGateway = uint32ToIP4(u)
+	}
+	if u, ok := m["address"].Value().(uint32); ok {
+		c.Address = uint32ToIP4(u)
+	}
+	if u, ok := m["ptp"].Value().(uint32); ok {
+		c.PTP = uint32ToIP4(u)
+	}
+	c.Prefix, _ = m["prefix"].Value().(uint32)
+	if us, ok := m["dns"].Value().([]uint32); ok {
+		c.DNS = uint32sToIP4s(us)
+	}
+	if us, ok := m["nbns"].Value().([]uint32); ok {
+		c.NBNS = uint32sToIP4s(us)
+	}
+	c.MSS, _ = m["mss"].Value().(uint32)
+	c.Domain, _ = m["domain"].Value().(string)
+	c.Domains, _ = m["domains"].Value().([]string)
+	if routes, ok := m["routes"].Value().([][]uint32); ok {
+		for _, r := range routes {
+			if len(r) != 4 {
+				continue
+			}
+			c.Routes = append(c.Routes, IP4Route{uint32ToIP4(r[0]), r[1], uint32ToIP4(r[2]), r[3]})
+		}
+	}
+	c.NeverDefault, _ = m["never-default"].Value().(bool)
+	c.PreserveRoutes, _ = m["preserve-routes"].Value().(bool)
+	return c
+}
+
+func ip4sToUint32s(ips []net.IP) []uint32 {
+	us := make([]uint32, len(ips))
+	for i, ip := range ips {
+		us[i] = ip4ToUint32(ip)
+	}
+	return us
+}
+
+func uint32sToIP4s(us []uint32) []net.IP {
+	ips := make([]net.IP, len(us))
+	for i, u := range us {
+		ips[i] = uint32ToIP4(u)
+	}
+	return ips
+}
+
+// Encode returns the D-Bus representation of c.
+func (c IP6Config) Encode() map[string]dbus.Variant {
+	m := map[string]dbus.Variant{}
+	if c.InternalGateway != nil {
+		m["internal-gateway"] = dbus.MakeVariant(ip6Bytes(c.InternalGateway))
+	}
+	if c.Address != nil {
+		m["address"] = dbus.MakeVariant(ip6Bytes(c.Address))
+	}
+	if c.PTP != nil {
+		m["ptp"] = dbus.MakeVariant(ip6Bytes(c.PTP))
+	}
+	if c.Prefix != 0 {
+		m["prefix"] = dbus.MakeVariant(c.Prefix)
+	}
+	if len(c.DNS) != 0 {
+		dns := make([][]byte, len(c.DNS))
+		for i, ip := range c.DNS {
+			dns[i] = ip6Bytes(ip)
+		}
+		m["dns"] = dbus.MakeVariant(dns)
+	}
+	if c.MSS != 0 {
+		m["mss"] = dbus.MakeVariant(c.MSS)
+	}
+	if c.Domain != "" {
+		m["domain"] = dbus.MakeVariant(c.Domain)
+	}
+	if len(c.Domains) != 0 {
+		m["domains"] = dbus.MakeVariant(c.Domains)
+	}
+	if len(c.Routes) != 0 {
+		routes := make([]ip6Route, len(c.Routes))
+		for i, r := range c.Routes {
+			routes[i] = ip6Route{ip6Bytes(r.Dest), r.Prefix, ip6Bytes(r.NextHop), r.Metric}
+		}
+		m["routes"] = dbus.MakeVariant(routes)
+	}
+	if c.NeverDefault {
+		m["never-default"] = dbus.MakeVariant(true)
+	}
+	if c.PreserveRoutes {
+		m["preserve-routes"] = dbus.MakeVariant(true)
+	}
+	return m
+}
+
+// DecodeIP6Config returns the IP6Config corresponding to the D-Bus representation m.
+func DecodeIP6Config(m map[string]dbus.Variant) IP6Config {
+	var c IP6Config
+	if b, ok := m["internal-gateway"].Value().([]byte); ok {
+		c.InternalGateway = net.IP(b)
+	}
+	if b, ok := m["address"].Value().([]byte); ok {
+		c.Address = net.IP(b)
+	}
+	if b, ok := m["ptp"].Value().([]byte); ok {
+		c.PTP = net.IP(b)
+	}
+	c.Prefix, _ = m["prefix"].Value().(uint32)
+	if dns, ok := m["dns"].Value().([][]byte); ok {
+		for _, b := range dns {
+			c.DNS = append(c.DNS, net.IP(b))
+		}
+	}
+	c.MSS, _ = m["mss"].Value().(uint32)
+	c.Domain, _ = m["domain"].Value().(string)
+	c.Domains, _ = m["domains"].Value().([]string)
+	var routes []ip6Route
+	if v, ok := m["routes"]; ok && dbus.Store([]interface{}{v.Value()}, &routes) == nil {
+		for _, r := range routes {
+			c.Routes = append(c.Routes, IP6Route{net.IP(r.Dest), r.Prefix, net.IP(r.NextHop), r.Metric})
+		}
+	}
+	c.NeverDefault, _ = m["never-default"].Value().(bool)
+	c.PreserveRoutes, _ = m["preserve-routes"].Value().(bool)
+	return c
+}
@@ -0,0 +1,102 @@
+package vpnplugin
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// wire returns m as received from D-Bus.
+func wire(t *testing.T, m map[string]dbus.Variant) map[string]dbus.Variant {
+	msg := &dbus.Message{
+		Type: dbus.TypeSignal,
+		Headers: map[dbus.HeaderField]dbus.Variant{
+			dbus.FieldPath:      dbus.MakeVariant(dbus.ObjectPath(PluginPath)),
+			dbus.FieldInterface: dbus.MakeVariant(PluginIface),
+			dbus.FieldMember:    dbus.MakeVariant("Config"),
+			dbus.FieldSignature: dbus.MakeVariant(dbus.SignatureOf(m)),
+		},
+		Body: []interface{}{m},
+	}
+	var buf bytes.Buffer
+	if err := msg.EncodeTo(&buf, binary.LittleEndian); err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := dbus.DecodeMessage(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return decoded.Body[0].(map[string]dbus.Variant)
+}
+
+func TestIP4Address(t *testing.T) {
+	ip := net.IPv4(192, 168, 1, 2)
+	u := ip4ToUint32(ip)
+
+	var b [4]byte
+	nativeEndian.PutUint32(b[:], u)
+	if b != [4]byte{192, 168, 1, 2} {
+		t.Errorf("ip4ToUint32(%s) = %#x, expected network byte order in memory, got %v", ip, u, b)
+	}
+	if got := uint32ToIP4(u); !got.Equal(ip) {
+		t.Errorf("uint32ToIP4(%#x) = %s, expected %s", u, got, ip)
+	}
+}
+
+func TestConfigRoundTrip(t *testing.T) {
+	tests := []Config{
+		{Gateway: net.IPv4(1, 2, 3, 4).To4(), TunDev: "tun0", Banner: "hello", MTU: 1400, HasIP4: true, CanPersist: true},
+		{Gateway: net.ParseIP("2001:db8::1"), TunDev: "tun1", HasIP6: true},
+	}
+
+	for _, c := range tests {
+		if got := DecodeConfig(wire(t, c.Encode())); !reflect.DeepEqual(got, c) {
+			t.Errorf("DecodeConfig(%#v.Encode()) = %#v", c, got)
+		}
+	}
+
+	if got := DecodeConfig(map[string]dbus.Variant{}); !got.HasIP4 {
+		t.Errorf("DecodeConfig({}) should default to HasIP4")
+	}
+}
+
+func TestIP4ConfigRoundTrip(t *testing.T) {
+	c := IP4Config{
+		InternalGateway: net.IPv4(10, 0, 0, 1).To4(),
+		Address:         net.IPv4(10, 0, 0, 2).To4(),
+		PTP:             net.IPv4(10, 0, 0, 3).To4(),
+		Prefix:          24,
+		DNS:             []net.IP{net.IPv4(10, 0, 0, 53).To4()},
+		NBNS:            []net.IP{net.IPv4(10, 0, 0, 137).To4()},
+		MSS:             1300,
+		Domain:          "example.com",
+		Domains:         []string{"a.example.com", "b.example.com"},
+		Routes:          []IP4Route{{net.IPv4(192, 168, 0, 0).To4(), 16, net.IPv4(10, 0, 0, 1).To4(), 100}},
+		NeverDefault:    true,
+		PreserveRoutes:  true,
+	}
+
+	if got := DecodeIP4Config(wire(t, c.Encode())); !reflect.DeepEqual(got, c) {
+		t.Errorf("DecodeIP4Config(c.Encode()) = %#v, expected %#v", got, c)
+	}
+}
+
+func TestIP6ConfigRoundTrip(t *testing.T) {
+	c := IP6Config{
+		InternalGateway: net.ParseIP("fd00::1"),
+		Address:         net.ParseIP("fd00::2"),
+		Prefix:          64,
+		DNS:             []net.IP{net.ParseIP("fd00::53")},
+		Domains:         []string{"example.com"},
+		Routes:          []IP6Route{{net.ParseIP("fd01::"), 48, net.ParseIP("fd00::1"), 10}},
+		NeverDefault:    true,
+	}
+
+	if got := DecodeIP6Config(wire(t, c.Encode())); !reflect.DeepEqual(got, c) {
+		t.Errorf("DecodeIP6Config(c.Encode()) = %#v, expected %#v", got, c)
+	}
+}
@@ -0,0 +1,523 @@
+// Package vpnplugin offers a framework to write NetworkManager VPN plugins, implementing the service side of the VPN Plugin D-Bus API (https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.VPN.Plugin.html).
+package vpnplugin
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/godbus/dbus/v5"
+
+	netmgr "github.com/nlepage/go-netmgr"
+	"github.com/nlepage/go-netmgr/internal/dbusext"
+	"github.com/nlepage/go-netmgr/internal/enums"
+)
+
+// PluginIface is the VPN Plugin interface.
+const PluginIface = "org.freedesktop.NetworkManager.VPN.Plugin"
+
+// PluginPath is the path at which NetworkManager calls VPN plugins.
+const PluginPath = "/org/freedesktop/NetworkManager/VPN/Plugin"
+
+// ErrorPrefix is the prefix of the names of the VPN Plugin D-Bus errors.
+const ErrorPrefix = "org.freedesktop.NetworkManager.VPN.Error."
+
+// Errors returned to NetworkManager, a Handler may also return them, any other error is sent as ErrFailed.
+var (
+	// ErrFailed means the operation failed.
+	ErrFailed = dbus.NewError(ErrorPrefix+"Failed", []interface{}{"failed"})
+
+	// ErrStartingInProgress means the VPN connection is already starting.
+	ErrStartingInProgress = dbus.NewError(ErrorPrefix+"StartingInProgress", []interface{}{"starting in progress"})
+
+	// ErrAlreadyStarted means the VPN connection is already started.
+	ErrAlreadyStarted = dbus.NewError(ErrorPrefix+"AlreadyStarted", []interface{}{"already started"})
+
+	// ErrStoppingInProgress means the VPN connection is already stopping.
+	ErrStoppingInProgress = dbus.NewError(ErrorPrefix+"StoppingInProgress", []interface{}{"stopping in progress"})
+
+	// ErrAlreadyStopped means the VPN connection is already stopped.
+	ErrAlreadyStopped = dbus.NewError(ErrorPrefix+"AlreadyStopped", []interface{}{"already stopped"})
+
+	// ErrWrongState means the operation is not allowed in the current state.
+	ErrWrongState = dbus.NewError(ErrorPrefix+"WrongState", []interface{}{"wrong state"})
+
+	// ErrBadArguments means the arguments are invalid.
+	ErrBadArguments = dbus.NewError(ErrorPrefix+"BadArguments", []interface{}{"bad arguments"})
+
+	// ErrLaunchFailed means the VPN connection could not be started.
+	ErrLaunchFailed = dbus.NewError(ErrorPrefix+"LaunchFailed", []interface{}{"launch failed"})
+
+	// ErrInvalidConnection means the connection settings are invalid.
+	ErrInvalidConnection = dbus.NewError(ErrorPrefix+"InvalidConnection", []interface{}{"invalid connection"})
+
+	// ErrInteractiveNotSupported means the plugin does not support interactive connections.
+	ErrInteractiveNotSupported = dbus.NewError(ErrorPrefix+"InteractiveNotSupported", []interface{}{"interactive not supported"})
+)
+
+type (
+	// Handler is implemented by VPN plugins.
+	//
+	// The methods of a Handler may be called concurrently.
+	Handler interface {
+		// Connect starts the VPN connection described by connection.
+		//
+		// It should return as soon as the connection is launched,
+		// the configuration is then sent to NetworkManager with Plugin.SetConfig, Plugin.SetIP4Config and Plugin.SetIP6Config.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.VPN.Plugin.html#gdbus-method-org-freedesktop-NetworkManager-VPN-Plugin.Connect for more information.
+		Connect(p *Plugin, connection netmgr.ConnectionSettings) error
+
+		// NeedSecrets returns the name of the setting which is missing secrets to connect, empty if no secrets are needed.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.VPN.Plugin.html#gdbus-method-org-freedesktop-NetworkManager-VPN-Plugin.NeedSecrets for more information.
+		NeedSecrets(connection netmgr.ConnectionSettings) (string, error)
+
+		// Disconnect stops the VPN connection.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.VPN.Plugin.html#gdbus-method-org-freedesktop-NetworkManager-VPN-Plugin.Disconnect for more information.
+		Disconnect(p *Plugin) error
+	}
+
+	// InteractiveHandler is implemented by VPN plugins able to request secrets while connecting.
+	InteractiveHandler interface {
+		Handler
+
+		// ConnectInteractive is like Connect, but the plugin may call Plugin.RequestSecrets while connecting.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.VPN.Plugin.html#gdbus-method-org-freedesktop-NetworkManager-VPN-Plugin.ConnectInteractive for more information.
+		ConnectInteractive(p *Plugin, connection netmgr.ConnectionSettings, details map[string]interface{}) error
+
+		// NewSecrets is called with the secrets requested by Plugin.RequestSecrets.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.VPN.Plugin.html#gdbus-method-org-freedesktop-NetworkManager-VPN-Plugin.NewSecrets for more information.
+		NewSecrets(p *Plugin, connection netmgr.ConnectionSettings) error
+	}
+
+	// Plugin is the service side of a NetworkManager VPN plugin, forwarding the calls of NetworkManager to a Handler.
+	Plugin struct {
+		conn    *dbus.Conn
+		handler Handler
+
+		lck    sync.Mutex
+		state  State
+		hasIP4 bool
+		hasIP6 bool
+		gotIP4 bool
+		gotIP6 bool
+	}
+
+	// pluginObject is the object exported on D-Bus for a Plugin.
+	pluginObject struct {
+		p *Plugin
+	}
+
+	// pluginProperties is the org.freedesktop.DBus.Properties object exported on D-Bus for a Plugin.
+	pluginProperties struct {
+		p *Plugin
+	}
+)
+
+// New returns a Plugin forwarding the calls of NetworkManager on conn to handler.
+func New(conn *dbus.Conn, handler Handler) *Plugin {
+	return &Plugin{
+		conn:    conn,
+		handler: handler,
+		state:   StateInit,
+	}
+}
+
+// Serve exports the plugin and requests busName, the D-Bus service name of the plugin given in its .name file.
+//
+// When ctx is done, the VPN connection is disconnected if needed, the name is released, and Serve returns.
+func (p *Plugin) Serve(ctx context.Context, busName string) error {
+	if err := p.conn.Export(&pluginObject{p}, PluginPath, PluginIface); err != nil {
+		return err
+	}
+	defer p.conn.Export(nil, PluginPath, PluginIface)
+	if err := p.conn.Export(&pluginProperties{p}, PluginPath, dbusext.PropertiesIface); err != nil {
+		return err
+	}
+	defer p.conn.Export(nil, PluginPath, dbusext.PropertiesIface)
+
+	reply, err := p.conn.RequestName(busName, dbus.NameFlagDoNotQueue)
+	if err != nil {
+		return err
+	}
+	if reply != dbus.RequestNameReplyPrimaryOwner {
+		return fmt.Errorf("%s is already taken", busName)
+	}
+	defer p.conn.ReleaseName(busName)
+
+	<-ctx.Done()
+
+	switch p.State() {
+	case StateStarting, StateStarted:
+		return p.disconnect()
+	}
+	return nil
+}
+
+// State returns the current state of the plugin.
+func (p *Plugin) State() State {
+	p.lck.Lock()
+	defer p.lck.Unlock()
+	return p.state
+}
+
+func (p *Plugin) setState(state State) error {
+	p.lck.Lock()
+	changed := p.state != state
+	p.state = state
+	p.lck.Unlock()
+
+	if !changed {
+		return nil
+	}
+	return p.emitState(state)
+}
+
+func (p *Plugin) emitState(state State) error {
+	if err := p.conn.Emit(PluginPath, PluginIface+".StateChanged", uint32(state)); err != nil {
+		return err
+	}
+	return p.conn.Emit(PluginPath, dbusext.PropertiesIface+".PropertiesChanged", PluginIface, map[string]dbus.Variant{"State": dbus.MakeVariant(uint32(state))}, []string{})
+}
+
+// SetConfig sends the generic configuration of the VPN connection to NetworkManager.
+//
+// If config.Banner is set, the LoginBanner signal is also emitted.
+//
+// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.VPN.Plugin.html#gdbus-signal-org-freedesktop-NetworkManager-VPN-Plugin.Config for more information.
+func (p *Plugin) SetConfig(config Config) error {
+	p.lck.Lock()
+	p.hasIP4, p.hasIP6 = config.HasIP4, config.HasIP6
+	p.lck.Unlock()
+
+	if err := p.conn.Emit(PluginPath, PluginIface+".Config", config.Encode()); err != nil {
+		return err
+	}
+	if config.Banner != "" {
+		if err := p.conn.Emit(PluginPath, PluginIface+".LoginBanner", config.Banner); err != nil {
+			return err
+		}
+	}
+	return p.checkStarted()
+}
+
+// SetIP4Config sends the IPv4 configuration of the VPN connection to NetworkManager.
+//
+// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.VPN.Plugin.html#gdbus-signal-org-freedesktop-NetworkManager-VPN-Plugin.Ip4Config for more information.
+func (p *Plugin) SetIP4Config(config IP4Config) error {
+	if err := p.conn.Emit(PluginPath, PluginIface+".Ip4Config", config.Encode()); err != nil {
+		return err
+	}
+	p.lck.Lock()
+	p.gotIP4 = true
+	p.lck.Unlock()
+	return p.checkStarted()
+}
+
+// SetIP6Config sends the IPv6 configuration of the VPN connection to NetworkManager.
+//
+// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.VPN.Plugin.html#gdbus-signal-org-freedesktop-NetworkManager-VPN-Plugin.Ip6Config for more information.
+func (p *Plugin) SetIP6Config(config IP6Config) error {
+	if err := p.conn.Emit(PluginPath, PluginIface+".Ip6Config", config.Encode()); err != nil {
+		return err
+	}
+	p.lck.Lock()
+	p.gotIP6 = true
+	p.lck.Unlock()
+	return p.checkStarted()
+}
+
+// checkStarted moves the plugin to StateStarted once all the expected configurations were sent.
+func (p *Plugin) checkStarted() error {
+	p.lck.Lock()
+	hasIP4, hasIP6 := p.hasIP4, p.hasIP6
+	if !hasIP4 && !hasIP6 {
+		// as libnm does, IPv4 is expected if nothing is said, including when SetConfig was not called
+		hasIP4 = true
+	}
+	started := p.state == StateStarting && hasIP4 == p.gotIP4 && hasIP6 == p.gotIP6
+	p.lck.Unlock()
+
+	if !started {
+		return nil
+	}
+	return p.setState(StateStarted)
+}
+
+// Failure signals a failure of the VPN connection to NetworkManager.
+//
+// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.VPN.Plugin.html#gdbus-signal-org-freedesktop-NetworkManager-VPN-Plugin.Failure for more information.
+func (p *Plugin) Failure(reason FailureReason) error {
+	return p.conn.Emit(PluginPath, PluginIface+".Failure", uint32(reason))
+}
+
+// RequestSecrets asks NetworkManager for more secrets during an interactive connection, they are then given to InteractiveHandler.NewSecrets.
+//
+// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.VPN.Plugin.html#gdbus-signal-org-freedesktop-NetworkManager-VPN-Plugin.SecretsRequired for more information.
+func (p *Plugin) RequestSecrets(message string, secrets []string) error {
+	return p.conn.Emit(PluginPath, PluginIface+".SecretsRequired", message, secrets)
+}
+
+// Stopped tells NetworkManager the VPN connection stopped by itself, for example after a Failure.
+func (p *Plugin) Stopped() error {
+	return p.setState(StateStopped)
+}
+
+func (p *Plugin) connect(connect func() error) *dbus.Error {
+	p.lck.Lock()
+	state := p.state
+	if state != StateStarting && state != StateStarted && state != StateStopping {
+		p.state = StateStarting
+		p.hasIP4, p.hasIP6, p.gotIP4, p.gotIP6 = false, false, false, false
+	}
+	p.lck.Unlock()
+
+	switch state {
+	case StateStarting:
+		return ErrStartingInProgress
+	case StateStarted:
+		return ErrAlreadyStarted
+	case StateStopping:
+		return ErrStoppingInProgress
+	}
+
+	p.emitState(StateStarting)
+
+	if err := connect(); err != nil {
+		p.setState(StateStopped)
+		if dbusErr := toDBusError(err); dbusErr.Name != ErrFailed.Name {
+			return dbusErr
+		}
+		return dbus.NewError(ErrLaunchFailed.Name, []interface{}{err.Error()})
+	}
+	return nil
+}
+
+func (p *Plugin) disconnect() error {
+	p.lck.Lock()
+	state := p.state
+	if state == StateStarting || state == StateStarted {
+		p.state = StateStopping
+	}
+	p.lck.Unlock()
+
+	switch state {
+	case StateStopping:
+		return ErrStoppingInProgress
+	case StateStarting, StateStarted:
+	default:
+		return ErrAlreadyStopped
+	}
+
+	p.emitState(StateStopping)
+
+	err := p.handler.Disconnect(p)
+	if stateErr := p.setState(StateStopped); err == nil {
+		err = stateErr
+	}
+	return err
+}
+
+func (o *pluginObject) Connect(connection map[string]map[string]dbus.Variant) *dbus.Error {
+	return o.p.connect(func() error {
+		return o.p.handler.Connect(o.p, decodeConnection(connection))
+	})
+}
+
+func (o *pluginObject) ConnectInteractive(connection map[string]map[string]dbus.Variant, details map[string]dbus.Variant) *dbus.Error {
+	handler, ok := o.p.handler.(InteractiveHandler)
+	if !ok {
+		return ErrInteractiveNotSupported
+	}
+	return o.p.connect(func() error {
+		return handler.ConnectInteractive(o.p, decodeConnection(connection), dbusext.ASV2ASI(details))
+	})
+}
+
+func (o *pluginObject) NeedSecrets(settings map[string]map[string]dbus.Variant) (string, *dbus.Error) {
+	settingName, err := o.p.handler.NeedSecrets(decodeConnection(settings))
+	return settingName, toDBusError(err)
+}
+
+func (o *pluginObject) Disconnect() *dbus.Error {
+	return toDBusError(o.p.disconnect())
+}
+
+func (o *pluginObject) SetConfig(config map[string]dbus.Variant) *dbus.Error {
+	return toDBusError(o.p.SetConfig(DecodeConfig(config)))
+}
+
+func (o *pluginObject) SetIp4Config(config map[string]dbus.Variant) *dbus.Error {
+	return toDBusError(o.p.SetIP4Config(DecodeIP4Config(config)))
+}
+
+func (o *pluginObject) SetIp6Config(config map[string]dbus.Variant) *dbus.Error {
+	return toDBusError(o.p.SetIP6Config(DecodeIP6Config(config)))
+}
+
+func (o *pluginObject) SetFailure(reason string) *dbus.Error {
+	// called by helpers when the IP configuration is unusable
+	o.p.Failure(FailureBadIPConfig)
+	if err := o.p.disconnect(); err != nil && err != ErrAlreadyStopped {
+		return toDBusError(err)
+	}
+	return nil
+}
+
+func (o *pluginObject) NewSecrets(connection map[string]map[string]dbus.Variant) *dbus.Error {
+	handler, ok := o.p.handler.(InteractiveHandler)
+	if !ok {
+		return ErrInteractiveNotSupported
+	}
+	return toDBusError(handler.NewSecrets(o.p, decodeConnection(connection)))
+}
+
+func (o *pluginProperties) Get(iface, property string) (dbus.Variant, *dbus.Error) {
+	if iface != PluginIface || property != "State" {
+		return dbus.Variant{}, dbus.NewError("org.freedesktop.DBus.Error.UnknownProperty", []interface{}{property})
+	}
+	return dbus.MakeVariant(uint32(o.p.State())), nil
+}
+
+func (o *pluginProperties) GetAll(iface string) (map[string]dbus.Variant, *dbus.Error) {
+	if iface != PluginIface {
+		return map[string]dbus.Variant{}, nil
+	}
+	return map[string]dbus.Variant{"State": dbus.MakeVariant(uint32(o.p.State()))}, nil
+}
+
+func (o *pluginProperties) Set(iface, property string, value dbus.Variant) *dbus.Error {
+	return dbus.NewError("org.freedesktop.DBus.Error.PropertyReadOnly", []interface{}{property})
+}
+
+func decodeConnection(connection map[string]map[string]dbus.Variant) netmgr.ConnectionSettings {
+	settings := make(netmgr.ConnectionSettings, len(connection))
+	for name, setting := range connection {
+		settings[name] = dbusext.ASV2ASI(setting)
+	}
+	return settings
+}
+
+func toDBusError(err error) *dbus.Error {
+	if err == nil {
+		return nil
+	}
+	if dbusErr, ok := err.(*dbus.Error); ok {
+		return dbusErr
+	}
+	return dbus.NewError(ErrFailed.Name, []interface{}{err.Error()})
+}
+
+// State is the state of a VPN plugin.
+//
+// See https://developer.gnome.org/NetworkManager/stable/nm-vpn-dbus-types.html#NMVpnServiceState for more information.
+type State uint
+
+const (
+	// StateUnknown means the state of the VPN plugin is unknown.
+	StateUnknown State = iota
+
+	// StateInit means the VPN plugin is initialized.
+	StateInit
+
+	// StateShutdown is not used.
+	StateShutdown
+
+	// StateStarting means the plugin is attempting to connect to a VPN server.
+	StateStarting
+
+	// StateStarted means the plugin has connected to a VPN server.
+	StateStarted
+
+	// StateStopping means the plugin is disconnecting from the VPN server.
+	StateStopping
+
+	// StateStopped means the plugin has disconnected from the VPN server.
+	StateStopped
+)
+
+var stateNames = []enums.Name{
+	{Value: uint(StateUnknown), Name: "NM_VPN_SERVICE_STATE_UNKNOWN"},
+	{Value: uint(StateInit), Name: "NM_VPN_SERVICE_STATE_INIT"},
+	{Value: uint(StateShutdown), Name: "NM_VPN_SERVICE_STATE_SHUTDOWN"},
+	{Value: uint(StateStarting), Name: "NM_VPN_SERVICE_STATE_STARTING"},
+	{Value: uint(StateStarted), Name: "NM_VPN_SERVICE_STATE_STARTED"},
+	{Value: uint(StateStopping), Name: "NM_VPN_SERVICE_STATE_STOPPING"},
+	{Value: uint(StateStopped), Name: "NM_VPN_SERVICE_STATE_STOPPED"},
+}
+
+func (s State) String() string {
+	return enums.String(uint(s), stateNames)
+}
+
+// ParseState returns the State named s, as returned by State.String.
+func ParseState(s string) (State, error) {
+	v, err := enums.Parse("State", s, stateNames)
+	return State(v), err
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s State) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *State) UnmarshalText(text []byte) error {
+	v, err := ParseState(string(text))
+	if err != nil {
+		return err
+	}
+	*s = v
+	return nil
+}
+
+// FailureReason is the reason of a VPN plugin failure.
+//
+// See https://developer.gnome.org/NetworkManager/stable/nm-vpn-dbus-types.html#NMVpnPluginFailure for more information.
+type FailureReason uint
+
+const (
+	// FailureLoginFailed means login failed.
+	FailureLoginFailed FailureReason = iota
+
+	// FailureConnectFailed means connect failed.
+	FailureConnectFailed
+
+	// FailureBadIPConfig means invalid IP configuration returned from the VPN plugin.
+	FailureBadIPConfig
+)
+
+var failureReasonNames = []enums.Name{
+	{Value: uint(FailureLoginFailed), Name: "NM_VPN_PLUGIN_FAILURE_LOGIN_FAILED"},
+	{Value: uint(FailureConnectFailed), Name: "NM_VPN_PLUGIN_FAILURE_CONNECT_FAILED"},
+	{Value: uint(FailureBadIPConfig), Name: "NM_VPN_PLUGIN_FAILURE_BAD_IP_CONFIG"},
+}
+
+func (r FailureReason) String() string {
+	return enums.String(uint(r), failureReasonNames)
+}
+
+// ParseFailureReason returns the FailureReason named s, as returned by FailureReason.String.
+func ParseFailureReason(s string) (FailureReason, error) {
+	v, err := enums.Parse("FailureReason", s, failureReasonNames)
+	return FailureReason(v), err
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (r FailureReason) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (r *FailureReason) UnmarshalText(text []byte) error {
+	v, err := ParseFailureReason(string(text))
+	if err != nil {
+		return err
+	}
+	*r = v
+	return nil
+}
@@ -0,0 +1,61 @@
+package vpnplugin
+
+import (
+	"bufio"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+)
+
+func TestSetIP4ConfigWithoutConfig(t *testing.T) {
+	p := New(privateBus(t), nil)
+	p.state = StateStarting
+
+	if err := p.SetIP4Config(IP4Config{Prefix: 24}); err != nil {
+		t.Fatal(err)
+	}
+	if state := p.State(); state != StateStarted {
+		t.Errorf("expected %s once the IPv4 configuration is sent without Config, got %s", StateStarted, state)
+	}
+}
+
+// privateBus starts a private dbus-daemon and connects to it, the test is skipped if dbus-daemon is unavailable.
+func privateBus(t *testing.T) *dbus.Conn {
+	daemon, err := exec.LookPath("dbus-daemon")
+	if err != nil {
+		t.Skip("dbus-daemon is unavailable")
+	}
+
+	cmd := exec.Command(daemon, "--session", "--nofork", "--print-address=1")
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cmd.Start(); err != nil {
+		t.Skipf("dbus-daemon could not be started: %v", err)
+	}
+	t.Cleanup(func() {
+		cmd.Process.Kill()
+		cmd.Wait()
+	})
+
+	address, err := bufio.NewReader(stdout).ReadString('\n')
+	if err != nil {
+		t.Skipf("dbus-daemon did not print its address: %v", err)
+	}
+
+	conn, err := dbus.Dial(strings.TrimSpace(address))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	if err := conn.Auth(nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := conn.Hello(); err != nil {
+		t.Fatal(err)
+	}
+	return conn
+}
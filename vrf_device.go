@@ -0,0 +1,33 @@
+package netmgr
+
+// VrfDeviceIface is the Vrf Device interface.
+const VrfDeviceIface = "org.freedesktop.NetworkManager.Device.Vrf"
+
+type (
+	// VrfDevice represents a VRF (Virtual Routing and Forwarding) device.
+	//
+	// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Vrf.html for more information.
+	VrfDevice interface {
+		Device
+		isVrfDevice()
+
+		// Properties
+
+		// Table is the routing table of the VRF.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Vrf.html#gdbus-property-org-freedesktop-NetworkManager-Device-Vrf.Table for more information.
+		Table() (uint32, error)
+	}
+
+	vrfDevice struct {
+		device
+	}
+)
+
+var _ VrfDevice = (*vrfDevice)(nil)
+
+func (*vrfDevice) isVrfDevice() {}
+
+func (d *vrfDevice) Table() (uint32, error) {
+	return d.GetUProperty(VrfDeviceIface + ".Table")
+}
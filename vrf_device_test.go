@@ -0,0 +1,27 @@
+package netmgr
+
+import (
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/godbus/dbus/v5/prop"
+)
+
+func TestVrfDevice(t *testing.T) {
+	conn := privateBus(t)
+
+	const devicePath = dbus.ObjectPath(NetworkManagerPath + "/Devices/1")
+
+	export(t, conn, BusName, devicePath, map[string]map[string]*prop.Prop{
+		DeviceIface:    {"DeviceType": {Value: uint32(DeviceTypeVrf)}},
+		VrfDeviceIface: {"Table": {Value: uint32(1001)}},
+	})
+
+	vrf, ok := NewDevice(conn, devicePath).(VrfDevice)
+	if !ok {
+		t.Fatal("NewDevice did not return a VrfDevice")
+	}
+	if table, err := vrf.Table(); err != nil || table != 1001 {
+		t.Errorf("Table() = %d, %v", table, err)
+	}
+}
@@ -0,0 +1,177 @@
+package netmgr
+
+// VxlanDeviceIface is the Vxlan Device interface.
+const VxlanDeviceIface = "org.freedesktop.NetworkManager.Device.Vxlan"
+
+type (
+	// VxlanDevice represents a VXLAN device.
+	//
+	// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Vxlan.html for more information.
+	VxlanDevice interface {
+		Device
+		isVxlanDevice()
+
+		// Properties
+
+		// HwAddress is the hardware address of the device.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Vxlan.html#gdbus-property-org-freedesktop-NetworkManager-Device-Vxlan.HwAddress for more information.
+		HwAddress() (string, error)
+
+		// Parent is the parent device of the VXLAN, nil if none.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Vxlan.html#gdbus-property-org-freedesktop-NetworkManager-Device-Vxlan.Parent for more information.
+		Parent() (Device, error)
+
+		// ID is the VXLAN Network Identifier (VNI).
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Vxlan.html#gdbus-property-org-freedesktop-NetworkManager-Device-Vxlan.Id for more information.
+		ID() (uint32, error)
+
+		// Group is the IP (v4 or v6) multicast group used to communicate with other physical hosts on this VXLAN.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Vxlan.html#gdbus-property-org-freedesktop-NetworkManager-Device-Vxlan.Group for more information.
+		Group() (string, error)
+
+		// Local is the local IPv4 or IPv6 address to use when sending VXLAN packets to other physical hosts.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Vxlan.html#gdbus-property-org-freedesktop-NetworkManager-Device-Vxlan.Local for more information.
+		Local() (string, error)
+
+		// Tos is the value to use in the IP ToS field for VXLAN packets sent to other physical hosts.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Vxlan.html#gdbus-property-org-freedesktop-NetworkManager-Device-Vxlan.Tos for more information.
+		Tos() (byte, error)
+
+		// TTL is the value to use in the IP TTL field for VXLAN packets sent to other physical hosts.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Vxlan.html#gdbus-property-org-freedesktop-NetworkManager-Device-Vxlan.Ttl for more information.
+		TTL() (byte, error)
+
+		// Learning tells if the device dynamically learns the remote IP addresses of hosts.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Vxlan.html#gdbus-property-org-freedesktop-NetworkManager-Device-Vxlan.Learning for more information.
+		Learning() (bool, error)
+
+		// Ageing is the lifetime in seconds of FDB entries learnt by the kernel.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Vxlan.html#gdbus-property-org-freedesktop-NetworkManager-Device-Vxlan.Ageing for more information.
+		Ageing() (uint32, error)
+
+		// Limit is the maximum number of entries that can be added to the forwarding table.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Vxlan.html#gdbus-property-org-freedesktop-NetworkManager-Device-Vxlan.Limit for more information.
+		Limit() (uint32, error)
+
+		// DstPort is the destination port for outgoing VXLAN packets.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Vxlan.html#gdbus-property-org-freedesktop-NetworkManager-Device-Vxlan.DstPort for more information.
+		DstPort() (uint16, error)
+
+		// SrcPortMin is the lowest source port number to use for outgoing VXLAN packets.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Vxlan.html#gdbus-property-org-freedesktop-NetworkManager-Device-Vxlan.SrcPortMin for more information.
+		SrcPortMin() (uint16, error)
+
+		// SrcPortMax is the highest source port number to use for outgoing VXLAN packets.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Vxlan.html#gdbus-property-org-freedesktop-NetworkManager-Device-Vxlan.SrcPortMax for more information.
+		SrcPortMax() (uint16, error)
+
+		// Proxy tells if ARP proxy is turned on.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Vxlan.html#gdbus-property-org-freedesktop-NetworkManager-Device-Vxlan.Proxy for more information.
+		Proxy() (bool, error)
+
+		// Rsc tells if route short circuit is turned on.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Vxlan.html#gdbus-property-org-freedesktop-NetworkManager-Device-Vxlan.Rsc for more information.
+		Rsc() (bool, error)
+
+		// L2Miss tells if netlink LL ADDR miss notifications are generated.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Vxlan.html#gdbus-property-org-freedesktop-NetworkManager-Device-Vxlan.L2miss for more information.
+		L2Miss() (bool, error)
+
+		// L3Miss tells if netlink IP ADDR miss notifications are generated.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Vxlan.html#gdbus-property-org-freedesktop-NetworkManager-Device-Vxlan.L3miss for more information.
+		L3Miss() (bool, error)
+	}
+
+	vxlanDevice struct {
+		device
+	}
+)
+
+var _ VxlanDevice = (*vxlanDevice)(nil)
+
+func (*vxlanDevice) isVxlanDevice() {}
+
+func (d *vxlanDevice) HwAddress() (string, error) {
+	return d.GetSProperty(VxlanDeviceIface + ".HwAddress")
+}
+
+func (d *vxlanDevice) Parent() (Device, error) {
+	return d.parent(VxlanDeviceIface)
+}
+
+func (d *vxlanDevice) ID() (uint32, error) {
+	return d.GetUProperty(VxlanDeviceIface + ".Id")
+}
+
+func (d *vxlanDevice) Group() (string, error) {
+	return d.GetSProperty(VxlanDeviceIface + ".Group")
+}
+
+func (d *vxlanDevice) Local() (string, error) {
+	return d.GetSProperty(VxlanDeviceIface + ".Local")
+}
+
+func (d *vxlanDevice) Tos() (byte, error) {
+	return d.GetYProperty(VxlanDeviceIface + ".Tos")
+}
+
+func (d *vxlanDevice) TTL() (byte, error) {
+	return d.GetYProperty(VxlanDeviceIface + ".Ttl")
+}
+
+func (d *vxlanDevice) Learning() (bool, error) {
+	return d.GetBProperty(VxlanDeviceIface + ".Learning")
+}
+
+func (d *vxlanDevice) Ageing() (uint32, error) {
+	return d.GetUProperty(VxlanDeviceIface + ".Ageing")
+}
+
+func (d *vxlanDevice) Limit() (uint32, error) {
+	return d.GetUProperty(VxlanDeviceIface + ".Limit")
+}
+
+func (d *vxlanDevice) DstPort() (uint16, error) {
+	return d.GetQProperty(VxlanDeviceIface + ".DstPort")
+}
+
+func (d *vxlanDevice) SrcPortMin() (uint16, error) {
+	return d.GetQProperty(VxlanDeviceIface + ".SrcPortMin")
+}
+
+func (d *vxlanDevice) SrcPortMax() (uint16, error) {
+	return d.GetQProperty(VxlanDeviceIface + ".SrcPortMax")
+}
+
+func (d *vxlanDevice) Proxy() (bool, error) {
+	return d.GetBProperty(VxlanDeviceIface + ".Proxy")
+}
+
+func (d *vxlanDevice) Rsc() (bool, error) {
+	return d.GetBProperty(VxlanDeviceIface + ".Rsc")
+}
+
+func (d *vxlanDevice) L2Miss() (bool, error) {
+	return d.GetBProperty(VxlanDeviceIface + ".L2miss")
+}
+
+func (d *vxlanDevice) L3Miss() (bool, error) {
+	return d.GetBProperty(VxlanDeviceIface + ".L3miss")
+}
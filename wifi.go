@@ -0,0 +1,307 @@
+package netmgr
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nlepage/go-netmgr/internal/dbusext"
+)
+
+// WifiScanTimeout is the maximum time ConnectWifi waits for a scan to finish when the SSID is not visible.
+const WifiScanTimeout = 30 * time.Second
+
+var (
+	// ErrNoWirelessDevice is returned when no wireless device is available.
+	ErrNoWirelessDevice = errors.New("no wireless device")
+
+	// ErrSSIDNotFound is returned when no access point is found for an SSID.
+	ErrSSIDNotFound = errors.New("SSID not found")
+
+	// ErrEnterpriseSettingsRequired is returned when connecting to an enterprise network without 802-1x settings.
+	ErrEnterpriseSettingsRequired = errors.New("802-1x settings are required for enterprise networks")
+)
+
+// WifiKeyMgmt is the key management used by a Wi-Fi network, as in the key-mgmt property of the 802-11-wireless-security setting.
+//
+// See https://developer.gnome.org/NetworkManager/stable/settings-802-11-wireless-security.html for more information.
+type WifiKeyMgmt string
+
+const (
+	// WifiKeyMgmtOpen means the network is open, no 802-11-wireless-security setting is needed.
+	WifiKeyMgmtOpen WifiKeyMgmt = ""
+
+	// WifiKeyMgmtWEP means static WEP.
+	WifiKeyMgmtWEP WifiKeyMgmt = "none"
+
+	// WifiKeyMgmtOWE means Opportunistic Wireless Encryption.
+	WifiKeyMgmtOWE WifiKeyMgmt = "owe"
+
+	// WifiKeyMgmtWPAPSK means WPA2 + WPA3 personal.
+	WifiKeyMgmtWPAPSK WifiKeyMgmt = "wpa-psk"
+
+	// WifiKeyMgmtSAE means WPA3 personal only.
+	WifiKeyMgmtSAE WifiKeyMgmt = "sae"
+
+	// WifiKeyMgmtWPAEAP means WPA2 + WPA3 enterprise.
+	WifiKeyMgmtWPAEAP WifiKeyMgmt = "wpa-eap"
+
+	// WifiKeyMgmtWPAEAPSuiteB192 means WPA3 enterprise only.
+	WifiKeyMgmtWPAEAPSuiteB192 WifiKeyMgmt = "wpa-eap-suite-b-192"
+)
+
+// DetectWifiKeyMgmt returns the key management to use for an access point, from its flags.
+//
+// When an access point supports both WPA-PSK and SAE (WPA3 transition mode), WifiKeyMgmtWPAPSK is returned for compatibility.
+func DetectWifiKeyMgmt(flags AccessPointFlags, wpaFlags, rsnFlags AccessPointSecurityFlags) (WifiKeyMgmt, error) {
+	security := wpaFlags | rsnFlags
+
+	switch {
+	case security&AccessPointSecurityKeyMgmt8021X != 0:
+		return WifiKeyMgmtWPAEAP, nil
+	case security&AccessPointSecurityKeyMgmtEapSuiteB192 != 0:
+		return WifiKeyMgmtWPAEAPSuiteB192, nil
+	case security&AccessPointSecurityKeyMgmtPsk != 0:
+		return WifiKeyMgmtWPAPSK, nil
+	case security&AccessPointSecurityKeyMgmtSae != 0:
+		return WifiKeyMgmtSAE, nil
+	case security&(AccessPointSecurityKeyMgmtOwe|AccessPointSecurityKeyMgmtOweTm) != 0:
+		return WifiKeyMgmtOWE, nil
+	case security&^(AccessPointSecurityPairWep40|AccessPointSecurityPairWep104|AccessPointSecurityGroupWep40|AccessPointSecurityGroupWep104) == 0:
+		// no key management, the network is either open or uses static WEP
+		if flags&AccessPointFlagPrivacy != 0 {
+			return WifiKeyMgmtWEP, nil
+		}
+		return WifiKeyMgmtOpen, nil
+	}
+	return "", fmt.Errorf("unsupported access point security: %s", security)
+}
+
+// WifiConnectOptions are the options of ConnectWifi.
+type WifiConnectOptions struct {
+	// Device is the wireless device to use, nil to use the device seeing the access point with the best signal.
+	Device interface{}
+
+	// Password is the pre-shared key or WEP key of the network.
+	Password string
+
+	// Enterprise is the 802-1x setting used for enterprise networks.
+	Enterprise map[string]interface{}
+
+	// Persist is the "persist" option given to AddAndActivateConnection2, empty for NetworkManager's default (PersistDisk).
+	Persist Persist
+}
+
+// WifiConnectionSettings returns the settings of a profile connecting to ssid with keyMgmt.
+func WifiConnectionSettings(ssid string, keyMgmt WifiKeyMgmt, options WifiConnectOptions) (ConnectionSettings, error) {
+	settings := ConnectionSettings{
+		"connection": {
+			"id":   ssid,
+			"type": "802-11-wireless",
+		},
+		"802-11-wireless": {
+			"ssid": []byte(ssid),
+			"mode": "infrastructure",
+		},
+	}
+
+	security := map[string]interface{}{
+		"key-mgmt": string(keyMgmt),
+	}
+
+	switch keyMgmt {
+	case WifiKeyMgmtOpen:
+		return settings, nil
+	case WifiKeyMgmtWEP:
+		security["wep-key0"] = options.Password
+	case WifiKeyMgmtWPAPSK, WifiKeyMgmtSAE:
+		security["psk"] = options.Password
+	case WifiKeyMgmtWPAEAP, WifiKeyMgmtWPAEAPSuiteB192:
+		if options.Enterprise == nil {
+			return nil, ErrEnterpriseSettingsRequired
+		}
+		settings["802-1x"] = options.Enterprise
+	}
+
+	settings["802-11-wireless-security"] = security
+
+	return settings, nil
+}
+
+func (nm *networkManager) ConnectWifi(ctx context.Context, ssid string, options WifiConnectOptions) (SettingsConnection, ConnectionActive, error) {
+	device, accessPoint, err := nm.findAccessPoint(ctx, ssid, options.Device)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	flags, err := accessPoint.Flags()
+	if err != nil {
+		return nil, nil, err
+	}
+	wpaFlags, err := accessPoint.WpaFlags()
+	if err != nil {
+		return nil, nil, err
+	}
+	rsnFlags, err := accessPoint.RsnFlags()
+	if err != nil {
+		return nil, nil, err
+	}
+	keyMgmt, err := DetectWifiKeyMgmt(flags, wpaFlags, rsnFlags)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	settings, err := WifiConnectionSettings(ssid, keyMgmt, options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	addOptions := map[string]interface{}{}
+	if options.Persist != "" {
+		addOptions["persist"] = string(options.Persist)
+	}
+
+	settingsConnection, connectionActive, err := nm.AddAndActivateConnection2(settings, device, accessPoint, addOptions)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := connectionActive.WaitActivated(ctx); err != nil {
+		return settingsConnection, connectionActive, err
+	}
+
+	return settingsConnection, connectionActive, nil
+}
+
+// ConnectWifi connects to the Wi-Fi network ssid, and waits until the connection is activated.
+//
+// The key management is detected from the flags of the access point with the best signal.
+// A scan is requested if no access point is visible for ssid.
+func ConnectWifi(ctx context.Context, ssid string, options WifiConnectOptions) (SettingsConnection, ConnectionActive, error) {
+	nm, err := System()
+	if err != nil {
+		return nil, nil, err
+	}
+	return nm.ConnectWifi(ctx, ssid, options)
+}
+
+func (nm *networkManager) wirelessDevices(device interface{}) ([]WirelessDevice, error) {
+	if device != nil {
+		if wd, ok := device.(WirelessDevice); ok {
+			return []WirelessDevice{wd}, nil
+		}
+		path, err := dbusext.ObjectPath(device)
+		if err != nil {
+			return nil, err
+		}
+		d := NewDevice(nm.Conn, path)
+		wd, ok := d.(WirelessDevice)
+		if !ok {
+			return nil, fmt.Errorf("%s is not a wireless device", path)
+		}
+		return []WirelessDevice{wd}, nil
+	}
+
+	devices, err := nm.GetDevices()
+	if err != nil {
+		return nil, err
+	}
+	var wirelessDevices []WirelessDevice
+	for _, d := range devices {
+		if wd, ok := d.(WirelessDevice); ok {
+			wirelessDevices = append(wirelessDevices, wd)
+		}
+	}
+	if len(wirelessDevices) == 0 {
+		return nil, ErrNoWirelessDevice
+	}
+	return wirelessDevices, nil
+}
+
+func (nm *networkManager) findAccessPoint(ctx context.Context, ssid string, device interface{}) (WirelessDevice, AccessPoint, error) {
+	devices, err := nm.wirelessDevices(device)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	d, ap, err := bestAccessPoint(devices, ssid)
+	if err != nil || ap != nil {
+		return d, ap, err
+	}
+
+	if err := nm.scan(ctx, devices, ssid); err != nil {
+		return nil, nil, err
+	}
+
+	d, ap, err = bestAccessPoint(devices, ssid)
+	if err != nil {
+		return nil, nil, err
+	}
+	if ap == nil {
+		return nil, nil, fmt.Errorf("%w: %s", ErrSSIDNotFound, ssid)
+	}
+	return d, ap, nil
+}
+
+func bestAccessPoint(devices []WirelessDevice, ssid string) (WirelessDevice, AccessPoint, error) {
+	var (
+		bestDevice      WirelessDevice
+		bestAccessPoint AccessPoint
+		bestStrength    uint8
+	)
+
+	for _, d := range devices {
+		accessPoints, err := d.GetAllAccessPoints()
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, ap := range accessPoints {
+			apSsid, err := ap.Ssid()
+			if err != nil {
+				// access points may disappear at any time
+				continue
+			}
+			if !bytes.Equal(apSsid, []byte(ssid)) {
+				continue
+			}
+			strength, err := ap.Strength()
+			if err != nil {
+				continue
+			}
+			if bestAccessPoint == nil || strength > bestStrength {
+				bestDevice, bestAccessPoint, bestStrength = d, ap, strength
+			}
+		}
+	}
+
+	return bestDevice, bestAccessPoint, nil
+}
+
+// scan requests a scan of ssid on devices, and waits for one of the scans to finish, at most WifiScanTimeout.
+func (nm *networkManager) scan(ctx context.Context, devices []WirelessDevice, ssid string) error {
+	scanned := make(chan int64)
+	for _, d := range devices {
+		if err := d.LastScanChanged(scanned); err != nil {
+			return err
+		}
+		defer dbusext.RemoveSignal(nm.Conn, d.Path(), dbusext.PropertiesIface, "PropertiesChanged", scanned)
+	}
+
+	for _, d := range devices {
+		// a scan is refused if one is already running, which is fine
+		d.RequestScan(map[string]interface{}{"ssids": [][]byte{[]byte(ssid)}})
+	}
+
+	timer := time.NewTimer(WifiScanTimeout)
+	defer timer.Stop()
+
+	select {
+	case <-scanned:
+	case <-timer.C:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+package netmgr
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+
+	"github.com/nlepage/go-netmgr/internal/dbusext"
+)
+
+// WifiP2PDeviceIface is the Wi-Fi P2P Device interface.
+const WifiP2PDeviceIface = "org.freedesktop.NetworkManager.Device.WifiP2P"
+
+// WifiP2PMaxFindTimeout is the longest timeout accepted by StartFind.
+const WifiP2PMaxFindTimeout = 600 * time.Second
+
+type (
+	// WifiP2PDevice represents a Wi-Fi P2P device.
+	//
+	// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.WifiP2P.html for more information.
+	WifiP2PDevice interface {
+		Device
+		isWifiP2PDevice()
+
+		// Methods
+
+		// StartFind starts a find operation for Wi-Fi P2P peers, which stops after timeout, 0 for the default of NetworkManager (30 seconds).
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.WifiP2P.html#gdbus-method-org-freedesktop-NetworkManager-Device-WifiP2P.StartFind for more information.
+		StartFind(timeout time.Duration) error
+
+		// StopFind stops an ongoing find operation.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.WifiP2P.html#gdbus-method-org-freedesktop-NetworkManager-Device-WifiP2P.StopFind for more information.
+		StopFind() error
+
+		// Properties
+
+		// HwAddress is the active hardware address of the device.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.WifiP2P.html#gdbus-property-org-freedesktop-NetworkManager-Device-WifiP2P.HwAddress for more information.
+		HwAddress() (string, error)
+
+		// Peers is the list of peers visible to this device.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.WifiP2P.html#gdbus-property-org-freedesktop-NetworkManager-Device-WifiP2P.Peers for more information.
+		Peers() ([]WifiP2PPeer, error)
+
+		// Signals
+
+		// PeerAdded is emitted when a new Wi-Fi P2P peer is found by the device.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.WifiP2P.html#gdbus-signal-org-freedesktop-NetworkManager-Device-WifiP2P.PeerAdded for more information.
+		PeerAdded(ch chan<- WifiP2PPeer) error
+
+		// PeerRemoved is emitted when a Wi-Fi P2P peer disappears from view of the device.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.WifiP2P.html#gdbus-signal-org-freedesktop-NetworkManager-Device-WifiP2P.PeerRemoved for more information.
+		PeerRemoved(ch chan<- WifiP2PPeer) error
+	}
+
+	wifiP2PDevice struct {
+		device
+	}
+)
+
+var _ WifiP2PDevice = (*wifiP2PDevice)(nil)
+
+func (*wifiP2PDevice) isWifiP2PDevice() {}
+
+func (d *wifiP2PDevice) StartFind(timeout time.Duration) error {
+	options := map[string]interface{}{}
+	if timeout != 0 {
+		if timeout < time.Second || timeout > WifiP2PMaxFindTimeout {
+			return fmt.Errorf("find timeout must be between 1s and %s", WifiP2PMaxFindTimeout)
+		}
+		options["timeout"] = int32(timeout / time.Second)
+	}
+	return d.CallAndStore(WifiP2PDeviceIface+".StartFind", dbusext.Args{options}, nil)
+}
+
+func (d *wifiP2PDevice) StopFind() error {
+	return d.CallAndStore(WifiP2PDeviceIface+".StopFind", nil, nil)
+}
+
+func (d *wifiP2PDevice) HwAddress() (string, error) {
+	return d.GetSProperty(WifiP2PDeviceIface + ".HwAddress")
+}
+
+func (d *wifiP2PDevice) Peers() ([]WifiP2PPeer, error) {
+	paths, err := d.GetAOProperty(WifiP2PDeviceIface + ".Peers")
+	if err != nil {
+		return nil, err
+	}
+	return NewWifiP2PPeers(d.Conn, paths), nil
+}
+
+func (d *wifiP2PDevice) PeerAdded(ch chan<- WifiP2PPeer) error {
+	return d.peerSignal("PeerAdded", ch)
+}
+
+func (d *wifiP2PDevice) PeerRemoved(ch chan<- WifiP2PPeer) error {
+	return d.peerSignal("PeerRemoved", ch)
+}
+
+func (d *wifiP2PDevice) peerSignal(member string, ch chan<- WifiP2PPeer) error {
+	return d.Signal(WifiP2PDeviceIface, member, reflect.TypeOf(dbus.ObjectPath("")), ch, func(path dbus.ObjectPath) WifiP2PPeer {
+		return NewWifiP2PPeer(d.Conn, path)
+	})
+}
+
+// WifiP2PConnectionSettings returns the settings of a profile connecting to the Wi-Fi P2P peer with hardware address hwAddress.
+func WifiP2PConnectionSettings(id, hwAddress string) ConnectionSettings {
+	return ConnectionSettings{
+		"connection": {
+			"id":          id,
+			"type":        "wifi-p2p",
+			"autoconnect": false,
+		},
+		"wifi-p2p": {
+			"peer": hwAddress,
+		},
+	}
+}
+
+func (nm *networkManager) ConnectWifiP2P(ctx context.Context, device WifiP2PDevice, peer WifiP2PPeer) (SettingsConnection, ConnectionActive, error) {
+	hwAddress, err := peer.HwAddress()
+	if err != nil {
+		return nil, nil, err
+	}
+	name, err := peer.Name()
+	if err != nil {
+		return nil, nil, err
+	}
+	if name == "" {
+		name = hwAddress
+	}
+
+	// the profile is deleted by NetworkManager when it is deactivated, including when activation fails
+	addOptions := map[string]interface{}{"persist": string(PersistVolatile)}
+
+	settingsConnection, connectionActive, err := nm.AddAndActivateConnection2(WifiP2PConnectionSettings(name, hwAddress), device, peer, addOptions)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := connectionActive.WaitActivated(ctx); err != nil {
+		return settingsConnection, connectionActive, err
+	}
+
+	return settingsConnection, connectionActive, nil
+}
+
+// ConnectWifiP2P connects device to the Wi-Fi P2P peer, as found by WifiP2PDevice.StartFind, and waits until the connection is activated.
+//
+// The profile is volatile: it is not saved to disk, and NetworkManager deletes it once the connection is deactivated.
+func ConnectWifiP2P(ctx context.Context, device WifiP2PDevice, peer WifiP2PPeer) (SettingsConnection, ConnectionActive, error) {
+	nm, err := System()
+	if err != nil {
+		return nil, nil, err
+	}
+	return nm.ConnectWifiP2P(ctx, device, peer)
+}
@@ -0,0 +1,132 @@
+package netmgr
+
+import (
+	"github.com/godbus/dbus/v5"
+
+	"github.com/nlepage/go-netmgr/internal/dbusext"
+)
+
+// WifiP2PPeerIface is the Wi-Fi P2P Peer interface.
+const WifiP2PPeerIface = "org.freedesktop.NetworkManager.WifiP2PPeer"
+
+type (
+	// WifiP2PPeer represents a Wi-Fi P2P peer.
+	//
+	// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.WifiP2PPeer.html for more information.
+	WifiP2PPeer interface {
+		dbus.BusObject
+
+		// Properties
+
+		// Name is the name of the peer.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.WifiP2PPeer.html#gdbus-property-org-freedesktop-NetworkManager-WifiP2PPeer.Name for more information.
+		Name() (string, error)
+
+		// Flags describes the capabilities of the peer.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.WifiP2PPeer.html#gdbus-property-org-freedesktop-NetworkManager-WifiP2PPeer.Flags for more information.
+		Flags() (AccessPointFlags, error)
+
+		// Manufacturer is the manufacturer of the peer.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.WifiP2PPeer.html#gdbus-property-org-freedesktop-NetworkManager-WifiP2PPeer.Manufacturer for more information.
+		Manufacturer() (string, error)
+
+		// Model is the model of the peer.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.WifiP2PPeer.html#gdbus-property-org-freedesktop-NetworkManager-WifiP2PPeer.Model for more information.
+		Model() (string, error)
+
+		// ModelNumber is the model number of the peer.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.WifiP2PPeer.html#gdbus-property-org-freedesktop-NetworkManager-WifiP2PPeer.ModelNumber for more information.
+		ModelNumber() (string, error)
+
+		// Serial is the serial number of the peer.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.WifiP2PPeer.html#gdbus-property-org-freedesktop-NetworkManager-WifiP2PPeer.Serial for more information.
+		Serial() (string, error)
+
+		// WfdIEs are the Wi-Fi Display Information Elements of the peer.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.WifiP2PPeer.html#gdbus-property-org-freedesktop-NetworkManager-WifiP2PPeer.WfdIEs for more information.
+		WfdIEs() ([]byte, error)
+
+		// HwAddress is the hardware address (BSSID) of the peer.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.WifiP2PPeer.html#gdbus-property-org-freedesktop-NetworkManager-WifiP2PPeer.HwAddress for more information.
+		HwAddress() (string, error)
+
+		// Strength is the current signal quality of the peer, in percent.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.WifiP2PPeer.html#gdbus-property-org-freedesktop-NetworkManager-WifiP2PPeer.Strength for more information.
+		Strength() (uint8, error)
+
+		// LastSeen is the timestamp (in CLOCK_BOOTTIME seconds) for the last time the peer was found in scan results, -1 if never found.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.WifiP2PPeer.html#gdbus-property-org-freedesktop-NetworkManager-WifiP2PPeer.LastSeen for more information.
+		LastSeen() (int32, error)
+	}
+
+	wifiP2PPeer struct {
+		dbusext.BusObject
+	}
+)
+
+var _ WifiP2PPeer = (*wifiP2PPeer)(nil)
+
+// NewWifiP2PPeer returns the WifiP2PPeer from conn corresponding to path.
+func NewWifiP2PPeer(conn *dbus.Conn, path dbus.ObjectPath) WifiP2PPeer {
+	return &wifiP2PPeer{dbusext.NewBusObject(conn, BusName, path)}
+}
+
+// NewWifiP2PPeers returns the slice of WifiP2PPeer from conn corresponding to paths.
+func NewWifiP2PPeers(conn *dbus.Conn, paths []dbus.ObjectPath) []WifiP2PPeer {
+	peers := make([]WifiP2PPeer, len(paths))
+	for i, path := range paths {
+		peers[i] = NewWifiP2PPeer(conn, path)
+	}
+	return peers
+}
+
+func (p *wifiP2PPeer) Name() (string, error) {
+	return p.GetSProperty(WifiP2PPeerIface + ".Name")
+}
+
+func (p *wifiP2PPeer) Flags() (AccessPointFlags, error) {
+	flags, err := p.GetUProperty(WifiP2PPeerIface + ".Flags")
+	return AccessPointFlags(flags), err
+}
+
+func (p *wifiP2PPeer) Manufacturer() (string, error) {
+	return p.GetSProperty(WifiP2PPeerIface + ".Manufacturer")
+}
+
+func (p *wifiP2PPeer) Model() (string, error) {
+	return p.GetSProperty(WifiP2PPeerIface + ".Model")
+}
+
+func (p *wifiP2PPeer) ModelNumber() (string, error) {
+	return p.GetSProperty(WifiP2PPeerIface + ".ModelNumber")
+}
+
+func (p *wifiP2PPeer) Serial() (string, error) {
+	return p.GetSProperty(WifiP2PPeerIface + ".Serial")
+}
+
+func (p *wifiP2PPeer) WfdIEs() ([]byte, error) {
+	return p.GetAYProperty(WifiP2PPeerIface + ".WfdIEs")
+}
+
+func (p *wifiP2PPeer) HwAddress() (string, error) {
+	return p.GetSProperty(WifiP2PPeerIface + ".HwAddress")
+}
+
+func (p *wifiP2PPeer) Strength() (uint8, error) {
+	return p.GetYProperty(WifiP2PPeerIface + ".Strength")
+}
+
+func (p *wifiP2PPeer) LastSeen() (int32, error) {
+	return p.GetIProperty(WifiP2PPeerIface + ".LastSeen")
+}
@@ -0,0 +1,136 @@
+package netmgr
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/nlepage/go-netmgr/internal/dbusext"
+)
+
+// WiredDeviceIface is the Wired Device interface.
+const WiredDeviceIface = "org.freedesktop.NetworkManager.Device.Wired"
+
+type (
+	// WiredDevice represents an Ethernet device.
+	//
+	// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Wired.html for more information.
+	WiredDevice interface {
+		Device
+		isWiredDevice()
+
+		// Properties
+
+		// HwAddress is the active hardware address of the device.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Wired.html#gdbus-property-org-freedesktop-NetworkManager-Device-Wired.HwAddress for more information.
+		HwAddress() (string, error)
+
+		// PermHwAddress is the permanent hardware address of the device.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Wired.html#gdbus-property-org-freedesktop-NetworkManager-Device-Wired.PermHwAddress for more information.
+		PermHwAddress() (string, error)
+
+		// Speed is the design speed of the device, in megabits/second (Mb/s).
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Wired.html#gdbus-property-org-freedesktop-NetworkManager-Device-Wired.Speed for more information.
+		Speed() (uint32, error)
+
+		// S390Subchannels are the IBM s390 subchannels of the device, empty on other platforms.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Wired.html#gdbus-property-org-freedesktop-NetworkManager-Device-Wired.S390Subchannels for more information.
+		S390Subchannels() ([]string, error)
+
+		// Carrier tells if the device has a carrier, i.e. if a cable is plugged and the link is up.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Wired.html#gdbus-property-org-freedesktop-NetworkManager-Device-Wired.Carrier for more information.
+		Carrier() (bool, error)
+
+		// Properties changes
+
+		// CarrierChanged sends the new Carrier value to ch each time it changes.
+		CarrierChanged(ch chan<- bool) error
+
+		// Helpers
+
+		// WatchCarrier returns a channel receiving the current Carrier value, then each change, until ctx is done.
+		WatchCarrier(ctx context.Context) (<-chan bool, error)
+	}
+
+	wiredDevice struct {
+		device
+	}
+)
+
+var _ WiredDevice = (*wiredDevice)(nil)
+
+func (*wiredDevice) isWiredDevice() {}
+
+func (d *wiredDevice) HwAddress() (string, error) {
+	return d.GetSProperty(WiredDeviceIface + ".HwAddress")
+}
+
+func (d *wiredDevice) PermHwAddress() (string, error) {
+	return d.GetSProperty(WiredDeviceIface + ".PermHwAddress")
+}
+
+func (d *wiredDevice) Speed() (uint32, error) {
+	return d.GetUProperty(WiredDeviceIface + ".Speed")
+}
+
+func (d *wiredDevice) S390Subchannels() ([]string, error) {
+	return d.GetASProperty(WiredDeviceIface + ".S390Subchannels")
+}
+
+func (d *wiredDevice) Carrier() (bool, error) {
+	return d.GetBProperty(WiredDeviceIface + ".Carrier")
+}
+
+func (d *wiredDevice) CarrierChanged(ch chan<- bool) error {
+	return d.PropertyChanged(WiredDeviceIface, "Carrier", reflect.TypeOf(false), ch, nil)
+}
+
+func (d *wiredDevice) WatchCarrier(ctx context.Context) (<-chan bool, error) {
+	return watchCarrier(ctx, &d.device, d.Carrier, d.CarrierChanged)
+}
+
+// watchCarrier implements WatchCarrier for the devices having a Carrier property.
+func watchCarrier(ctx context.Context, d *device, carrier func() (bool, error), carrierChanged func(chan<- bool) error) (<-chan bool, error) {
+	changes := make(chan bool)
+	if err := carrierChanged(changes); err != nil {
+		return nil, err
+	}
+
+	// subscribe first, so that no change is missed between reading the current value and watching changes
+	current, err := carrier()
+	if err != nil {
+		dbusext.RemoveSignal(d.Conn, d.Path(), dbusext.PropertiesIface, "PropertiesChanged", changes)
+		return nil, err
+	}
+
+	ch := make(chan bool, 1)
+	ch <- current
+
+	go func() {
+		defer close(ch)
+		defer dbusext.RemoveSignal(d.Conn, d.Path(), dbusext.PropertiesIface, "PropertiesChanged", changes)
+		last := current
+		for {
+			select {
+			case c := <-changes:
+				if c == last {
+					continue
+				}
+				last = c
+				select {
+				case ch <- c:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch, nil
+}
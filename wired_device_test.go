@@ -0,0 +1,65 @@
+package netmgr
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/godbus/dbus/v5/prop"
+)
+
+func TestWatchCarrierCancelWhileSubscribing(t *testing.T) {
+	conn := privateBus(t)
+
+	const devicePath = dbus.ObjectPath(NetworkManagerPath + "/Devices/1")
+
+	props := export(t, conn, BusName, devicePath, map[string]map[string]*prop.Prop{
+		DeviceIface:      {"DeviceType": {Value: uint32(DeviceTypeEthernet)}},
+		WiredDeviceIface: {"Carrier": {Value: true, Emit: prop.EmitTrue}},
+	})
+
+	d := NewDevice(conn, devicePath).(*wiredDevice)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch, err := d.WatchCarrier(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if carrier := <-ch; !carrier {
+		t.Fatal("expected the current carrier first")
+	}
+
+	// ch is not read anymore, so the watch blocks, then the dispatcher blocks sending the last change to the watch
+	for _, carrier := range []bool{false, true, false} {
+		props.SetMust(WiredDeviceIface, "Carrier", carrier)
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	subscribed := make(chan error, 1)
+	go func() {
+		subscribed <- d.PropertyChanged(DeviceIface, "Driver", reflect.TypeOf(""), make(chan string), nil)
+	}()
+	time.Sleep(100 * time.Millisecond)
+	cancel()
+
+	timeout := time.After(5 * time.Second)
+	for closed := false; !closed; {
+		select {
+		case _, ok := <-ch:
+			closed = !ok
+		case <-timeout:
+			t.Fatal("the watch did not stop while subscribing")
+		}
+	}
+	select {
+	case err := <-subscribed:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-timeout:
+		t.Fatal("subscribing did not complete after the watch stopped")
+	}
+}
@@ -0,0 +1,237 @@
+package netmgr
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+
+	"golang.org/x/crypto/curve25519"
+)
+
+// WireGuardKey is a WireGuard private, public or preshared key.
+type WireGuardKey [32]byte
+
+// GenerateWireGuardPrivateKey returns a new random private key.
+func GenerateWireGuardPrivateKey() (WireGuardKey, error) {
+	var key WireGuardKey
+	if _, err := rand.Read(key[:]); err != nil {
+		return key, err
+	}
+	// clamp the key, as wg genkey does
+	key[0] &= 248
+	key[31] = (key[31] & 127) | 64
+	return key, nil
+}
+
+// GenerateWireGuardPresharedKey returns a new random preshared key.
+func GenerateWireGuardPresharedKey() (WireGuardKey, error) {
+	var key WireGuardKey
+	_, err := rand.Read(key[:])
+	return key, err
+}
+
+// ParseWireGuardKey returns the key encoded in base64 by s, as used by the wg tool and NetworkManager.
+func ParseWireGuardKey(s string) (WireGuardKey, error) {
+	var key WireGuardKey
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return key, fmt.Errorf("invalid WireGuard key: %w", err)
+	}
+	if len(b) != len(key) {
+		return key, fmt.Errorf("invalid WireGuard key: %d bytes, expected %d", len(b), len(key))
+	}
+	copy(key[:], b)
+	return key, nil
+}
+
+// PublicKey returns the public key corresponding to the private key k.
+func (k WireGuardKey) PublicKey() WireGuardKey {
+	var public WireGuardKey
+	curve25519.ScalarBaseMult((*[32]byte)(&public), (*[32]byte)(&k))
+	return public
+}
+
+// IsZero tells if k is the zero key, meaning no key.
+func (k WireGuardKey) IsZero() bool {
+	return k == WireGuardKey{}
+}
+
+// String returns k encoded in base64.
+func (k WireGuardKey) String() string {
+	return base64.StdEncoding.EncodeToString(k[:])
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (k WireGuardKey) MarshalText() ([]byte, error) {
+	return []byte(k.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (k *WireGuardKey) UnmarshalText(text []byte) error {
+	v, err := ParseWireGuardKey(string(text))
+	if err != nil {
+		return err
+	}
+	*k = v
+	return nil
+}
+
+type (
+	// WireGuardConfig is the configuration of a WireGuard connection profile.
+	//
+	// See https://developer.gnome.org/NetworkManager/stable/settings-wireguard.html for more information.
+	WireGuardConfig struct {
+		// InterfaceName is the name of the WireGuard interface, such as "wg0".
+		InterfaceName string
+
+		// PrivateKey is the private key of the interface.
+		PrivateKey WireGuardKey
+
+		// ListenPort is the local UDP port, 0 to choose one randomly.
+		ListenPort uint16
+
+		// FwMark is the firewall mark of the packets sent by the interface, 0 if none.
+		FwMark uint32
+
+		// MTU is the MTU of the interface, 0 to use the default.
+		MTU uint32
+
+		// Addresses are the IPv4 and IPv6 addresses of the interface, with their prefix length, such as "10.0.0.2/24".
+		Addresses []string
+
+		// Peers are the peers of the interface.
+		Peers []WireGuardPeer
+	}
+
+	// WireGuardPeer is a peer of a WireGuard connection profile.
+	WireGuardPeer struct {
+		// PublicKey is the public key of the peer.
+		PublicKey WireGuardKey
+
+		// PresharedKey is the preshared key shared with the peer, zero if none.
+		PresharedKey WireGuardKey
+
+		// Endpoint is the address of the peer, as "host:port", empty if the peer connects first.
+		Endpoint string
+
+		// AllowedIPs are the networks routed to the peer, such as "0.0.0.0/0".
+		AllowedIPs []string
+
+		// PersistentKeepalive is the interval in seconds of the keepalive packets sent to the peer, 0 to disable them.
+		PersistentKeepalive uint16
+	}
+)
+
+// WireGuardConnectionSettings returns the settings of a WireGuard profile named id, which may be given to AddAndActivateConnection2.
+func WireGuardConnectionSettings(id string, config WireGuardConfig) (ConnectionSettings, error) {
+	if config.InterfaceName == "" {
+		return nil, errors.New("an interface name is required")
+	}
+	if config.PrivateKey.IsZero() {
+		return nil, errors.New("a private key is required")
+	}
+
+	var addresses4, addresses6 []map[string]interface{}
+	for _, address := range config.Addresses {
+		ip, ipNet, err := net.ParseCIDR(address)
+		if err != nil {
+			return nil, err
+		}
+		prefix, _ := ipNet.Mask.Size()
+		addressData := map[string]interface{}{
+			"address": ip.String(),
+			"prefix":  uint32(prefix),
+		}
+		if ip.To4() != nil {
+			addresses4 = append(addresses4, addressData)
+		} else {
+			addresses6 = append(addresses6, addressData)
+		}
+	}
+
+	peers := make([]map[string]interface{}, 0, len(config.Peers))
+	for _, peer := range config.Peers {
+		p, err := peer.encode()
+		if err != nil {
+			return nil, err
+		}
+		peers = append(peers, p)
+	}
+
+	wireguard := map[string]interface{}{
+		"private-key": config.PrivateKey.String(),
+		"peers":       peers,
+	}
+	if config.ListenPort != 0 {
+		wireguard["listen-port"] = uint32(config.ListenPort)
+	}
+	if config.FwMark != 0 {
+		wireguard["fwmark"] = config.FwMark
+	}
+	if config.MTU != 0 {
+		wireguard["mtu"] = config.MTU
+	}
+
+	settings := ConnectionSettings{
+		"connection": {
+			"id":             id,
+			"type":           "wireguard",
+			"interface-name": config.InterfaceName,
+		},
+		"wireguard": wireguard,
+		"ipv4": {
+			"method": "disabled",
+		},
+		"ipv6": {
+			"method": "ignore",
+		},
+	}
+	if len(addresses4) != 0 {
+		settings["ipv4"] = map[string]interface{}{
+			"method":       "manual",
+			"address-data": addresses4,
+		}
+	}
+	if len(addresses6) != 0 {
+		settings["ipv6"] = map[string]interface{}{
+			"method":       "manual",
+			"address-data": addresses6,
+		}
+	}
+
+	return settings, nil
+}
+
+// encode returns the D-Bus representation of p, as an element of the peers property of the wireguard setting.
+func (p WireGuardPeer) encode() (map[string]interface{}, error) {
+	if p.PublicKey.IsZero() {
+		return nil, errors.New("a public key is required for each peer")
+	}
+	for _, allowedIP := range p.AllowedIPs {
+		if _, _, err := net.ParseCIDR(allowedIP); err != nil {
+			return nil, err
+		}
+	}
+
+	peer := map[string]interface{}{
+		"public-key":  p.PublicKey.String(),
+		"allowed-ips": append([]string{}, p.AllowedIPs...),
+	}
+	if !p.PresharedKey.IsZero() {
+		peer["preshared-key"] = p.PresharedKey.String()
+		// store the preshared key in the profile, instead of asking it to a secret agent
+		peer["preshared-key-flags"] = uint32(0)
+	}
+	if p.Endpoint != "" {
+		if _, _, err := net.SplitHostPort(p.Endpoint); err != nil {
+			return nil, fmt.Errorf("invalid endpoint of peer %s: %w", p.PublicKey, err)
+		}
+		peer["endpoint"] = p.Endpoint
+	}
+	if p.PersistentKeepalive != 0 {
+		peer["persistent-keepalive"] = uint32(p.PersistentKeepalive)
+	}
+	return peer, nil
+}
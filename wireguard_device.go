@@ -0,0 +1,57 @@
+package netmgr
+
+// WireGuardDeviceIface is the WireGuard Device interface.
+const WireGuardDeviceIface = "org.freedesktop.NetworkManager.Device.WireGuard"
+
+type (
+	// WireGuardDevice represents a WireGuard device.
+	//
+	// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.WireGuard.html for more information.
+	WireGuardDevice interface {
+		Device
+		isWireGuardDevice()
+
+		// Properties
+
+		// PublicKey is the public key of the device.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.WireGuard.html#gdbus-property-org-freedesktop-NetworkManager-Device-WireGuard.PublicKey for more information.
+		PublicKey() (WireGuardKey, error)
+
+		// ListenPort is the local UDP port of the device, 0 if chosen randomly.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.WireGuard.html#gdbus-property-org-freedesktop-NetworkManager-Device-WireGuard.ListenPort for more information.
+		ListenPort() (uint16, error)
+
+		// FwMark is the firewall mark of the packets sent by the device, 0 if none.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.WireGuard.html#gdbus-property-org-freedesktop-NetworkManager-Device-WireGuard.FwMark for more information.
+		FwMark() (uint32, error)
+	}
+
+	wireGuardDevice struct {
+		device
+	}
+)
+
+var _ WireGuardDevice = (*wireGuardDevice)(nil)
+
+func (*wireGuardDevice) isWireGuardDevice() {}
+
+func (d *wireGuardDevice) PublicKey() (WireGuardKey, error) {
+	var key WireGuardKey
+	publicKey, err := d.GetAYProperty(WireGuardDeviceIface + ".PublicKey")
+	if err != nil {
+		return key, err
+	}
+	copy(key[:], publicKey)
+	return key, nil
+}
+
+func (d *wireGuardDevice) ListenPort() (uint16, error) {
+	return d.GetQProperty(WireGuardDeviceIface + ".ListenPort")
+}
+
+func (d *wireGuardDevice) FwMark() (uint32, error) {
+	return d.GetUProperty(WireGuardDeviceIface + ".FwMark")
+}
@@ -0,0 +1,59 @@
+package netmgr
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+func TestWireGuardKeyPublicKey(t *testing.T) {
+	// test vector of RFC 7748 section 6.1
+	var private WireGuardKey
+	hex.Decode(private[:], []byte("77076d0a7318a57d3c16c17251b26645df4c2f87ebc0992ab177fba51db92c2a"))
+
+	public := private.PublicKey()
+	if got := hex.EncodeToString(public[:]); got != "8520f0098930a754748b7ddcb43ef75a0dbf3a0d26381af4eba4a98eaa9b4e6a" {
+		t.Errorf("PublicKey() = %s", got)
+	}
+
+	parsed, err := ParseWireGuardKey(private.String())
+	if err != nil || parsed != private {
+		t.Errorf("ParseWireGuardKey(%s) = %s, %v", private, parsed, err)
+	}
+}
+
+func TestWireGuardConnectionSettings(t *testing.T) {
+	privateKey, err := GenerateWireGuardPrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	peerKey, _ := GenerateWireGuardPrivateKey()
+	presharedKey, _ := GenerateWireGuardPresharedKey()
+
+	settings, err := WireGuardConnectionSettings("site", WireGuardConfig{
+		InterfaceName: "wg0",
+		PrivateKey:    privateKey,
+		Addresses:     []string{"10.0.0.2/24", "fd00::2/64"},
+		Peers: []WireGuardPeer{{
+			PublicKey:           peerKey.PublicKey(),
+			PresharedKey:        presharedKey,
+			Endpoint:            "vpn.example.com:51820",
+			AllowedIPs:          []string{"10.0.0.0/24"},
+			PersistentKeepalive: 25,
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if settings["ipv4"]["method"] != "manual" || settings["ipv6"]["method"] != "manual" {
+		t.Errorf("unexpected IP settings %v %v", settings["ipv4"], settings["ipv6"])
+	}
+	peers := settings["wireguard"]["peers"].([]map[string]interface{})
+	if len(peers) != 1 || peers[0]["public-key"] != peerKey.PublicKey().String() || peers[0]["persistent-keepalive"] != uint32(25) {
+		t.Errorf("unexpected peers %v", peers)
+	}
+
+	if _, err := WireGuardConnectionSettings("site", WireGuardConfig{InterfaceName: "wg0", PrivateKey: privateKey, Peers: []WireGuardPeer{{PublicKey: peerKey, Endpoint: "no-port"}}}); err == nil {
+		t.Errorf("expected an error for an endpoint without port")
+	}
+}
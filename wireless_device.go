@@ -0,0 +1,268 @@
+package netmgr
+
+import (
+	"reflect"
+
+	"github.com/godbus/dbus/v5"
+
+	"github.com/nlepage/go-netmgr/internal/dbusext"
+	"github.com/nlepage/go-netmgr/internal/enums"
+)
+
+// WirelessDeviceIface is the Wireless Device interface.
+const WirelessDeviceIface = "org.freedesktop.NetworkManager.Device.Wireless"
+
+type (
+	// WirelessDevice represents a Wi-Fi device.
+	//
+	// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Wireless.html for more information.
+	WirelessDevice interface {
+		Device
+		isWirelessDevice()
+
+		// Methods
+
+		// GetAccessPoints gets the list of access points visible to this device, hidden access points are not included.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Wireless.html#gdbus-method-org-freedesktop-NetworkManager-Device-Wireless.GetAccessPoints for more information.
+		GetAccessPoints() ([]AccessPoint, error)
+
+		// GetAllAccessPoints gets the list of all access points visible to this device, including hidden ones.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Wireless.html#gdbus-method-org-freedesktop-NetworkManager-Device-Wireless.GetAllAccessPoints for more information.
+		GetAllAccessPoints() ([]AccessPoint, error)
+
+		// RequestScan requests a scan of the available access points.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Wireless.html#gdbus-method-org-freedesktop-NetworkManager-Device-Wireless.RequestScan for more information.
+		RequestScan(options map[string]interface{}) error
+
+		// Properties
+
+		// HwAddress is the active hardware address of the device.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Wireless.html#gdbus-property-org-freedesktop-NetworkManager-Device-Wireless.HwAddress for more information.
+		HwAddress() (string, error)
+
+		// PermHwAddress is the permanent hardware address of the device.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Wireless.html#gdbus-property-org-freedesktop-NetworkManager-Device-Wireless.PermHwAddress for more information.
+		PermHwAddress() (string, error)
+
+		// Mode is the operating mode of the wireless device.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Wireless.html#gdbus-property-org-freedesktop-NetworkManager-Device-Wireless.Mode for more information.
+		Mode() (Wifi80211Mode, error)
+
+		// Bitrate is the bit rate currently used by the wireless device, in kilobits/second (Kb/s).
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Wireless.html#gdbus-property-org-freedesktop-NetworkManager-Device-Wireless.Bitrate for more information.
+		Bitrate() (uint32, error)
+
+		// AccessPoints is the list of access point objects visible to this wireless device.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Wireless.html#gdbus-property-org-freedesktop-NetworkManager-Device-Wireless.AccessPoints for more information.
+		AccessPoints() ([]AccessPoint, error)
+
+		// ActiveAccessPoint is the access point currently used by the wireless device, nil if none.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Wireless.html#gdbus-property-org-freedesktop-NetworkManager-Device-Wireless.ActiveAccessPoint for more information.
+		ActiveAccessPoint() (AccessPoint, error)
+
+		// WirelessCapabilities are the capabilities of the wireless device.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Wireless.html#gdbus-property-org-freedesktop-NetworkManager-Device-Wireless.WirelessCapabilities for more information.
+		WirelessCapabilities() (WifiDeviceCapabilities, error)
+
+		// LastScan is the timestamp (in CLOCK_BOOTTIME milliseconds) for the last finished network scan, -1 if no scan was done.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Wireless.html#gdbus-property-org-freedesktop-NetworkManager-Device-Wireless.LastScan for more information.
+		LastScan() (int64, error)
+
+		// Properties changes
+
+		// LastScanChanged sends the new LastScan value to ch each time a scan finishes.
+		LastScanChanged(ch chan<- int64) error
+	}
+
+	wirelessDevice struct {
+		device
+	}
+)
+
+var _ WirelessDevice = (*wirelessDevice)(nil)
+
+func (*wirelessDevice) isWirelessDevice() {}
+
+func (d *wirelessDevice) GetAccessPoints() ([]AccessPoint, error) {
+	return d.getAccessPoints(WirelessDeviceIface + ".GetAccessPoints")
+}
+
+func (d *wirelessDevice) GetAllAccessPoints() ([]AccessPoint, error) {
+	return d.getAccessPoints(WirelessDeviceIface + ".GetAllAccessPoints")
+}
+
+func (d *wirelessDevice) getAccessPoints(method string) ([]AccessPoint, error) {
+	var paths []dbus.ObjectPath
+	if err := d.CallAndStore(method, nil, dbusext.Args{&paths}); err != nil {
+		return nil, err
+	}
+	return NewAccessPoints(d.Conn, paths), nil
+}
+
+func (d *wirelessDevice) RequestScan(options map[string]interface{}) error {
+	if options == nil {
+		options = map[string]interface{}{}
+	}
+	return d.CallAndStore(WirelessDeviceIface+".RequestScan", dbusext.Args{options}, nil)
+}
+
+func (d *wirelessDevice) HwAddress() (string, error) {
+	return d.GetSProperty(WirelessDeviceIface + ".HwAddress")
+}
+
+func (d *wirelessDevice) PermHwAddress() (string, error) {
+	return d.GetSProperty(WirelessDeviceIface + ".PermHwAddress")
+}
+
+func (d *wirelessDevice) Mode() (Wifi80211Mode, error) {
+	mode, err := d.GetUProperty(WirelessDeviceIface + ".Mode")
+	return Wifi80211Mode(mode), err
+}
+
+func (d *wirelessDevice) Bitrate() (uint32, error) {
+	return d.GetUProperty(WirelessDeviceIface + ".Bitrate")
+}
+
+func (d *wirelessDevice) AccessPoints() ([]AccessPoint, error) {
+	paths, err := d.GetAOProperty(WirelessDeviceIface + ".AccessPoints")
+	if err != nil {
+		return nil, err
+	}
+	return NewAccessPoints(d.Conn, paths), nil
+}
+
+func (d *wirelessDevice) ActiveAccessPoint() (AccessPoint, error) {
+	path, err := d.GetOProperty(WirelessDeviceIface + ".ActiveAccessPoint")
+	if err != nil || path == "/" {
+		return nil, err
+	}
+	return NewAccessPoint(d.Conn, path), nil
+}
+
+func (d *wirelessDevice) WirelessCapabilities() (WifiDeviceCapabilities, error) {
+	capabilities, err := d.GetUProperty(WirelessDeviceIface + ".WirelessCapabilities")
+	return WifiDeviceCapabilities(capabilities), err
+}
+
+func (d *wirelessDevice) LastScan() (int64, error) {
+	return d.GetXProperty(WirelessDeviceIface + ".LastScan")
+}
+
+func (d *wirelessDevice) LastScanChanged(ch chan<- int64) error {
+	return d.PropertyChanged(WirelessDeviceIface, "LastScan", reflect.TypeOf(int64(0)), ch, nil)
+}
+
+// WifiDeviceCapabilities are the 802.11 specific device encryption and authentication capabilities.
+//
+// See https://developer.gnome.org/NetworkManager/stable/nm-dbus-types.html#NMDeviceWifiCapabilities for more information.
+type WifiDeviceCapabilities uint
+
+const (
+	// WifiDeviceCapNone means device has no encryption/authentication capabilities.
+	WifiDeviceCapNone WifiDeviceCapabilities = 0
+
+	// WifiDeviceCapCipherWep40 means device supports 40/64-bit WEP encryption.
+	WifiDeviceCapCipherWep40 WifiDeviceCapabilities = 1 << (iota - 1)
+
+	// WifiDeviceCapCipherWep104 means device supports 104/128-bit WEP encryption.
+	WifiDeviceCapCipherWep104
+
+	// WifiDeviceCapCipherTkip means device supports TKIP encryption.
+	WifiDeviceCapCipherTkip
+
+	// WifiDeviceCapCipherCcmp means device supports AES/CCMP encryption.
+	WifiDeviceCapCipherCcmp
+
+	// WifiDeviceCapWpa means device supports WPA1 authentication.
+	WifiDeviceCapWpa
+
+	// WifiDeviceCapRsn means device supports WPA2/RSN authentication.
+	WifiDeviceCapRsn
+
+	// WifiDeviceCapAp means device supports Access Point mode.
+	WifiDeviceCapAp
+
+	// WifiDeviceCapAdhoc means device supports Ad-Hoc mode.
+	WifiDeviceCapAdhoc
+
+	// WifiDeviceCapFreqValid means device reports frequency capabilities.
+	WifiDeviceCapFreqValid
+
+	// WifiDeviceCapFreq2GHz means device supports 2.4GHz frequencies.
+	WifiDeviceCapFreq2GHz
+
+	// WifiDeviceCapFreq5GHz means device supports 5GHz frequencies.
+	WifiDeviceCapFreq5GHz
+
+	// WifiDeviceCapFreq6GHz means device supports 6GHz frequencies.
+	WifiDeviceCapFreq6GHz
+
+	// WifiDeviceCapMesh means device supports acting as a mesh point.
+	WifiDeviceCapMesh
+
+	// WifiDeviceCapIbssRsn means device supports WPA2/RSN in an IBSS network.
+	WifiDeviceCapIbssRsn
+)
+
+var wifiDeviceCapabilitiesNames = []enums.Name{
+	{Value: uint(WifiDeviceCapCipherWep40), Name: "NM_WIFI_DEVICE_CAP_CIPHER_WEP40"},
+	{Value: uint(WifiDeviceCapCipherWep104), Name: "NM_WIFI_DEVICE_CAP_CIPHER_WEP104"},
+	{Value: uint(WifiDeviceCapCipherTkip), Name: "NM_WIFI_DEVICE_CAP_CIPHER_TKIP"},
+	{Value: uint(WifiDeviceCapCipherCcmp), Name: "NM_WIFI_DEVICE_CAP_CIPHER_CCMP"},
+	{Value: uint(WifiDeviceCapWpa), Name: "NM_WIFI_DEVICE_CAP_WPA"},
+	{Value: uint(WifiDeviceCapRsn), Name: "NM_WIFI_DEVICE_CAP_RSN"},
+	{Value: uint(WifiDeviceCapAp), Name: "NM_WIFI_DEVICE_CAP_AP"},
+	{Value: uint(WifiDeviceCapAdhoc), Name: "NM_WIFI_DEVICE_CAP_ADHOC"},
+	{Value: uint(WifiDeviceCapFreqValid), Name: "NM_WIFI_DEVICE_CAP_FREQ_VALID"},
+	{Value: uint(WifiDeviceCapFreq2GHz), Name: "NM_WIFI_DEVICE_CAP_FREQ_2GHZ"},
+	{Value: uint(WifiDeviceCapFreq5GHz), Name: "NM_WIFI_DEVICE_CAP_FREQ_5GHZ"},
+	{Value: uint(WifiDeviceCapFreq6GHz), Name: "NM_WIFI_DEVICE_CAP_FREQ_6GHZ"},
+	{Value: uint(WifiDeviceCapMesh), Name: "NM_WIFI_DEVICE_CAP_MESH"},
+	{Value: uint(WifiDeviceCapIbssRsn), Name: "NM_WIFI_DEVICE_CAP_IBSS_RSN"},
+}
+
+func (c WifiDeviceCapabilities) String() string {
+	return enums.FlagsString(uint(c), "NM_WIFI_DEVICE_CAP_NONE", wifiDeviceCapabilitiesNames)
+}
+
+// ParseWifiDeviceCapabilities returns the WifiDeviceCapabilities corresponding to s, as returned by WifiDeviceCapabilities.String.
+func ParseWifiDeviceCapabilities(s string) (WifiDeviceCapabilities, error) {
+	v, err := enums.ParseFlags("WifiDeviceCapabilities", s, "NM_WIFI_DEVICE_CAP_NONE", wifiDeviceCapabilitiesNames)
+	return WifiDeviceCapabilities(v), err
+}
+
+// Bits decomposes c into its single flags.
+func (c WifiDeviceCapabilities) Bits() []WifiDeviceCapabilities {
+	bits := enums.Bits(uint(c))
+	flags := make([]WifiDeviceCapabilities, len(bits))
+	for i, bit := range bits {
+		flags[i] = WifiDeviceCapabilities(bit)
+	}
+	return flags
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (c WifiDeviceCapabilities) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (c *WifiDeviceCapabilities) UnmarshalText(text []byte) error {
+	v, err := ParseWifiDeviceCapabilities(string(text))
+	if err != nil {
+		return err
+	}
+	*c = v
+	return nil
+}
@@ -0,0 +1,75 @@
+package netmgr
+
+const (
+	// WpanDeviceIface is the Wpan Device interface.
+	WpanDeviceIface = "org.freedesktop.NetworkManager.Device.Wpan"
+
+	// LowpanDeviceIface is the Lowpan Device interface.
+	LowpanDeviceIface = "org.freedesktop.NetworkManager.Device.Lowpan"
+)
+
+type (
+	// WpanDevice represents an IEEE 802.15.4 (WPAN) MAC layer device, its 6LoWPAN interfaces are LowpanDevice.
+	//
+	// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Wpan.html for more information.
+	WpanDevice interface {
+		Device
+		isWpanDevice()
+
+		// Properties
+
+		// HwAddress is the hardware address of the device.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Wpan.html#gdbus-property-org-freedesktop-NetworkManager-Device-Wpan.HwAddress for more information.
+		HwAddress() (string, error)
+	}
+
+	wpanDevice struct {
+		device
+	}
+
+	// LowpanDevice represents a 6LoWPAN interface, on top of a WpanDevice.
+	//
+	// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Lowpan.html for more information.
+	LowpanDevice interface {
+		Device
+		isLowpanDevice()
+
+		// Properties
+
+		// HwAddress is the hardware address of the device.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Lowpan.html#gdbus-property-org-freedesktop-NetworkManager-Device-Lowpan.HwAddress for more information.
+		HwAddress() (string, error)
+
+		// Parent is the parent device of the 6LoWPAN interface, nil if none.
+		//
+		// See https://developer.gnome.org/NetworkManager/stable/gdbus-org.freedesktop.NetworkManager.Device.Lowpan.html#gdbus-property-org-freedesktop-NetworkManager-Device-Lowpan.Parent for more information.
+		Parent() (Device, error)
+	}
+
+	lowpanDevice struct {
+		device
+	}
+)
+
+var (
+	_ WpanDevice   = (*wpanDevice)(nil)
+	_ LowpanDevice = (*lowpanDevice)(nil)
+)
+
+func (*wpanDevice) isWpanDevice() {}
+
+func (*lowpanDevice) isLowpanDevice() {}
+
+func (d *wpanDevice) HwAddress() (string, error) {
+	return d.GetSProperty(WpanDeviceIface + ".HwAddress")
+}
+
+func (d *lowpanDevice) HwAddress() (string, error) {
+	return d.GetSProperty(LowpanDeviceIface + ".HwAddress")
+}
+
+func (d *lowpanDevice) Parent() (Device, error) {
+	return d.parent(LowpanDeviceIface)
+}